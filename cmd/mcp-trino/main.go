@@ -14,21 +14,44 @@
 //
 //	# Run the server (stdio transport for Claude Desktop)
 //	./mcp-trino
+//
+//	# Or serve many clients over Streamable HTTP
+//	./mcp-trino --transport http --http-addr :8443 --tls-cert server.crt --tls-key server.key
+//
+//	# Or drive everything from a YAML/JSON config file
+//	./mcp-trino --config /etc/mcp-trino/config.yaml
 package main
 
 import (
 	"context"
+	"flag"
 	"log"
 	"os"
 	"os/signal"
 	"syscall"
 
-	"github.com/modelcontextprotocol/go-sdk/mcp"
-
 	"github.com/txn2/mcp-trino/internal/server"
 )
 
 func main() {
+	flags := parseFlags(os.Args[1:])
+
+	// Load options from the config file (if any) and environment
+	opts, err := server.LoadOptions(flags.configPath)
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	if flags.configPath != "" {
+		//nolint:gosec // G706: value from operator-controlled flag/env var
+		log.Printf("Loaded configuration from %s", flags.configPath)
+	}
+
+	// Command-line flags override the config file and environment variables
+	flags.apply(&opts.Transport)
+	if err := opts.Transport.Validate(); err != nil {
+		log.Fatalf("Invalid transport configuration: %v", err)
+	}
+
 	// Setup context with cancellation
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -42,8 +65,6 @@ func main() {
 		cancel()
 	}()
 
-	// Create server with default options (from environment)
-	opts := server.DefaultOptions()
 	mcpServer, mgr, err := server.New(opts)
 	if err != nil {
 		log.Fatalf("Failed to create server: %v", err)
@@ -72,9 +93,17 @@ func main() {
 		mgr.ConnectionCount(),
 		defaultHost,
 	)
+	if opts.Transport.Mode == server.TransportHTTP {
+		//nolint:gosec // G706: values from operator-controlled flags/env vars
+		log.Printf("Serving Streamable HTTP on %s%s (tls: %t)",
+			opts.Transport.HTTP.Address, opts.Transport.HTTP.Path, opts.Transport.HTTP.TLSEnabled())
+		if opts.Transport.HTTP.Auth.Enabled() {
+			log.Printf("Bearer token authentication enabled (impersonation: %t)", opts.ToolkitConfig.Impersonate)
+		}
+	}
 
-	// Run server with stdio transport
-	if err := mcpServer.Run(ctx, &mcp.StdioTransport{}); err != nil {
+	// Run server on the selected transport
+	if err := server.Run(ctx, mcpServer, opts.Transport); err != nil {
 		if ctx.Err() != nil {
 			// Context canceled, normal shutdown
 			log.Println("Server stopped")
@@ -83,3 +112,58 @@ func main() {
 		log.Fatalf("Server error: %v", err)
 	}
 }
+
+// cliFlags holds parsed command-line flags.
+type cliFlags struct {
+	configPath string
+	transport  server.TransportConfig
+	set        map[string]bool
+}
+
+// parseFlags parses command-line flags. Transport flags are recorded so
+// that only flags given explicitly override the loaded configuration.
+func parseFlags(args []string) cliFlags {
+	defaults := server.DefaultTransportConfig()
+	f := cliFlags{set: make(map[string]bool)}
+
+	fs := flag.NewFlagSet("mcp-trino", flag.ExitOnError)
+	fs.StringVar(&f.configPath, "config", os.Getenv("MCP_TRINO_CONFIG"), "YAML or JSON config file (env MCP_TRINO_CONFIG)")
+	fs.StringVar(&f.transport.Mode, "transport", defaults.Mode, "Transport to serve on: stdio or http (env MCP_TRINO_TRANSPORT)")
+	fs.StringVar(&f.transport.HTTP.Address, "http-addr", defaults.HTTP.Address,
+		"Listen address for the http transport (env MCP_TRINO_HTTP_ADDR)")
+	fs.StringVar(&f.transport.HTTP.Path, "http-path", defaults.HTTP.Path, "URL path of the MCP endpoint (env MCP_TRINO_HTTP_PATH)")
+	fs.StringVar(&f.transport.HTTP.TLSCertFile, "tls-cert", "", "TLS certificate file (env MCP_TRINO_TLS_CERT)")
+	fs.StringVar(&f.transport.HTTP.TLSKeyFile, "tls-key", "", "TLS private key file (env MCP_TRINO_TLS_KEY)")
+	fs.BoolVar(&f.transport.HTTP.Stateless, "stateless", false, "Disable HTTP session tracking (env MCP_TRINO_HTTP_STATELESS)")
+	fs.DurationVar(&f.transport.HTTP.SessionTimeout, "session-timeout", defaults.HTTP.SessionTimeout,
+		"Close idle HTTP sessions after this duration, 0 to disable (env MCP_TRINO_SESSION_TIMEOUT)")
+	_ = fs.Parse(args) //nolint:errcheck // ExitOnError handles failures
+
+	fs.Visit(func(fl *flag.Flag) { f.set[fl.Name] = true })
+	return f
+}
+
+// apply copies explicitly given transport flags into cfg.
+func (f cliFlags) apply(cfg *server.TransportConfig) {
+	if f.set["transport"] {
+		cfg.Mode = f.transport.Mode
+	}
+	if f.set["http-addr"] {
+		cfg.HTTP.Address = f.transport.HTTP.Address
+	}
+	if f.set["http-path"] {
+		cfg.HTTP.Path = f.transport.HTTP.Path
+	}
+	if f.set["tls-cert"] {
+		cfg.HTTP.TLSCertFile = f.transport.HTTP.TLSCertFile
+	}
+	if f.set["tls-key"] {
+		cfg.HTTP.TLSKeyFile = f.transport.HTTP.TLSKeyFile
+	}
+	if f.set["stateless"] {
+		cfg.HTTP.Stateless = f.transport.HTTP.Stateless
+	}
+	if f.set["session-timeout"] {
+		cfg.HTTP.SessionTimeout = f.transport.HTTP.SessionTimeout
+	}
+}
@@ -3,6 +3,7 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -43,16 +44,75 @@ type Options struct {
 	// SemanticCacheConfig configures caching for the semantic provider.
 	// If nil, default caching (5 minute TTL) is applied when a provider is configured.
 	SemanticCacheConfig *semantic.CacheConfig
+
+	// Transport selects how the server is exposed (stdio or Streamable HTTP).
+	// It is not used by New; pass it to Run.
+	Transport TransportConfig
 }
 
 // DefaultOptions returns default server options.
 // Note: MultiServerConfig is loaded from environment when nil.
 func DefaultOptions() Options {
+	transport := TransportFromEnv()
+
+	// Run queries as the authenticated caller when HTTP auth is configured
+	toolkitCfg := tools.DefaultConfig()
+	toolkitCfg.Impersonate = transport.HTTP.Auth.Enabled() && transport.HTTP.Auth.Impersonate
+
 	return Options{
 		MultiServerConfig: nil, // Loaded from env in New()
-		ToolkitConfig:     tools.DefaultConfig(),
+		ToolkitConfig:     toolkitCfg,
 		ExtensionsConfig:  extensions.FromEnv(),
+		Transport:         transport,
+	}
+}
+
+// LoadOptions returns server options for the binary. When path is empty the
+// options come from environment variables (see DefaultOptions); otherwise
+// they are loaded from the config file at path, with environment variables
+// overriding file values.
+func LoadOptions(path string) (Options, error) {
+	if path == "" {
+		return DefaultOptions(), nil
+	}
+	return OptionsFromFile(path)
+}
+
+// OptionsFromFile loads server options from a YAML or JSON config file.
+// Environment variables override file values (see extensions.LoadConfig).
+// Semantic providers declared in the file are created immediately.
+func OptionsFromFile(path string) (Options, error) {
+	fileCfg, err := extensions.LoadConfig(path)
+	if err != nil {
+		return Options{}, err
+	}
+
+	extCfg := fileCfg.ExtConfig()
+	if extCfg.AccessPolicy, err = fileCfg.AccessPolicy(); err != nil {
+		return Options{}, err
 	}
+
+	provider, err := fileCfg.SemanticProvider()
+	if err != nil {
+		return Options{}, err
+	}
+	cacheCfg := fileCfg.SemanticCacheConfig()
+
+	msCfg := fileCfg.MultiServerConfig()
+	transport := transportFromFile(fileCfg)
+
+	toolkitCfg := fileCfg.ToolsConfig()
+	toolkitCfg.Impersonate = transport.HTTP.Auth.Enabled() && transport.HTTP.Auth.Impersonate
+
+	return Options{
+		MultiServerConfig:   &msCfg,
+		ToolkitConfig:       toolkitCfg,
+		ExtensionsConfig:    extCfg,
+		Descriptions:        fileCfg.DescriptionsMap(),
+		SemanticProvider:    provider,
+		SemanticCacheConfig: &cacheCfg,
+		Transport:           transport,
+	}, nil
 }
 
 // New creates a new MCP server with Trino tools.
@@ -80,12 +140,6 @@ func New(opts Options) (*mcp.Server, *multiserver.Manager, error) {
 	// Create connection manager
 	mgr := multiserver.NewManager(msCfg)
 
-	// Create MCP server
-	server := mcp.NewServer(&mcp.Implementation{
-		Name:    "mcp-trino",
-		Version: Version,
-	}, nil)
-
 	// Build toolkit options from extensions configuration
 	toolkitOpts := extensions.BuildToolkitOptions(opts.ExtensionsConfig)
 
@@ -125,9 +179,7 @@ func New(opts Options) (*mcp.Server, *multiserver.Manager, error) {
 
 	// Add semantic provider to toolkit options if configured
 	if semanticProvider != nil {
-		toolkitOpts = append(toolkitOpts, tools.WithSemanticProvider(semanticProvider))
-
-		// Apply caching
+		// Apply caching here, so the sensitive column guard shares the cache
 		cacheConfig := opts.SemanticCacheConfig
 		if cacheConfig == nil {
 			// Default: 5 minute TTL, 10000 entries
@@ -136,12 +188,67 @@ func New(opts Options) (*mcp.Server, *multiserver.Manager, error) {
 				MaxEntries: 10000,
 			}
 		}
-		toolkitOpts = append(toolkitOpts, tools.WithSemanticCache(*cacheConfig))
+		semanticProvider = semantic.NewCachingProvider(semanticProvider, *cacheConfig)
+		toolkitOpts = append(toolkitOpts, tools.WithSemanticProvider(semanticProvider))
+	}
+
+	// Add the extensions that need the semantic provider and Trino clients
+	semanticOpts, err := semanticExtensionOptions(opts.ExtensionsConfig, semanticProvider, mgr)
+	if err != nil {
+		return nil, nil, err
 	}
+	toolkitOpts = append(toolkitOpts, semanticOpts...)
 
-	// Create toolkit with multi-server manager and register tools
+	// Create toolkit with multi-server manager
 	toolkit := tools.NewToolkitWithManager(mgr, opts.ToolkitConfig, toolkitOpts...)
+
+	// Create MCP server, completing catalog/schema/table arguments from the toolkit
+	server := mcp.NewServer(&mcp.Implementation{
+		Name:    "mcp-trino",
+		Version: Version,
+	}, &mcp.ServerOptions{
+		CompletionHandler: toolkit.Complete,
+	})
+
+	// Register tools, resources, and prompts
 	toolkit.RegisterAll(server)
+	toolkit.RegisterResources(server)
+	toolkit.RegisterPrompts(server)
 
 	return server, mgr, nil
 }
+
+// semanticExtensionOptions builds the sensitive column guard and the
+// redactor, the extensions that need the semantic provider and Trino
+// clients, as configured in cfg.
+func semanticExtensionOptions(
+	cfg extensions.Config, provider semantic.Provider, mgr *multiserver.Manager,
+) ([]tools.ToolkitOption, error) {
+	var opts []tools.ToolkitOption
+
+	// Guard queries that read sensitive columns
+	if cfg.SensitiveColumns != nil {
+		if provider == nil {
+			return nil, errors.New("sensitive_columns requires a semantic provider")
+		}
+		guard, err := extensions.NewSensitiveColumnGuard(provider, extensions.ManagerDescriber(mgr), *cfg.SensitiveColumns)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, tools.WithQueryInterceptor(guard))
+	}
+
+	// Redact query results
+	if cfg.Redaction != nil {
+		if cfg.Redaction.SensitiveColumns && provider == nil {
+			return nil, errors.New("redaction.sensitive_columns requires a semantic provider")
+		}
+		redactor, err := extensions.NewRedactor(provider, extensions.ManagerDescriber(mgr), *cfg.Redaction)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, tools.WithQueryOutputTransformer(redactor))
+	}
+
+	return opts, nil
+}
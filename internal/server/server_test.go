@@ -7,7 +7,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
 	"github.com/txn2/mcp-trino/pkg/client"
+	"github.com/txn2/mcp-trino/pkg/extensions"
 	"github.com/txn2/mcp-trino/pkg/multiserver"
 	"github.com/txn2/mcp-trino/pkg/semantic"
 	"github.com/txn2/mcp-trino/pkg/tools"
@@ -258,6 +261,56 @@ func TestNew_MultipleConnections(t *testing.T) {
 	}
 }
 
+func TestNew_CompletionsAndPrompts(t *testing.T) {
+	ctx := context.Background()
+	opts := Options{
+		MultiServerConfig: &multiserver.Config{
+			Default: "default",
+			Primary: client.Config{Host: "localhost", Port: 8080, User: "admin"},
+			Connections: map[string]multiserver.ConnectionConfig{
+				"staging": {Host: "staging.example.com"},
+			},
+		},
+		ToolkitConfig: tools.DefaultConfig(),
+	}
+
+	server, mgr, err := New(opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer func() { _ = mgr.Close() }()
+
+	t1, t2 := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, t1, nil)
+	if err != nil {
+		t.Fatalf("server connect: %v", err)
+	}
+	defer func() { _ = serverSession.Close() }()
+
+	mcpClient := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0"}, nil)
+	session, err := mcpClient.Connect(ctx, t2, nil)
+	if err != nil {
+		t.Fatalf("client connect: %v", err)
+	}
+	defer func() { _ = session.Close() }()
+
+	prompts, err := session.ListPrompts(ctx, nil)
+	if err != nil || len(prompts.Prompts) == 0 {
+		t.Fatalf("expected built-in prompts, got %v (err %v)", prompts, err)
+	}
+
+	result, err := session.Complete(ctx, &mcp.CompleteParams{
+		Ref:      &mcp.CompleteReference{Type: "ref/resource", URI: tools.ResourceCatalogsTemplate},
+		Argument: mcp.CompleteParamsArgument{Name: "connection", Value: "st"},
+	})
+	if err != nil {
+		t.Fatalf("Complete error: %v", err)
+	}
+	if len(result.Completion.Values) != 1 || result.Completion.Values[0] != "staging" {
+		t.Errorf("expected staging, got %v", result.Completion.Values)
+	}
+}
+
 func TestDefaultOptions_DescriptionsNil(t *testing.T) {
 	opts := DefaultOptions()
 
@@ -339,6 +392,91 @@ func TestNew_WithSemanticProvider(t *testing.T) {
 	}
 }
 
+func TestNew_SensitiveColumns(t *testing.T) {
+	msCfg := &multiserver.Config{
+		Default: "default",
+		Primary: client.Config{Host: "localhost", Port: 8080, User: "admin"},
+	}
+	provider := &semantic.ProviderFunc{NameFn: func() string { return "mock" }}
+
+	tests := []struct {
+		name     string
+		provider semantic.Provider
+		cfg      extensions.SensitiveColumnConfig
+		wantErr  bool
+	}{
+		{name: "with provider", provider: provider, cfg: extensions.SensitiveColumnConfig{Mode: extensions.SensitiveAcknowledge}},
+		{name: "without provider", cfg: extensions.SensitiveColumnConfig{}, wantErr: true},
+		{name: "invalid config", provider: provider, cfg: extensions.SensitiveColumnConfig{Mode: "warn"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("SEMANTIC_FILE", "")
+			extCfg := extensions.DefaultConfig()
+			extCfg.SensitiveColumns = &tt.cfg
+
+			_, mgr, err := New(Options{
+				MultiServerConfig: msCfg,
+				ToolkitConfig:     tools.DefaultConfig(),
+				ExtensionsConfig:  extCfg,
+				SemanticProvider:  tt.provider,
+			})
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			_ = mgr.Close()
+		})
+	}
+}
+
+func TestNew_Redaction(t *testing.T) {
+	t.Setenv("SEMANTIC_FILE", "")
+	msCfg := &multiserver.Config{
+		Default: "default",
+		Primary: client.Config{Host: "localhost", Port: 8080, User: "admin"},
+	}
+
+	tests := []struct {
+		name    string
+		cfg     extensions.RedactionConfig
+		wantErr bool
+	}{
+		{name: "detectors only", cfg: extensions.RedactionConfig{Detectors: []string{"email"}}},
+		{name: "sensitive columns without provider", cfg: extensions.RedactionConfig{SensitiveColumns: true}, wantErr: true},
+		{name: "invalid detector", cfg: extensions.RedactionConfig{Detectors: []string{"passport"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			extCfg := extensions.DefaultConfig()
+			extCfg.Redaction = &tt.cfg
+
+			_, mgr, err := New(Options{
+				MultiServerConfig: msCfg,
+				ToolkitConfig:     tools.DefaultConfig(),
+				ExtensionsConfig:  extCfg,
+			})
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			_ = mgr.Close()
+		})
+	}
+}
+
 func TestNew_WithSemanticCacheConfig(t *testing.T) {
 	// Create a mock semantic provider
 	mockProvider := &semantic.ProviderFunc{
@@ -490,3 +628,112 @@ func TestNew_SemanticProviderTakesPrecedenceOverEnv(t *testing.T) {
 	// The explicit provider should be used, not the one from SEMANTIC_FILE
 	// (verified by the fact that we're using mockProvider with name "explicit-provider")
 }
+
+func TestLoadOptions_FromFile(t *testing.T) {
+	dir := t.TempDir()
+	semanticPath := filepath.Join(dir, "semantic.yaml")
+	if err := os.WriteFile(semanticPath, []byte("tables: []\n"), 0o600); err != nil {
+		t.Fatalf("failed to write semantic file: %v", err)
+	}
+
+	configPath := filepath.Join(dir, "config.yaml")
+	configYAML := `
+trino:
+  connection_name: prod
+  host: trino.example.com
+  user: service
+connections:
+  staging:
+    host: staging.example.com
+toolkit:
+  default_limit: 250
+  descriptions:
+    trino_query: Custom query description
+extensions:
+  readonly: true
+semantic:
+  providers:
+    - type: static
+      file: ` + semanticPath + `
+  cache:
+    ttl: 1m
+transport:
+  mode: http
+  address: 127.0.0.1:9000
+  auth:
+    hmac_secret: secret
+`
+	if err := os.WriteFile(configPath, []byte(configYAML), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	opts, err := LoadOptions(configPath)
+	if err != nil {
+		t.Fatalf("LoadOptions() error: %v", err)
+	}
+
+	if opts.MultiServerConfig == nil || opts.MultiServerConfig.Default != "prod" {
+		t.Fatalf("unexpected multi-server config: %+v", opts.MultiServerConfig)
+	}
+	if opts.MultiServerConfig.ConnectionCount() != 2 {
+		t.Errorf("expected 2 connections, got %d", opts.MultiServerConfig.ConnectionCount())
+	}
+	if opts.ToolkitConfig.DefaultLimit != 250 {
+		t.Errorf("expected default limit 250, got %d", opts.ToolkitConfig.DefaultLimit)
+	}
+	if !opts.ToolkitConfig.Impersonate {
+		t.Error("expected impersonation enabled with auth configured")
+	}
+	if opts.Descriptions[tools.ToolQuery] != "Custom query description" {
+		t.Errorf("unexpected descriptions: %v", opts.Descriptions)
+	}
+	if !opts.ExtensionsConfig.EnableReadOnly {
+		t.Error("expected read-only extension enabled")
+	}
+	if opts.SemanticProvider == nil {
+		t.Fatal("expected semantic provider")
+	}
+	defer func() { _ = opts.SemanticProvider.Close() }()
+	if opts.SemanticCacheConfig == nil || opts.SemanticCacheConfig.TTL != time.Minute {
+		t.Errorf("unexpected semantic cache config: %+v", opts.SemanticCacheConfig)
+	}
+	if opts.Transport.Mode != TransportHTTP || opts.Transport.HTTP.Address != "127.0.0.1:9000" {
+		t.Errorf("unexpected transport: %+v", opts.Transport)
+	}
+	if opts.Transport.HTTP.Path != DefaultHTTPPath {
+		t.Errorf("expected default path, got %q", opts.Transport.HTTP.Path)
+	}
+
+	mcpServer, mgr, err := New(opts)
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	defer func() { _ = mgr.Close() }()
+	if mcpServer == nil {
+		t.Fatal("expected server")
+	}
+}
+
+func TestLoadOptions_Errors(t *testing.T) {
+	if _, err := LoadOptions(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("expected error for missing config file")
+	}
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte("semantic:\n  providers:\n    - type: bogus\n"), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	if _, err := LoadOptions(configPath); err == nil {
+		t.Error("expected error for unknown semantic provider")
+	}
+}
+
+func TestLoadOptions_NoFile(t *testing.T) {
+	opts, err := LoadOptions("")
+	if err != nil {
+		t.Fatalf("LoadOptions() error: %v", err)
+	}
+	if opts.MultiServerConfig != nil {
+		t.Error("expected MultiServerConfig to be loaded from env in New()")
+	}
+}
@@ -0,0 +1,321 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	mcpauth "github.com/modelcontextprotocol/go-sdk/auth"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/txn2/mcp-trino/pkg/auth"
+	"github.com/txn2/mcp-trino/pkg/extensions"
+)
+
+// Transport names accepted by TransportConfig.Mode.
+const (
+	// TransportStdio serves a single MCP client over stdin/stdout.
+	TransportStdio = "stdio"
+
+	// TransportHTTP serves many MCP clients over the Streamable HTTP transport.
+	TransportHTTP = "http"
+)
+
+// Default HTTP transport settings.
+const (
+	DefaultHTTPAddress    = ":8080"
+	DefaultHTTPPath       = "/mcp"
+	DefaultSessionTimeout = 30 * time.Minute
+	defaultShutdownGrace  = 10 * time.Second
+	defaultHeaderTimeout  = 10 * time.Second
+)
+
+// TransportConfig selects and configures the transport the server is exposed on.
+type TransportConfig struct {
+	// Mode is the transport to use: "stdio" (default) or "http".
+	Mode string
+
+	// HTTP configures the Streamable HTTP transport. Ignored for stdio.
+	HTTP HTTPConfig
+}
+
+// HTTPConfig configures the Streamable HTTP transport.
+type HTTPConfig struct {
+	// Address is the listen address (host:port). Default: ":8080".
+	Address string
+
+	// Path is the URL path the MCP endpoint is mounted on. Default: "/mcp".
+	Path string
+
+	// TLSCertFile and TLSKeyFile enable HTTPS when both are set.
+	TLSCertFile string
+	TLSKeyFile  string
+
+	// Stateless disables session tracking. Each request is served by a
+	// temporary session, so server-to-client requests are not available.
+	Stateless bool
+
+	// SessionTimeout closes sessions that receive no requests for this long.
+	// Default: 30m. Zero after normalization keeps sessions until the client
+	// disconnects.
+	SessionTimeout time.Duration
+
+	// JSONResponse returns application/json responses instead of
+	// text/event-stream streams.
+	JSONResponse bool
+
+	// Auth configures bearer token authentication. Requests are rejected
+	// with 401 unless they carry a valid token when a key source is set.
+	Auth auth.Config
+}
+
+// DefaultTransportConfig returns a TransportConfig using the stdio transport.
+func DefaultTransportConfig() TransportConfig {
+	return TransportConfig{
+		Mode: TransportStdio,
+		HTTP: HTTPConfig{
+			Address:        DefaultHTTPAddress,
+			Path:           DefaultHTTPPath,
+			SessionTimeout: DefaultSessionTimeout,
+			Auth:           auth.DefaultConfig(),
+		},
+	}
+}
+
+// TransportFromEnv loads transport configuration from environment variables.
+// Environment variables:
+//   - MCP_TRINO_TRANSPORT: stdio (default) or http
+//   - MCP_TRINO_HTTP_ADDR: Listen address for the HTTP transport
+//   - MCP_TRINO_HTTP_PATH: URL path of the MCP endpoint
+//   - MCP_TRINO_TLS_CERT: TLS certificate file
+//   - MCP_TRINO_TLS_KEY: TLS private key file
+//   - MCP_TRINO_HTTP_STATELESS: Disable session tracking (true/false)
+//   - MCP_TRINO_SESSION_TIMEOUT: Idle session timeout (e.g. "30m", or seconds)
+//   - MCP_TRINO_HTTP_JSON_RESPONSE: Use JSON responses instead of SSE (true/false)
+//
+// Bearer token authentication is loaded with auth.FromEnv (MCP_TRINO_AUTH_*).
+func TransportFromEnv() TransportConfig {
+	cfg := DefaultTransportConfig()
+
+	if v := os.Getenv("MCP_TRINO_TRANSPORT"); v != "" {
+		cfg.Mode = strings.ToLower(strings.TrimSpace(v))
+	}
+	if v := os.Getenv("MCP_TRINO_HTTP_ADDR"); v != "" {
+		cfg.HTTP.Address = v
+	}
+	if v := os.Getenv("MCP_TRINO_HTTP_PATH"); v != "" {
+		cfg.HTTP.Path = v
+	}
+	if v := os.Getenv("MCP_TRINO_TLS_CERT"); v != "" {
+		cfg.HTTP.TLSCertFile = v
+	}
+	if v := os.Getenv("MCP_TRINO_TLS_KEY"); v != "" {
+		cfg.HTTP.TLSKeyFile = v
+	}
+	if v := os.Getenv("MCP_TRINO_HTTP_STATELESS"); v != "" {
+		cfg.HTTP.Stateless = envBool(v)
+	}
+	if v := os.Getenv("MCP_TRINO_SESSION_TIMEOUT"); v != "" {
+		if d, ok := parseEnvDuration(v); ok {
+			cfg.HTTP.SessionTimeout = d
+		}
+	}
+	if v := os.Getenv("MCP_TRINO_HTTP_JSON_RESPONSE"); v != "" {
+		cfg.HTTP.JSONResponse = envBool(v)
+	}
+	cfg.HTTP.Auth = auth.FromEnv()
+
+	return cfg
+}
+
+// transportFromFile converts the transport section of a config file.
+// Unset fields keep their defaults.
+func transportFromFile(fileCfg extensions.ServerConfig) TransportConfig {
+	cfg := DefaultTransportConfig()
+	t := fileCfg.Transport
+
+	if t.Mode != "" {
+		cfg.Mode = strings.ToLower(strings.TrimSpace(t.Mode))
+	}
+	if t.Address != "" {
+		cfg.HTTP.Address = t.Address
+	}
+	if t.Path != "" {
+		cfg.HTTP.Path = t.Path
+	}
+	cfg.HTTP.TLSCertFile = t.TLSCert
+	cfg.HTTP.TLSKeyFile = t.TLSKey
+	if t.Stateless != nil {
+		cfg.HTTP.Stateless = *t.Stateless
+	}
+	if t.SessionTimeout != nil {
+		cfg.HTTP.SessionTimeout = t.SessionTimeout.Duration()
+	}
+	if t.JSONResponse != nil {
+		cfg.HTTP.JSONResponse = *t.JSONResponse
+	}
+	cfg.HTTP.Auth = fileCfg.AuthConfig()
+
+	return cfg
+}
+
+// Validate checks that the transport configuration is usable.
+func (c TransportConfig) Validate() error {
+	switch c.Mode {
+	case "", TransportStdio:
+		return nil
+	case TransportHTTP:
+		return c.HTTP.Validate()
+	default:
+		return fmt.Errorf("unknown transport %q (use %q or %q)", c.Mode, TransportStdio, TransportHTTP)
+	}
+}
+
+// Validate checks that the HTTP configuration is usable.
+func (c HTTPConfig) Validate() error {
+	if (c.TLSCertFile == "") != (c.TLSKeyFile == "") {
+		return fmt.Errorf("both TLS certificate and key must be set to enable TLS")
+	}
+	if c.Path != "" && !strings.HasPrefix(c.Path, "/") {
+		return fmt.Errorf("HTTP path must start with '/': %q", c.Path)
+	}
+	if c.SessionTimeout < 0 {
+		return fmt.Errorf("session timeout must not be negative")
+	}
+	if c.Auth.Enabled() {
+		if err := c.Auth.Validate(); err != nil {
+			return fmt.Errorf("auth: %w", err)
+		}
+	}
+	return nil
+}
+
+// TLSEnabled reports whether the HTTP transport serves HTTPS.
+func (c HTTPConfig) TLSEnabled() bool {
+	return c.TLSCertFile != "" && c.TLSKeyFile != ""
+}
+
+// normalize fills unset HTTP fields with defaults.
+func (c HTTPConfig) normalize() HTTPConfig {
+	if c.Address == "" {
+		c.Address = DefaultHTTPAddress
+	}
+	if c.Path == "" {
+		c.Path = DefaultHTTPPath
+	}
+	return c
+}
+
+// NewHTTPHandler returns an http.Handler serving the MCP server over the
+// Streamable HTTP transport at cfg.Path. Every session shares the same
+// *mcp.Server (and therefore the same toolkit and connection manager).
+// When cfg.Auth has a key source, the endpoint requires a valid bearer token
+// and the token's principal is available to tool handlers.
+func NewHTTPHandler(server *mcp.Server, cfg HTTPConfig) (http.Handler, error) {
+	cfg = cfg.normalize()
+
+	var mcpHandler http.Handler = mcp.NewStreamableHTTPHandler(func(*http.Request) *mcp.Server {
+		return server
+	}, &mcp.StreamableHTTPOptions{
+		Stateless:      cfg.Stateless,
+		JSONResponse:   cfg.JSONResponse,
+		SessionTimeout: cfg.SessionTimeout,
+	})
+
+	if cfg.Auth.Enabled() {
+		verifier, err := auth.NewVerifier(cfg.Auth)
+		if err != nil {
+			return nil, fmt.Errorf("auth: %w", err)
+		}
+		mcpHandler = mcpauth.RequireBearerToken(verifier.TokenVerifier(), &mcpauth.RequireBearerTokenOptions{
+			Scopes: cfg.Auth.RequiredScopes,
+		})(mcpHandler)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(cfg.Path, mcpHandler)
+	return mux, nil
+}
+
+// Run serves the MCP server on the configured transport until ctx is canceled.
+// For the stdio transport this blocks until the client disconnects; for the
+// HTTP transport it listens on cfg.HTTP.Address and shuts down gracefully
+// when ctx is canceled.
+func Run(ctx context.Context, server *mcp.Server, cfg TransportConfig) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	if cfg.Mode != TransportHTTP {
+		return server.Run(ctx, &mcp.StdioTransport{})
+	}
+	handler, err := NewHTTPHandler(server, cfg.HTTP)
+	if err != nil {
+		return err
+	}
+	return serveHTTP(ctx, handler, cfg.HTTP)
+}
+
+// serveHTTP runs an HTTP server for handler until ctx is canceled.
+func serveHTTP(ctx context.Context, handler http.Handler, cfg HTTPConfig) error {
+	cfg = cfg.normalize()
+
+	httpServer := &http.Server{
+		Addr:              cfg.Address,
+		Handler:           handler,
+		ReadHeaderTimeout: defaultHeaderTimeout,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		var err error
+		if cfg.TLSEnabled() {
+			err = httpServer.ListenAndServeTLS(cfg.TLSCertFile, cfg.TLSKeyFile)
+		} else {
+			err = httpServer.ListenAndServe()
+		}
+		errCh <- err
+	}()
+
+	select {
+	case err := <-errCh:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return fmt.Errorf("http server: %w", err)
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), defaultShutdownGrace)
+		defer cancel()
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			return fmt.Errorf("http server shutdown: %w", err)
+		}
+		return ctx.Err()
+	}
+}
+
+// envBool parses a boolean environment value, accepting common variations.
+func envBool(s string) bool {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "true", "1", "yes", "on", "enabled":
+		return true
+	default:
+		return false
+	}
+}
+
+// parseEnvDuration parses a Go duration string or a plain number of seconds.
+func parseEnvDuration(s string) (time.Duration, bool) {
+	s = strings.TrimSpace(s)
+	if d, err := time.ParseDuration(s); err == nil {
+		return d, true
+	}
+	if secs, err := strconv.Atoi(s); err == nil {
+		return time.Duration(secs) * time.Second, true
+	}
+	return 0, false
+}
@@ -0,0 +1,308 @@
+package server
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/txn2/mcp-trino/pkg/auth"
+	"github.com/txn2/mcp-trino/pkg/client"
+	"github.com/txn2/mcp-trino/pkg/extensions"
+	"github.com/txn2/mcp-trino/pkg/multiserver"
+	"github.com/txn2/mcp-trino/pkg/tools"
+)
+
+func TestDefaultTransportConfig(t *testing.T) {
+	cfg := DefaultTransportConfig()
+	if cfg.Mode != TransportStdio {
+		t.Errorf("expected stdio mode, got %q", cfg.Mode)
+	}
+	if cfg.HTTP.Address != DefaultHTTPAddress {
+		t.Errorf("expected address %q, got %q", DefaultHTTPAddress, cfg.HTTP.Address)
+	}
+	if cfg.HTTP.Path != DefaultHTTPPath {
+		t.Errorf("expected path %q, got %q", DefaultHTTPPath, cfg.HTTP.Path)
+	}
+	if cfg.HTTP.SessionTimeout != DefaultSessionTimeout {
+		t.Errorf("expected session timeout %v, got %v", DefaultSessionTimeout, cfg.HTTP.SessionTimeout)
+	}
+}
+
+func TestTransportFromEnv(t *testing.T) {
+	t.Setenv("MCP_TRINO_TRANSPORT", "HTTP")
+	t.Setenv("MCP_TRINO_HTTP_ADDR", "127.0.0.1:9443")
+	t.Setenv("MCP_TRINO_HTTP_PATH", "/trino")
+	t.Setenv("MCP_TRINO_TLS_CERT", "/etc/tls/tls.crt")
+	t.Setenv("MCP_TRINO_TLS_KEY", "/etc/tls/tls.key")
+	t.Setenv("MCP_TRINO_HTTP_STATELESS", "true")
+	t.Setenv("MCP_TRINO_SESSION_TIMEOUT", "90")
+	t.Setenv("MCP_TRINO_HTTP_JSON_RESPONSE", "yes")
+
+	cfg := TransportFromEnv()
+
+	if cfg.Mode != TransportHTTP {
+		t.Errorf("expected http mode, got %q", cfg.Mode)
+	}
+	if cfg.HTTP.Address != "127.0.0.1:9443" {
+		t.Errorf("unexpected address %q", cfg.HTTP.Address)
+	}
+	if cfg.HTTP.Path != "/trino" {
+		t.Errorf("unexpected path %q", cfg.HTTP.Path)
+	}
+	if !cfg.HTTP.TLSEnabled() {
+		t.Error("expected TLS to be enabled")
+	}
+	if !cfg.HTTP.Stateless {
+		t.Error("expected stateless to be true")
+	}
+	if cfg.HTTP.SessionTimeout != 90*time.Second {
+		t.Errorf("expected 90s session timeout, got %v", cfg.HTTP.SessionTimeout)
+	}
+	if !cfg.HTTP.JSONResponse {
+		t.Error("expected JSON responses to be enabled")
+	}
+}
+
+func TestTransportFromFile_SessionTimeout(t *testing.T) {
+	duration := func(d time.Duration) *extensions.Duration {
+		v := extensions.Duration(d)
+		return &v
+	}
+	tests := []struct {
+		name    string
+		timeout *extensions.Duration
+		want    time.Duration
+	}{
+		{name: "unset keeps the default", want: DefaultSessionTimeout},
+		{name: "zero disables the timeout", timeout: duration(0), want: 0},
+		{name: "set", timeout: duration(90 * time.Second), want: 90 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fileCfg := extensions.ServerConfig{Transport: extensions.TransportFileConfig{SessionTimeout: tt.timeout}}
+			if got := transportFromFile(fileCfg).HTTP.SessionTimeout; got != tt.want {
+				t.Errorf("session timeout = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransportConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     TransportConfig
+		wantErr bool
+	}{
+		{name: "empty mode", cfg: TransportConfig{}},
+		{name: "stdio", cfg: TransportConfig{Mode: TransportStdio}},
+		{name: "http", cfg: TransportConfig{Mode: TransportHTTP, HTTP: DefaultTransportConfig().HTTP}},
+		{name: "unknown mode", cfg: TransportConfig{Mode: "sse"}, wantErr: true},
+		{
+			name:    "cert without key",
+			cfg:     TransportConfig{Mode: TransportHTTP, HTTP: HTTPConfig{TLSCertFile: "a.crt"}},
+			wantErr: true,
+		},
+		{
+			name:    "relative path",
+			cfg:     TransportConfig{Mode: TransportHTTP, HTTP: HTTPConfig{Path: "mcp"}},
+			wantErr: true,
+		},
+		{
+			name: "auth with negative leeway",
+			cfg: TransportConfig{Mode: TransportHTTP, HTTP: HTTPConfig{
+				Auth: auth.Config{HMACSecret: "secret", Leeway: -time.Second},
+			}},
+			wantErr: true,
+		},
+		{
+			name:    "negative session timeout",
+			cfg:     TransportConfig{Mode: TransportHTTP, HTTP: HTTPConfig{SessionTimeout: -time.Second}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewHTTPHandler_ServesTools(t *testing.T) {
+	mcpServer, mgr, err := New(Options{
+		MultiServerConfig: &multiserver.Config{
+			Default: "default",
+			Primary: client.Config{Host: "localhost", Port: 8080, User: "test"},
+		},
+		ToolkitConfig: tools.DefaultConfig(),
+	})
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	defer func() { _ = mgr.Close() }()
+
+	handler, err := NewHTTPHandler(mcpServer, HTTPConfig{})
+	if err != nil {
+		t.Fatalf("NewHTTPHandler() error: %v", err)
+	}
+	httpServer := httptest.NewServer(handler)
+	defer httpServer.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Two independent clients share the same server.
+	for i := range 2 {
+		mcpClient := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "v0.0.1"}, nil)
+		session, err := mcpClient.Connect(ctx, &mcp.StreamableClientTransport{
+			Endpoint: httpServer.URL + DefaultHTTPPath,
+		}, nil)
+		if err != nil {
+			t.Fatalf("client %d: Connect() error: %v", i, err)
+		}
+
+		result, err := session.ListTools(ctx, nil)
+		if err != nil {
+			t.Fatalf("client %d: ListTools() error: %v", i, err)
+		}
+		if len(result.Tools) != len(tools.AllTools()) {
+			t.Errorf("client %d: expected %d tools, got %d", i, len(tools.AllTools()), len(result.Tools))
+		}
+		if session.ID() == "" {
+			t.Errorf("client %d: expected a session ID", i)
+		}
+		_ = session.Close()
+	}
+}
+
+// bearerTransport adds an Authorization header to every request.
+type bearerTransport struct {
+	token string
+}
+
+func (b bearerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+b.token)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func hs256Token(t *testing.T, secret string, claims map[string]any) string {
+	t.Helper()
+	enc := func(v any) string {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	signed := enc(map[string]string{"alg": "HS256", "typ": "JWT"}) + "." + enc(claims)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(signed))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestNewHTTPHandler_BearerAuth(t *testing.T) {
+	mcpServer := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "v0"}, nil)
+
+	authCfg := auth.DefaultConfig()
+	authCfg.HMACSecret = "test-secret"
+	authCfg.Audience = "mcp-trino"
+
+	handler, err := NewHTTPHandler(mcpServer, HTTPConfig{Auth: authCfg})
+	if err != nil {
+		t.Fatalf("NewHTTPHandler() error: %v", err)
+	}
+	httpServer := httptest.NewServer(handler)
+	defer httpServer.Close()
+
+	endpoint := httpServer.URL + DefaultHTTPPath
+
+	t.Run("missing token", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, endpoint, http.NoBody)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("expected 401, got %d", resp.StatusCode)
+		}
+	})
+
+	t.Run("valid token", func(t *testing.T) {
+		token := hs256Token(t, "test-secret", map[string]any{
+			"sub": "alice",
+			"aud": "mcp-trino",
+			"exp": time.Now().Add(time.Hour).Unix(),
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		mcpClient := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "v0.0.1"}, nil)
+		session, err := mcpClient.Connect(ctx, &mcp.StreamableClientTransport{
+			Endpoint:   endpoint,
+			HTTPClient: &http.Client{Transport: bearerTransport{token: token}},
+		}, nil)
+		if err != nil {
+			t.Fatalf("Connect() error: %v", err)
+		}
+		_ = session.Close()
+	})
+}
+
+func TestNewHTTPHandler_InvalidAuth(t *testing.T) {
+	mcpServer := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "v0"}, nil)
+	_, err := NewHTTPHandler(mcpServer, HTTPConfig{Auth: auth.Config{JWKSFile: "/nonexistent/jwks.json"}})
+	if err == nil {
+		t.Fatal("expected error for missing JWKS file")
+	}
+}
+
+func TestRun_InvalidTransport(t *testing.T) {
+	mcpServer := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "v0"}, nil)
+	err := Run(context.Background(), mcpServer, TransportConfig{Mode: "carrier-pigeon"})
+	if err == nil {
+		t.Fatal("expected error for unknown transport")
+	}
+}
+
+func TestRun_HTTPShutdown(t *testing.T) {
+	mcpServer := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "v0"}, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan error, 1)
+	go func() {
+		done <- Run(ctx, mcpServer, TransportConfig{
+			Mode: TransportHTTP,
+			HTTP: HTTPConfig{Address: "127.0.0.1:0"},
+		})
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-done:
+		if err == nil || ctx.Err() == nil {
+			t.Errorf("expected context cancellation error, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after context cancellation")
+	}
+}
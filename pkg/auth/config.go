@@ -0,0 +1,144 @@
+// Package auth provides bearer token authentication for the mcp-trino HTTP
+// transport.
+//
+// Tokens are JWTs verified against keys loaded from a JWKS file, a PEM public
+// key, or a shared HMAC secret. No network access is required, which keeps
+// verification fast and testable offline. Verified tokens yield a principal
+// (the user name taken from a configurable claim) that the toolkit can use to
+// run Trino queries on behalf of the caller.
+package auth
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// DefaultUserClaim is the JWT claim used as the principal name by default.
+const DefaultUserClaim = "sub"
+
+// Config configures bearer token verification.
+type Config struct {
+	// JWKSFile is the path to a JSON Web Key Set used to verify tokens.
+	JWKSFile string
+
+	// PublicKeyFile is the path to a PEM-encoded public key or certificate
+	// used to verify RS*, PS*, and ES* tokens.
+	PublicKeyFile string
+
+	// HMACSecret is a shared secret used to verify HS* tokens.
+	HMACSecret string
+
+	// Issuer is the expected "iss" claim. Empty disables the check.
+	Issuer string
+
+	// Audience is the expected "aud" claim. Empty disables the check.
+	Audience string
+
+	// UserClaim is the claim that names the principal. Default: "sub".
+	// Common alternatives are "preferred_username" and "email".
+	UserClaim string
+
+	// RequiredScopes lists scopes every token must carry.
+	RequiredScopes []string
+
+	// Leeway is the clock skew tolerated for "exp" and "nbf". Default: 30s.
+	Leeway time.Duration
+
+	// Impersonate runs Trino queries as the authenticated principal.
+	// Default: true.
+	Impersonate bool
+}
+
+// DefaultConfig returns a Config with sensible defaults. Authentication is
+// disabled until a key source is configured.
+func DefaultConfig() Config {
+	return Config{
+		UserClaim:   DefaultUserClaim,
+		Leeway:      30 * time.Second,
+		Impersonate: true,
+	}
+}
+
+// FromEnv loads authentication configuration from environment variables.
+// Environment variables:
+//   - MCP_TRINO_AUTH_JWKS_FILE: JWKS file used to verify tokens
+//   - MCP_TRINO_AUTH_PUBLIC_KEY_FILE: PEM public key or certificate
+//   - MCP_TRINO_AUTH_HMAC_SECRET: Shared secret for HS256/384/512 tokens
+//   - MCP_TRINO_AUTH_ISSUER: Expected token issuer
+//   - MCP_TRINO_AUTH_AUDIENCE: Expected token audience
+//   - MCP_TRINO_AUTH_USER_CLAIM: Claim naming the principal (default: sub)
+//   - MCP_TRINO_AUTH_SCOPES: Comma-separated scopes every token must carry
+//   - MCP_TRINO_AUTH_LEEWAY: Allowed clock skew (e.g. "30s")
+//   - MCP_TRINO_AUTH_IMPERSONATE: Run queries as the principal (default: true)
+func FromEnv() Config {
+	cfg := DefaultConfig()
+
+	if v := os.Getenv("MCP_TRINO_AUTH_JWKS_FILE"); v != "" {
+		cfg.JWKSFile = v
+	}
+	if v := os.Getenv("MCP_TRINO_AUTH_PUBLIC_KEY_FILE"); v != "" {
+		cfg.PublicKeyFile = v
+	}
+	if v := os.Getenv("MCP_TRINO_AUTH_HMAC_SECRET"); v != "" {
+		cfg.HMACSecret = v
+	}
+	if v := os.Getenv("MCP_TRINO_AUTH_ISSUER"); v != "" {
+		cfg.Issuer = v
+	}
+	if v := os.Getenv("MCP_TRINO_AUTH_AUDIENCE"); v != "" {
+		cfg.Audience = v
+	}
+	if v := os.Getenv("MCP_TRINO_AUTH_USER_CLAIM"); v != "" {
+		cfg.UserClaim = v
+	}
+	if v := os.Getenv("MCP_TRINO_AUTH_SCOPES"); v != "" {
+		cfg.RequiredScopes = splitList(v)
+	}
+	if v := os.Getenv("MCP_TRINO_AUTH_LEEWAY"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.Leeway = d
+		}
+	}
+	if v := os.Getenv("MCP_TRINO_AUTH_IMPERSONATE"); v != "" {
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "true", "1", "yes", "on", "enabled":
+			cfg.Impersonate = true
+		default:
+			cfg.Impersonate = false
+		}
+	}
+
+	return cfg
+}
+
+// Enabled reports whether a key source is configured.
+func (c Config) Enabled() bool {
+	return c.JWKSFile != "" || c.PublicKeyFile != "" || c.HMACSecret != ""
+}
+
+// Validate checks that the configuration is usable.
+func (c Config) Validate() error {
+	if !c.Enabled() {
+		return fmt.Errorf("no key source configured (set a JWKS file, public key file, or HMAC secret)")
+	}
+	if c.Leeway < 0 {
+		return fmt.Errorf("leeway must not be negative")
+	}
+	return nil
+}
+
+// splitList splits a comma- or space-separated list, dropping empty entries.
+func splitList(s string) []string {
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+	out := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if f = strings.TrimSpace(f); f != "" {
+			out = append(out, f)
+		}
+	}
+	return out
+}
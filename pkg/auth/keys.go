@@ -0,0 +1,222 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+)
+
+// verificationKey is a key that can verify token signatures.
+type verificationKey struct {
+	// ID is the JWKS "kid", empty for keys loaded from PEM or a secret.
+	ID string
+
+	// Algorithm restricts the key to one "alg" when set.
+	Algorithm string
+
+	// Key is an *rsa.PublicKey, *ecdsa.PublicKey, or []byte HMAC secret.
+	Key any
+}
+
+// jsonWebKey is a single entry of a JSON Web Key Set (RFC 7517).
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	K   string `json:"k"`
+}
+
+// loadKeys loads every verification key configured in cfg.
+func loadKeys(cfg Config) ([]verificationKey, error) {
+	var keys []verificationKey
+
+	if cfg.JWKSFile != "" {
+		data, err := os.ReadFile(cfg.JWKSFile) // #nosec G304 -- key file path is provided by administrator
+		if err != nil {
+			return nil, fmt.Errorf("reading JWKS file: %w", err)
+		}
+		jwks, err := parseJWKS(data)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, jwks...)
+	}
+
+	if cfg.PublicKeyFile != "" {
+		data, err := os.ReadFile(cfg.PublicKeyFile) // #nosec G304 -- key file path is provided by administrator
+		if err != nil {
+			return nil, fmt.Errorf("reading public key file: %w", err)
+		}
+		key, err := parsePublicKeyPEM(data)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, verificationKey{Key: key})
+	}
+
+	if cfg.HMACSecret != "" {
+		keys = append(keys, verificationKey{Key: []byte(cfg.HMACSecret)})
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("no verification keys loaded")
+	}
+	return keys, nil
+}
+
+// parseJWKS parses a JSON Web Key Set. Keys marked for encryption use and
+// keys of unsupported types are skipped.
+func parseJWKS(data []byte) ([]verificationKey, error) {
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("parsing JWKS: %w", err)
+	}
+
+	keys := make([]verificationKey, 0, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("parsing JWKS key %q: %w", jwk.Kid, err)
+		}
+		if key == nil {
+			continue
+		}
+		keys = append(keys, verificationKey{ID: jwk.Kid, Algorithm: jwk.Alg, Key: key})
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("JWKS contains no usable signing keys")
+	}
+	return keys, nil
+}
+
+// publicKey converts the JWK to a Go key. Returns nil for unsupported types.
+func (k jsonWebKey) publicKey() (any, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("modulus: %w", err)
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("exponent: %w", err)
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, errors.New("exponent too large")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		curve, err := curveByName(k.Crv)
+		if err != nil {
+			return nil, err
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("x coordinate: %w", err)
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("y coordinate: %w", err)
+		}
+		size := (curve.Params().BitSize + 7) / 8
+		if x.BitLen() > size*8 || y.BitLen() > size*8 {
+			return nil, errors.New("coordinates exceed curve size")
+		}
+		point := make([]byte, 1+2*size)
+		point[0] = 4 // uncompressed point marker
+		x.FillBytes(point[1 : 1+size])
+		y.FillBytes(point[1+size:])
+		key, err := ecdsa.ParseUncompressedPublicKey(curve, point)
+		if err != nil {
+			return nil, fmt.Errorf("invalid point: %w", err)
+		}
+		return key, nil
+	case "oct":
+		secret, err := base64.RawURLEncoding.DecodeString(k.K)
+		if err != nil {
+			return nil, fmt.Errorf("secret: %w", err)
+		}
+		return secret, nil
+	default:
+		return nil, nil
+	}
+}
+
+// parsePublicKeyPEM parses a PEM-encoded PKIX or PKCS#1 public key, or an
+// X.509 certificate.
+func parsePublicKeyPEM(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("public key file contains no PEM block")
+	}
+
+	switch block.Type {
+	case "PUBLIC KEY":
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parsing public key: %w", err)
+		}
+		return key, nil
+	case "RSA PUBLIC KEY":
+		key, err := x509.ParsePKCS1PublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parsing RSA public key: %w", err)
+		}
+		return key, nil
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parsing certificate: %w", err)
+		}
+		return cert.PublicKey, nil
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type %q", block.Type)
+	}
+}
+
+// curveByName returns the elliptic curve for a JWK "crv" value.
+func curveByName(name string) (elliptic.Curve, error) {
+	switch name {
+	case "P-256":
+		return elliptic.P256(), nil
+	case "P-384":
+		return elliptic.P384(), nil
+	case "P-521":
+		return elliptic.P521(), nil
+	default:
+		return nil, fmt.Errorf("unsupported curve %q", name)
+	}
+}
+
+// decodeBigInt decodes an unpadded base64url big-endian integer.
+func decodeBigInt(s string) (*big.Int, error) {
+	if s == "" {
+		return nil, errors.New("missing value")
+	}
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
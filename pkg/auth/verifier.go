@@ -0,0 +1,297 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"math/big"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	mcpauth "github.com/modelcontextprotocol/go-sdk/auth"
+)
+
+// ErrNoPrincipal is returned when a valid token does not carry the
+// configured user claim.
+var ErrNoPrincipal = errors.New("token has no user claim")
+
+// Claims holds the verified claims of a token.
+type Claims struct {
+	// User is the principal name taken from the configured user claim.
+	User string
+
+	// Scopes lists the scopes granted by the "scope" or "scp" claim.
+	Scopes []string
+
+	// Expiration is the "exp" claim.
+	Expiration time.Time
+
+	// Raw holds every claim in the token payload.
+	Raw map[string]any
+}
+
+// Verifier verifies bearer tokens.
+type Verifier struct {
+	config Config
+	keys   []verificationKey
+	now    func() time.Time
+}
+
+// NewVerifier loads the configured keys and returns a Verifier.
+func NewVerifier(cfg Config) (*Verifier, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if cfg.UserClaim == "" {
+		cfg.UserClaim = DefaultUserClaim
+	}
+
+	keys, err := loadKeys(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Verifier{config: cfg, keys: keys, now: time.Now}, nil
+}
+
+// Verify checks the token signature and claims. Errors wrap
+// auth.ErrInvalidToken from the MCP SDK so they map to 401 responses.
+func (v *Verifier) Verify(token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, invalid("malformed token")
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, invalid("malformed header")
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, invalid("malformed signature")
+	}
+	if err := v.verifySignature(header.Alg, header.Kid, parts[0]+"."+parts[1], sig); err != nil {
+		return nil, err
+	}
+
+	var raw map[string]any
+	if err := decodeSegment(parts[1], &raw); err != nil {
+		return nil, invalid("malformed payload")
+	}
+	return v.validateClaims(raw)
+}
+
+// TokenVerifier adapts the Verifier to the MCP SDK's bearer token middleware.
+// The principal is exposed as TokenInfo.UserID.
+func (v *Verifier) TokenVerifier() mcpauth.TokenVerifier {
+	return func(_ context.Context, token string, _ *http.Request) (*mcpauth.TokenInfo, error) {
+		claims, err := v.Verify(token)
+		if err != nil {
+			return nil, err
+		}
+		return &mcpauth.TokenInfo{
+			UserID:     claims.User,
+			Scopes:     claims.Scopes,
+			Expiration: claims.Expiration,
+			Extra:      claims.Raw,
+		}, nil
+	}
+}
+
+// verifySignature checks sig against every key compatible with alg and kid.
+func (v *Verifier) verifySignature(alg, kid, signed string, sig []byte) error {
+	if alg == "" || strings.EqualFold(alg, "none") {
+		return invalid("unsigned tokens are not accepted")
+	}
+
+	matched := false
+	for _, key := range v.keys {
+		if kid != "" && key.ID != "" && key.ID != kid {
+			continue
+		}
+		if key.Algorithm != "" && key.Algorithm != alg {
+			continue
+		}
+		ok, supported := verifyWithKey(alg, key.Key, []byte(signed), sig)
+		if !supported {
+			continue
+		}
+		matched = true
+		if ok {
+			return nil
+		}
+	}
+
+	if !matched {
+		return invalid(fmt.Sprintf("no key for algorithm %q", alg))
+	}
+	return invalid("signature verification failed")
+}
+
+// validateClaims checks the registered claims and extracts the principal.
+func (v *Verifier) validateClaims(raw map[string]any) (*Claims, error) {
+	now := v.now()
+	leeway := v.config.Leeway
+
+	exp, ok := numericDate(raw["exp"])
+	if !ok {
+		return nil, invalid("token has no expiration")
+	}
+	if now.After(exp.Add(leeway)) {
+		return nil, invalid("token expired")
+	}
+	if nbf, ok := numericDate(raw["nbf"]); ok && now.Add(leeway).Before(nbf) {
+		return nil, invalid("token not yet valid")
+	}
+
+	if v.config.Issuer != "" {
+		if iss, _ := raw["iss"].(string); iss != v.config.Issuer {
+			return nil, invalid("unexpected issuer")
+		}
+	}
+	if v.config.Audience != "" && !slices.Contains(stringList(raw["aud"]), v.config.Audience) {
+		return nil, invalid("unexpected audience")
+	}
+
+	user, _ := raw[v.config.UserClaim].(string)
+	if user == "" {
+		return nil, fmt.Errorf("%w: %w (%s)", mcpauth.ErrInvalidToken, ErrNoPrincipal, v.config.UserClaim)
+	}
+
+	scopes := stringList(raw["scp"])
+	if s, ok := raw["scope"].(string); ok {
+		scopes = strings.Fields(s)
+	}
+
+	return &Claims{
+		User:       user,
+		Scopes:     scopes,
+		Expiration: exp,
+		Raw:        raw,
+	}, nil
+}
+
+// verifyWithKey verifies sig with key using alg. The second result is false
+// when the key type cannot be used with alg.
+func verifyWithKey(alg string, key any, signed, sig []byte) (ok, supported bool) {
+	hashFn, cryptoHash, ok := hashForAlg(alg)
+	if !ok {
+		return false, false
+	}
+
+	switch alg[:2] {
+	case "HS":
+		secret, isSecret := key.([]byte)
+		if !isSecret {
+			return false, false
+		}
+		mac := hmac.New(hashFn, secret)
+		mac.Write(signed)
+		return hmac.Equal(sig, mac.Sum(nil)), true
+	case "RS", "PS":
+		pub, isRSA := key.(*rsa.PublicKey)
+		if !isRSA {
+			return false, false
+		}
+		digest := sum(hashFn, signed)
+		if alg[0] == 'P' {
+			opts := &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash}
+			return rsa.VerifyPSS(pub, cryptoHash, digest, sig, opts) == nil, true
+		}
+		return rsa.VerifyPKCS1v15(pub, cryptoHash, digest, sig) == nil, true
+	case "ES":
+		pub, isEC := key.(*ecdsa.PublicKey)
+		if !isEC {
+			return false, false
+		}
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if len(sig) != 2*size {
+			return false, true
+		}
+		r := new(big.Int).SetBytes(sig[:size])
+		s := new(big.Int).SetBytes(sig[size:])
+		return ecdsa.Verify(pub, sum(hashFn, signed), r, s), true
+	default:
+		return false, false
+	}
+}
+
+// hashForAlg returns the hash used by a JWS algorithm.
+func hashForAlg(alg string) (func() hash.Hash, crypto.Hash, bool) {
+	if len(alg) != 5 {
+		return nil, 0, false
+	}
+	switch alg[2:] {
+	case "256":
+		return sha256.New, crypto.SHA256, true
+	case "384":
+		return sha512.New384, crypto.SHA384, true
+	case "512":
+		return sha512.New, crypto.SHA512, true
+	default:
+		return nil, 0, false
+	}
+}
+
+// sum hashes data with the given hash constructor.
+func sum(hashFn func() hash.Hash, data []byte) []byte {
+	h := hashFn()
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+// decodeSegment decodes a base64url JSON token segment into v.
+func decodeSegment(seg string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// numericDate converts a JSON NumericDate claim to a time.
+func numericDate(v any) (time.Time, bool) {
+	f, ok := v.(float64)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(f), 0), true
+}
+
+// stringList converts a claim that is either a string or an array of strings.
+func stringList(v any) []string {
+	switch val := v.(type) {
+	case string:
+		return []string{val}
+	case []any:
+		out := make([]string, 0, len(val))
+		for _, item := range val {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	default:
+		return nil
+	}
+}
+
+// invalid returns an error wrapping the SDK's ErrInvalidToken.
+func invalid(reason string) error {
+	return fmt.Errorf("%w: %s", mcpauth.ErrInvalidToken, reason)
+}
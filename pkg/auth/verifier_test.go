@@ -0,0 +1,381 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	mcpauth "github.com/modelcontextprotocol/go-sdk/auth"
+)
+
+func b64(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func segment(t *testing.T, v any) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	return b64(data)
+}
+
+func signRS256(t *testing.T, key *rsa.PrivateKey, kid string, claims map[string]any) string {
+	t.Helper()
+	signed := segment(t, map[string]string{"alg": "RS256", "typ": "JWT", "kid": kid}) + "." + segment(t, claims)
+	digest := sha256.Sum256([]byte(signed))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	return signed + "." + b64(sig)
+}
+
+func signES256(t *testing.T, key *ecdsa.PrivateKey, claims map[string]any) string {
+	t.Helper()
+	signed := segment(t, map[string]string{"alg": "ES256", "typ": "JWT"}) + "." + segment(t, claims)
+	digest := sha256.Sum256([]byte(signed))
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	sig := make([]byte, 64)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:])
+	return signed + "." + b64(sig)
+}
+
+func signHS256(t *testing.T, secret string, claims map[string]any) string {
+	t.Helper()
+	signed := segment(t, map[string]string{"alg": "HS256", "typ": "JWT"}) + "." + segment(t, claims)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(signed))
+	return signed + "." + b64(mac.Sum(nil))
+}
+
+func writeFile(t *testing.T, name string, data []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+	return path
+}
+
+func writeJWKS(t *testing.T, kid string, key *rsa.PublicKey) string {
+	t.Helper()
+	jwks := map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": kid,
+			"alg": "RS256",
+			"use": "sig",
+			"n":   b64(key.N.Bytes()),
+			"e":   b64(big.NewInt(int64(key.E)).Bytes()),
+		}},
+	}
+	data, err := json.Marshal(jwks)
+	if err != nil {
+		t.Fatalf("marshal JWKS: %v", err)
+	}
+	return writeFile(t, "jwks.json", data)
+}
+
+func validClaims() map[string]any {
+	return map[string]any{
+		"sub":   "alice",
+		"iss":   "https://idp.example.com",
+		"aud":   []string{"mcp-trino", "other"},
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"scope": "trino:query trino:read",
+	}
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv("MCP_TRINO_AUTH_JWKS_FILE", "/etc/jwks.json")
+	t.Setenv("MCP_TRINO_AUTH_ISSUER", "https://idp.example.com")
+	t.Setenv("MCP_TRINO_AUTH_AUDIENCE", "mcp-trino")
+	t.Setenv("MCP_TRINO_AUTH_USER_CLAIM", "email")
+	t.Setenv("MCP_TRINO_AUTH_SCOPES", "trino:query, trino:read")
+	t.Setenv("MCP_TRINO_AUTH_LEEWAY", "1m")
+	t.Setenv("MCP_TRINO_AUTH_IMPERSONATE", "false")
+
+	cfg := FromEnv()
+
+	if !cfg.Enabled() {
+		t.Error("expected auth to be enabled")
+	}
+	if cfg.UserClaim != "email" {
+		t.Errorf("expected user claim email, got %q", cfg.UserClaim)
+	}
+	if len(cfg.RequiredScopes) != 2 || cfg.RequiredScopes[1] != "trino:read" {
+		t.Errorf("unexpected scopes %v", cfg.RequiredScopes)
+	}
+	if cfg.Leeway != time.Minute {
+		t.Errorf("expected 1m leeway, got %v", cfg.Leeway)
+	}
+	if cfg.Impersonate {
+		t.Error("expected impersonation to be disabled")
+	}
+}
+
+func TestDefaultConfig(t *testing.T) {
+	cfg := DefaultConfig()
+	if cfg.Enabled() {
+		t.Error("expected auth to be disabled by default")
+	}
+	if cfg.UserClaim != DefaultUserClaim {
+		t.Errorf("expected user claim %q, got %q", DefaultUserClaim, cfg.UserClaim)
+	}
+	if !cfg.Impersonate {
+		t.Error("expected impersonation by default")
+	}
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected Validate() to fail without a key source")
+	}
+}
+
+func TestVerifier_JWKS(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := DefaultConfig()
+	cfg.JWKSFile = writeJWKS(t, "key-1", &key.PublicKey)
+	cfg.Issuer = "https://idp.example.com"
+	cfg.Audience = "mcp-trino"
+
+	v, err := NewVerifier(cfg)
+	if err != nil {
+		t.Fatalf("NewVerifier() error: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		token   func() string
+		wantErr bool
+	}{
+		{
+			name:  "valid",
+			token: func() string { return signRS256(t, key, "key-1", validClaims()) },
+		},
+		{
+			name:  "no kid tries all keys",
+			token: func() string { return signRS256(t, key, "", validClaims()) },
+		},
+		{
+			name:    "wrong key",
+			token:   func() string { return signRS256(t, other, "key-1", validClaims()) },
+			wantErr: true,
+		},
+		{
+			name:    "unknown kid",
+			token:   func() string { return signRS256(t, key, "key-2", validClaims()) },
+			wantErr: true,
+		},
+		{
+			name: "expired",
+			token: func() string {
+				c := validClaims()
+				c["exp"] = time.Now().Add(-time.Hour).Unix()
+				return signRS256(t, key, "key-1", c)
+			},
+			wantErr: true,
+		},
+		{
+			name: "expired within leeway",
+			token: func() string {
+				c := validClaims()
+				c["exp"] = time.Now().Add(-10 * time.Second).Unix()
+				return signRS256(t, key, "key-1", c)
+			},
+		},
+		{
+			name: "missing exp",
+			token: func() string {
+				c := validClaims()
+				delete(c, "exp")
+				return signRS256(t, key, "key-1", c)
+			},
+			wantErr: true,
+		},
+		{
+			name: "not yet valid",
+			token: func() string {
+				c := validClaims()
+				c["nbf"] = time.Now().Add(time.Hour).Unix()
+				return signRS256(t, key, "key-1", c)
+			},
+			wantErr: true,
+		},
+		{
+			name: "wrong issuer",
+			token: func() string {
+				c := validClaims()
+				c["iss"] = "https://evil.example.com"
+				return signRS256(t, key, "key-1", c)
+			},
+			wantErr: true,
+		},
+		{
+			name: "wrong audience",
+			token: func() string {
+				c := validClaims()
+				c["aud"] = "someone-else"
+				return signRS256(t, key, "key-1", c)
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing subject",
+			token: func() string {
+				c := validClaims()
+				delete(c, "sub")
+				return signRS256(t, key, "key-1", c)
+			},
+			wantErr: true,
+		},
+		{
+			name: "alg none",
+			token: func() string {
+				return segment(t, map[string]string{"alg": "none"}) + "." + segment(t, validClaims()) + "."
+			},
+			wantErr: true,
+		},
+		{
+			name:    "malformed",
+			token:   func() string { return "not-a-jwt" },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := v.Verify(tt.token())
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				if !errors.Is(err, mcpauth.ErrInvalidToken) {
+					t.Errorf("expected error to wrap ErrInvalidToken, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Verify() error: %v", err)
+			}
+			if claims.User != "alice" {
+				t.Errorf("expected user alice, got %q", claims.User)
+			}
+			if len(claims.Scopes) != 2 || claims.Scopes[0] != "trino:query" {
+				t.Errorf("unexpected scopes %v", claims.Scopes)
+			}
+		})
+	}
+}
+
+func TestVerifier_PublicKeyFile(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := DefaultConfig()
+	cfg.PublicKeyFile = writeFile(t, "key.pem", pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	cfg.UserClaim = "preferred_username"
+
+	v, err := NewVerifier(cfg)
+	if err != nil {
+		t.Fatalf("NewVerifier() error: %v", err)
+	}
+
+	claims := validClaims()
+	claims["preferred_username"] = "bob"
+	got, err := v.Verify(signES256(t, key, claims))
+	if err != nil {
+		t.Fatalf("Verify() error: %v", err)
+	}
+	if got.User != "bob" {
+		t.Errorf("expected user bob, got %q", got.User)
+	}
+
+	// An HMAC token must not verify against an asymmetric key.
+	if _, err := v.Verify(signHS256(t, "secret", claims)); err == nil {
+		t.Error("expected HS256 token to be rejected")
+	}
+}
+
+func TestVerifier_TokenVerifier(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.HMACSecret = "test-secret"
+
+	v, err := NewVerifier(cfg)
+	if err != nil {
+		t.Fatalf("NewVerifier() error: %v", err)
+	}
+
+	info, err := v.TokenVerifier()(context.Background(), signHS256(t, "test-secret", validClaims()), nil)
+	if err != nil {
+		t.Fatalf("TokenVerifier() error: %v", err)
+	}
+	if info.UserID != "alice" {
+		t.Errorf("expected UserID alice, got %q", info.UserID)
+	}
+	if info.Expiration.IsZero() {
+		t.Error("expected expiration to be set")
+	}
+	if info.Extra["iss"] != "https://idp.example.com" {
+		t.Errorf("expected raw claims in Extra, got %v", info.Extra)
+	}
+
+	if _, err := v.TokenVerifier()(context.Background(), signHS256(t, "wrong", validClaims()), nil); err == nil {
+		t.Error("expected error for token signed with wrong secret")
+	}
+}
+
+func TestNewVerifier_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+	}{
+		{name: "no key source", cfg: DefaultConfig()},
+		{name: "missing JWKS file", cfg: Config{JWKSFile: filepath.Join(t.TempDir(), "missing.json")}},
+		{name: "invalid JWKS", cfg: Config{JWKSFile: writeFile(t, "bad.json", []byte("{"))}},
+		{name: "empty JWKS", cfg: Config{JWKSFile: writeFile(t, "empty.json", []byte(`{"keys":[]}`))}},
+		{name: "not PEM", cfg: Config{PublicKeyFile: writeFile(t, "bad.pem", []byte("garbage"))}},
+		{name: "negative leeway", cfg: Config{HMACSecret: "s", Leeway: -time.Second}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewVerifier(tt.cfg); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,317 @@
+package client
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ExplainAnalyzeResult holds the output of EXPLAIN ANALYZE: the plan
+// annotated with what each stage and operator actually did while the
+// query ran.
+type ExplainAnalyzeResult struct {
+	Verbose   bool            `json:"verbose"`
+	Plan      string          `json:"plan"`
+	Stages    []StageStats    `json:"stages"`
+	Operators []OperatorStats `json:"operators"`
+}
+
+// StageStats are the runtime statistics of a plan fragment.
+type StageStats struct {
+	Fragment        string  `json:"fragment"`
+	Distribution    string  `json:"distribution"` // e.g. SOURCE, HASH, or SINGLE
+	CPUMs           float64 `json:"cpu_ms"`
+	WallMs          float64 `json:"wall_ms"`
+	InputRows       int64   `json:"input_rows"`
+	InputBytes      int64   `json:"input_bytes"`
+	OutputRows      int64   `json:"output_rows"`
+	OutputBytes     int64   `json:"output_bytes"`
+	PeakMemoryBytes int64   `json:"peak_memory_bytes,omitempty"`
+}
+
+// OperatorStats are the runtime statistics of a plan node, such as a
+// TableScan or an InnerJoin.
+type OperatorStats struct {
+	Fragment string `json:"fragment"`
+	Name     string `json:"name"`
+	Table    string `json:"table,omitempty"`
+
+	CPUMs      float64 `json:"cpu_ms"`
+	CPUPercent float64 `json:"cpu_percent"` // share of the query's CPU time
+
+	// WallMs is the time the operator was scheduled, which Trino reports
+	// as Scheduled (Wall in older versions).
+	WallMs float64 `json:"wall_ms"`
+
+	// InputRows and InputBytes are only reported for table scans.
+	InputRows    int64 `json:"input_rows,omitempty"`
+	InputBytes   int64 `json:"input_bytes,omitempty"`
+	OutputRows   int64 `json:"output_rows"`
+	OutputBytes  int64 `json:"output_bytes"`
+	SpilledBytes int64 `json:"spilled_bytes,omitempty"`
+}
+
+// HotOperators returns up to n operators, the most CPU time first.
+func (r *ExplainAnalyzeResult) HotOperators(n int) []OperatorStats {
+	ops := slices.Clone(r.Operators)
+	slices.SortStableFunc(ops, func(a, b OperatorStats) int {
+		if c := cmp.Compare(b.CPUMs, a.CPUMs); c != 0 {
+			return c
+		}
+		return cmp.Compare(b.WallMs, a.WallMs)
+	})
+	return ops[:min(n, len(ops))]
+}
+
+// ExplainAnalyze runs a query and returns its plan with runtime
+// statistics. Unlike Explain, the statement is executed, so callers must
+// only pass statements they would run.
+//
+// It runs like QueryStream: opts.Timeout (or the client default) bounds it,
+// the session settings in opts apply, and if ctx ends first the query is
+// cancelled on the coordinator. opts.Limit is ignored.
+func (c *Client) ExplainAnalyze(
+	ctx context.Context, sqlQuery string, verbose bool, opts QueryOptions,
+) (*ExplainAnalyzeResult, error) {
+	explainSQL := "EXPLAIN ANALYZE " + sqlQuery
+	if verbose {
+		explainSQL = "EXPLAIN ANALYZE VERBOSE " + sqlQuery
+	}
+
+	opts.Limit = 0
+	rows, err := c.QueryStream(ctx, explainSQL, opts)
+	if err != nil {
+		return nil, fmt.Errorf("explain analyze failed: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var planLines []string
+	for rows.Next() {
+		if line, ok := rows.Values()[0].(string); ok {
+			planLines = append(planLines, line)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("explain analyze failed: %w", err)
+	}
+
+	result := ParseExplainAnalyze(strings.Join(planLines, "\n"))
+	result.Verbose = verbose
+	return result, nil
+}
+
+var (
+	// fragmentHeader matches "Fragment 1 [SOURCE]".
+	fragmentHeader = regexp.MustCompile(`^Fragment (\d+) \[([^\]]*)\]`)
+
+	// planNodeHeader matches a plan node such as "TableScan[table = ...]",
+	// after the tree drawing before it is removed.
+	planNodeHeader = regexp.MustCompile(`^([A-Z][A-Za-z]*)(\[.*)?$`)
+
+	// nestedStats matches parenthesized breakdowns such as "(Input: 0.00ns,
+	// Output: 0.00ns)" after Blocked, whose keys would shadow the stage's.
+	nestedStats = regexp.MustCompile(`\([^()]*:[^()]*\)`)
+
+	durationValue = regexp.MustCompile(`^([\d.]+)\s*(ns|us|ms|s|m|h|d)\b`)
+	percentValue  = regexp.MustCompile(`\(([\d.]+)%\)`)
+	rowsValue     = regexp.MustCompile(`^([\d.]+)([KMBT]?) rows?`)
+	bytesValue    = regexp.MustCompile(`([\d.]+)([kMGTP]?B)\b`)
+)
+
+// ParseExplainAnalyze extracts stage and operator statistics from the text
+// output of EXPLAIN ANALYZE. Lines it does not recognize are skipped, so
+// output from other Trino versions parses to whatever statistics match.
+func ParseExplainAnalyze(plan string) *ExplainAnalyzeResult {
+	result := &ExplainAnalyzeResult{Plan: plan, Stages: []StageStats{}, Operators: []OperatorStats{}}
+
+	// Appending may move the slices, so track the current entries by index
+	stage, op := -1, -1
+	for _, line := range strings.Split(plan, "\n") {
+		text := strings.TrimLeft(line, " │├└─-")
+		if m := fragmentHeader.FindStringSubmatch(text); m != nil {
+			result.Stages = append(result.Stages, StageStats{Fragment: m[1], Distribution: m[2]})
+			stage, op = len(result.Stages)-1, -1
+			continue
+		}
+		if stage < 0 {
+			continue
+		}
+		if m := planNodeHeader.FindStringSubmatch(text); m != nil {
+			result.Operators = append(result.Operators, OperatorStats{
+				Fragment: result.Stages[stage].Fragment,
+				Name:     m[1],
+				Table:    descriptorValue(m[2], "table"),
+			})
+			op = len(result.Operators) - 1
+			continue
+		}
+
+		fields := statFields(text)
+		switch {
+		case len(fields) == 0:
+		case op < 0:
+			applyStageStats(&result.Stages[stage], fields)
+		default:
+			applyOperatorStats(&result.Operators[op], fields)
+		}
+	}
+	return result
+}
+
+// statFields splits a statistics line such as "CPU: 1.00ms (2.56%),
+// Output: 1 row (9B)" into its values by key. Lines that do not start
+// with a known key give nil.
+func statFields(line string) map[string]string {
+	key, _, ok := strings.Cut(line, ":")
+	if !ok || !isStatKey(key) {
+		return nil
+	}
+	fields := make(map[string]string)
+	for _, part := range strings.FieldsFunc(nestedStats.ReplaceAllString(line, ""), func(r rune) bool {
+		return r == ',' || r == ';'
+	}) {
+		k, v, ok := strings.Cut(strings.TrimSpace(part), ":")
+		if ok {
+			fields[k] = strings.TrimSpace(v)
+		}
+	}
+	return fields
+}
+
+func isStatKey(key string) bool {
+	switch key {
+	case "CPU", "Scheduled", "Wall", "Input", "Output", "Spilled", "Peak Memory", "Peak memory":
+		return true
+	}
+	return false
+}
+
+func applyStageStats(s *StageStats, fields map[string]string) {
+	if v, ok := fields["CPU"]; ok {
+		s.CPUMs = parseDurationMs(v)
+	}
+	if v, ok := firstField(fields, "Scheduled", "Wall"); ok {
+		s.WallMs = parseDurationMs(v)
+	}
+	if v, ok := fields["Input"]; ok {
+		s.InputRows, s.InputBytes = parseRows(v)
+	}
+	if v, ok := fields["Output"]; ok {
+		s.OutputRows, s.OutputBytes = parseRows(v)
+	}
+	if v, ok := firstField(fields, "Peak Memory", "Peak memory"); ok {
+		s.PeakMemoryBytes = parseBytes(v)
+	}
+}
+
+func applyOperatorStats(o *OperatorStats, fields map[string]string) {
+	if v, ok := fields["CPU"]; ok {
+		o.CPUMs = parseDurationMs(v)
+		if m := percentValue.FindStringSubmatch(v); m != nil {
+			o.CPUPercent, _ = strconv.ParseFloat(m[1], 64)
+		}
+	}
+	if v, ok := firstField(fields, "Scheduled", "Wall"); ok {
+		o.WallMs = parseDurationMs(v)
+	}
+	if v, ok := fields["Input"]; ok {
+		o.InputRows, o.InputBytes = parseRows(v)
+	}
+	if v, ok := fields["Output"]; ok {
+		o.OutputRows, o.OutputBytes = parseRows(v)
+	}
+	if v, ok := fields["Spilled"]; ok {
+		o.SpilledBytes = parseBytes(v)
+	}
+}
+
+func firstField(fields map[string]string, keys ...string) (string, bool) {
+	for _, k := range keys {
+		if v, ok := fields[k]; ok {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// descriptorValue returns the value of key in a node descriptor such as
+// "[table = hive:sales:orders, filterPredicate = ...]".
+func descriptorValue(descriptor, key string) string {
+	_, rest, ok := strings.Cut(descriptor, key+" = ")
+	if !ok {
+		return ""
+	}
+	end := strings.IndexAny(rest, ",]")
+	if end < 0 {
+		return rest
+	}
+	return rest[:end]
+}
+
+// parseDurationMs parses a Trino duration such as 20.32ms or 1.50m into
+// milliseconds.
+func parseDurationMs(s string) float64 {
+	m := durationValue.FindStringSubmatch(s)
+	if m == nil {
+		return 0
+	}
+	v, _ := strconv.ParseFloat(m[1], 64)
+	switch m[2] {
+	case "ns":
+		return v / 1e6
+	case "us":
+		return v / 1e3
+	case "s":
+		return v * 1e3
+	case "m":
+		return v * 60e3
+	case "h":
+		return v * 3600e3
+	case "d":
+		return v * 86400e3
+	}
+	return v
+}
+
+// parseRows parses a row count with its size, such as "1500 rows (12kB)"
+// or "1.50M rows (1.2GB)".
+func parseRows(s string) (rows, bytes int64) {
+	if m := rowsValue.FindStringSubmatch(s); m != nil {
+		v, _ := strconv.ParseFloat(m[1], 64)
+		rows = int64(v * float64(countScale(m[2])))
+	}
+	if _, size, ok := strings.Cut(s, "("); ok {
+		bytes = parseBytes(size)
+	}
+	return rows, bytes
+}
+
+func countScale(suffix string) int64 {
+	switch suffix {
+	case "K":
+		return 1e3
+	case "M":
+		return 1e6
+	case "B":
+		return 1e9
+	case "T":
+		return 1e12
+	}
+	return 1
+}
+
+// parseBytes parses the first data size in s, such as 1.23kB or 4GB.
+// Trino's units are powers of 1024.
+func parseBytes(s string) int64 {
+	m := bytesValue.FindStringSubmatch(s)
+	if m == nil {
+		return 0
+	}
+	v, _ := strconv.ParseFloat(m[1], 64)
+	shift := strings.Index("BkMGTP", m[2][:1]) * 10
+	return int64(v * float64(int64(1)<<shift))
+}
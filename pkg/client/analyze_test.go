@@ -0,0 +1,164 @@
+package client
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestParseExplainAnalyze(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "explain_analyze.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := ParseExplainAnalyze(string(data))
+
+	wantStages := []StageStats{
+		{
+			Fragment: "1", Distribution: "HASH", CPUMs: 1120, WallMs: 1510,
+			InputRows: 150000, InputBytes: 2998927, OutputRows: 5, OutputBytes: 135, PeakMemoryBytes: 6543114,
+		},
+		{
+			Fragment: "2", Distribution: "SOURCE", CPUMs: 1170, WallMs: 1720,
+			InputRows: 1500000, OutputRows: 150000, OutputBytes: 2998927, PeakMemoryBytes: 1069547,
+		},
+	}
+	if !reflect.DeepEqual(result.Stages, wantStages) {
+		t.Errorf("stages:\ngot:  %+v\nwant: %+v", result.Stages, wantStages)
+	}
+
+	wantOperators := []OperatorStats{
+		{
+			Fragment: "1", Name: "Aggregate", CPUMs: 410, CPUPercent: 25.15, WallMs: 520,
+			OutputRows: 5, OutputBytes: 135, SpilledBytes: 1572864,
+		},
+		{Fragment: "1", Name: "LocalExchange", CPUMs: 35, CPUPercent: 2.15, WallMs: 41, OutputRows: 150000, OutputBytes: 2998927},
+		{Fragment: "1", Name: "RemoteSource", CPUMs: 12, CPUPercent: 0.74, WallMs: 15, OutputRows: 150000, OutputBytes: 2998927},
+		{Fragment: "2", Name: "Aggregate", CPUMs: 90, CPUPercent: 5.52, WallMs: 110, OutputRows: 150000, OutputBytes: 2998927},
+		{
+			Fragment: "2", Name: "TableScan", Table: "tpch:sf1:orders", CPUMs: 1080, CPUPercent: 66.26, WallMs: 1610,
+			InputRows: 1500000, InputBytes: 13495173, OutputRows: 1500000, OutputBytes: 13495173,
+		},
+	}
+	if !reflect.DeepEqual(result.Operators, wantOperators) {
+		t.Errorf("operators:\ngot:  %+v\nwant: %+v", result.Operators, wantOperators)
+	}
+
+	var hot []string
+	for _, op := range result.HotOperators(3) {
+		hot = append(hot, op.Fragment+":"+op.Name)
+	}
+	if want := []string{"2:TableScan", "1:Aggregate", "2:Aggregate"}; !reflect.DeepEqual(hot, want) {
+		t.Errorf("HotOperators(3) = %v, want %v", hot, want)
+	}
+}
+
+func TestParseExplainAnalyze_Values(t *testing.T) {
+	durations := map[string]float64{
+		"0.00ns": 0, "312.15us": 0.31215, "20.32ms": 20.32, "1.63s": 1630, "1.50m": 90000, "2.00h": 7.2e6,
+	}
+	for in, want := range durations {
+		if got := parseDurationMs(in); got != want {
+			t.Errorf("parseDurationMs(%q) = %v, want %v", in, got, want)
+		}
+	}
+
+	rows := map[string][2]int64{
+		"1 row (9B)":          {1, 9},
+		"1500 rows (0B)":      {1500, 0},
+		"1.50M rows (1.00GB)": {1500000, 1 << 30},
+		"2.00B rows (4TB)":    {2000000000, 4 << 40},
+	}
+	for in, want := range rows {
+		if r, b := parseRows(in); r != want[0] || b != want[1] {
+			t.Errorf("parseRows(%q) = %d, %d, want %d, %d", in, r, b, want[0], want[1])
+		}
+	}
+}
+
+func TestClient_ExplainAnalyze(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	client := NewWithDB(db, Config{Host: "localhost", Port: 8080, User: "test", Timeout: 30 * time.Second})
+
+	tests := []struct {
+		verbose bool
+		query   string
+	}{
+		{false, `^EXPLAIN ANALYZE SELECT 1$`},
+		{true, `^EXPLAIN ANALYZE VERBOSE SELECT 1$`},
+	}
+	for _, tt := range tests {
+		rows := sqlmock.NewRows([]string{"Query Plan"}).
+			AddRow("Fragment 0 [SINGLE]\n    CPU: 1.00ms, Scheduled: 2.00ms, Input: 1 row (9B); per task: avg.: 1.00, Output: 1 row (9B)")
+		mock.ExpectQuery(tt.query).WillReturnRows(rows)
+
+		result, err := client.ExplainAnalyze(context.Background(), "SELECT 1", tt.verbose, QueryOptions{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Verbose != tt.verbose || len(result.Stages) != 1 || result.Stages[0].WallMs != 2 {
+			t.Errorf("unexpected result: %+v", result)
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestClient_ExplainAnalyze_Cancels(t *testing.T) {
+	tests := []struct {
+		name    string
+		timeout time.Duration
+	}{
+		{name: "context cancelled"},
+		{name: "timeout", timeout: 200 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeTrino(t)
+			f.hold = true
+			c := newFakeTrinoClient(t, f)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			done := make(chan error, 1)
+			go func() {
+				_, err := c.ExplainAnalyze(ctx, "SELECT 1", false, QueryOptions{Timeout: tt.timeout})
+				done <- err
+			}()
+
+			if tt.timeout == 0 {
+				// Give the query ID time to arrive before the context ends
+				waitFor(t, func() bool { return len(f.Requests()) > 0 })
+				time.Sleep(200 * time.Millisecond)
+				cancel()
+			}
+
+			// The query stays RUNNING on the coordinator until it is cancelled
+			waitFor(t, func() bool { return len(f.Cancels()) > 0 })
+			if got := f.Cancels()[0].Query; got != fakeQueryID {
+				t.Errorf("expected query %s to be cancelled, got %q", fakeQueryID, got)
+			}
+			if got := f.Requests()[0].Query; got != "EXPLAIN ANALYZE SELECT 1" {
+				t.Errorf("expected EXPLAIN ANALYZE SELECT 1, got %q", got)
+			}
+			select {
+			case <-done:
+			case <-time.After(5 * time.Second):
+				t.Fatal("ExplainAnalyze did not return after the query was cancelled")
+			}
+		})
+	}
+}
@@ -0,0 +1,350 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	krbclient "github.com/jcmturner/gokrb5/v8/client"
+	krbconfig "github.com/jcmturner/gokrb5/v8/config"
+	"github.com/jcmturner/gokrb5/v8/keytab"
+	"github.com/jcmturner/gokrb5/v8/spnego"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// AuthMethod identifies how the client authenticates to Trino.
+type AuthMethod string
+
+// Supported authentication methods.
+const (
+	// AuthNone sends no credentials; Trino trusts the X-Trino-User header.
+	AuthNone AuthMethod = "none"
+
+	// AuthBasic sends Config.User and Config.Password (HTTPS only).
+	AuthBasic AuthMethod = "basic"
+
+	// AuthJWT sends a static or file-based JWT access token.
+	AuthJWT AuthMethod = "jwt"
+
+	// AuthOAuth2 obtains and refreshes access tokens with the OAuth2
+	// client credentials grant.
+	AuthOAuth2 AuthMethod = "oauth2"
+
+	// AuthKerberos authenticates with SPNEGO using a keytab.
+	AuthKerberos AuthMethod = "kerberos"
+
+	// AuthCertificate authenticates with a TLS client certificate.
+	AuthCertificate AuthMethod = "certificate"
+)
+
+// DefaultKerberosConfigPath is the krb5.conf used when AuthConfig.KerberosConfig is empty.
+const DefaultKerberosConfigPath = "/etc/krb5.conf"
+
+// tokenFileRefresh is how often a token file is re-read, so rotated tokens
+// are picked up without rereading the file for every request.
+const tokenFileRefresh = time.Minute
+
+// AuthConfig selects and configures how a connection authenticates.
+// Only the fields of the selected method are used.
+type AuthConfig struct {
+	// Method is the authentication method. When empty it is inferred from the
+	// fields that are set, falling back to basic when Config.Password is set
+	// and none otherwise.
+	Method AuthMethod `json:"method,omitempty" yaml:"method,omitempty"`
+
+	// Token is a JWT access token (jwt).
+	Token string `json:"token,omitempty" yaml:"token,omitempty"`
+
+	// TokenFile is a file holding a JWT access token, re-read periodically
+	// so rotated tokens are picked up (jwt).
+	TokenFile string `json:"token_file,omitempty" yaml:"token_file,omitempty"`
+
+	// TokenURL is the OAuth2 token endpoint (oauth2).
+	TokenURL string `json:"token_url,omitempty" yaml:"token_url,omitempty"`
+
+	// ClientID and ClientSecret are the OAuth2 client credentials (oauth2).
+	ClientID     string `json:"client_id,omitempty" yaml:"client_id,omitempty"`
+	ClientSecret string `json:"client_secret,omitempty" yaml:"client_secret,omitempty"`
+
+	// Scopes are the OAuth2 scopes to request (oauth2).
+	Scopes []string `json:"scopes,omitempty" yaml:"scopes,omitempty"`
+
+	// Audience is sent as the audience parameter of the token request,
+	// for providers that require it (oauth2).
+	Audience string `json:"audience,omitempty" yaml:"audience,omitempty"`
+
+	// KerberosPrincipal and KerberosRealm identify the client (kerberos).
+	KerberosPrincipal string `json:"kerberos_principal,omitempty" yaml:"kerberos_principal,omitempty"`
+	KerberosRealm     string `json:"kerberos_realm,omitempty" yaml:"kerberos_realm,omitempty"`
+
+	// KerberosKeytab is the keytab holding the principal's key (kerberos).
+	KerberosKeytab string `json:"kerberos_keytab,omitempty" yaml:"kerberos_keytab,omitempty"`
+
+	// KerberosConfig is the krb5.conf path. Default: /etc/krb5.conf (kerberos).
+	KerberosConfig string `json:"kerberos_config,omitempty" yaml:"kerberos_config,omitempty"`
+
+	// KerberosServiceName is the coordinator's service name. Default: "trino" (kerberos).
+	KerberosServiceName string `json:"kerberos_service_name,omitempty" yaml:"kerberos_service_name,omitempty"`
+
+	// ClientCert and ClientKey are PEM files for the TLS client certificate (certificate).
+	ClientCert string `json:"client_cert,omitempty" yaml:"client_cert,omitempty"`
+	ClientKey  string `json:"client_key,omitempty" yaml:"client_key,omitempty"`
+}
+
+// ResolvedMethod returns the method in use, inferring it when Method is
+// empty. password is the connection's Config.Password.
+func (a AuthConfig) ResolvedMethod(password string) AuthMethod {
+	switch {
+	case a.Method != "":
+		return a.Method
+	case a.Token != "" || a.TokenFile != "":
+		return AuthJWT
+	case a.TokenURL != "":
+		return AuthOAuth2
+	case a.KerberosKeytab != "":
+		return AuthKerberos
+	case a.ClientCert != "":
+		return AuthCertificate
+	case password != "":
+		return AuthBasic
+	default:
+		return AuthNone
+	}
+}
+
+// validate checks that the fields the method needs are set.
+func (a AuthConfig) validate(password string) error {
+	switch method := a.ResolvedMethod(password); method {
+	case AuthNone:
+		return nil
+	case AuthBasic:
+		if password == "" {
+			return fmt.Errorf("basic auth requires a password")
+		}
+	case AuthJWT:
+		if a.Token == "" && a.TokenFile == "" {
+			return fmt.Errorf("jwt auth requires a token or token file")
+		}
+	case AuthOAuth2:
+		if a.TokenURL == "" || a.ClientID == "" || a.ClientSecret == "" {
+			return fmt.Errorf("oauth2 auth requires a token URL, client ID, and client secret")
+		}
+	case AuthKerberos:
+		if a.KerberosPrincipal == "" || a.KerberosRealm == "" || a.KerberosKeytab == "" {
+			return fmt.Errorf("kerberos auth requires a principal, realm, and keytab")
+		}
+	case AuthCertificate:
+		if a.ClientCert == "" || a.ClientKey == "" {
+			return fmt.Errorf("certificate auth requires a client certificate and key")
+		}
+	default:
+		return fmt.Errorf("unknown auth method %q", method)
+	}
+	return nil
+}
+
+// ApplyAuthEnv overrides auth settings from environment variables:
+//   - TRINO_AUTH_METHOD: none, basic, jwt, oauth2, kerberos, or certificate
+//   - TRINO_ACCESS_TOKEN, TRINO_ACCESS_TOKEN_FILE: JWT access token
+//   - TRINO_OAUTH2_TOKEN_URL, TRINO_OAUTH2_CLIENT_ID, TRINO_OAUTH2_CLIENT_SECRET
+//   - TRINO_OAUTH2_SCOPES (comma-separated), TRINO_OAUTH2_AUDIENCE
+//   - TRINO_KERBEROS_PRINCIPAL, TRINO_KERBEROS_REALM, TRINO_KERBEROS_KEYTAB
+//   - TRINO_KERBEROS_CONFIG, TRINO_KERBEROS_SERVICE_NAME
+//   - TRINO_CLIENT_CERT, TRINO_CLIENT_KEY: TLS client certificate
+func ApplyAuthEnv(a AuthConfig) AuthConfig {
+	setString := func(dst *string, key string) {
+		if v := os.Getenv(key); v != "" {
+			*dst = v
+		}
+	}
+
+	if v := os.Getenv("TRINO_AUTH_METHOD"); v != "" {
+		a.Method = AuthMethod(strings.ToLower(v))
+	}
+	setString(&a.Token, "TRINO_ACCESS_TOKEN")
+	setString(&a.TokenFile, "TRINO_ACCESS_TOKEN_FILE")
+	setString(&a.TokenURL, "TRINO_OAUTH2_TOKEN_URL")
+	setString(&a.ClientID, "TRINO_OAUTH2_CLIENT_ID")
+	setString(&a.ClientSecret, "TRINO_OAUTH2_CLIENT_SECRET")
+	if v := os.Getenv("TRINO_OAUTH2_SCOPES"); v != "" {
+		a.Scopes = strings.Split(v, ",")
+	}
+	setString(&a.Audience, "TRINO_OAUTH2_AUDIENCE")
+	setString(&a.KerberosPrincipal, "TRINO_KERBEROS_PRINCIPAL")
+	setString(&a.KerberosRealm, "TRINO_KERBEROS_REALM")
+	setString(&a.KerberosKeytab, "TRINO_KERBEROS_KEYTAB")
+	setString(&a.KerberosConfig, "TRINO_KERBEROS_CONFIG")
+	setString(&a.KerberosServiceName, "TRINO_KERBEROS_SERVICE_NAME")
+	setString(&a.ClientCert, "TRINO_CLIENT_CERT")
+	setString(&a.ClientKey, "TRINO_CLIENT_KEY")
+	return a
+}
+
+// authenticator adds credentials to a coordinator request.
+type authenticator interface {
+	authenticate(req *http.Request) error
+}
+
+// newAuthenticator returns the authenticator for cfg's auth method, or nil
+// when requests carry no credentials (none, or certificate, which
+// authenticates in the TLS handshake). base is the transport used to reach
+// an OAuth2 token endpoint.
+func newAuthenticator(cfg Config, base http.RoundTripper) (authenticator, error) {
+	a := cfg.Auth
+	switch a.ResolvedMethod(cfg.Password) {
+	case AuthBasic:
+		return basicAuth{user: cfg.User, password: cfg.Password}, nil
+	case AuthJWT:
+		if a.Token != "" {
+			return bearerAuth{source: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: a.Token})}, nil
+		}
+		return bearerAuth{source: &fileTokenSource{path: a.TokenFile}}, nil
+	case AuthOAuth2:
+		cc := &clientcredentials.Config{
+			ClientID:     a.ClientID,
+			ClientSecret: a.ClientSecret,
+			TokenURL:     a.TokenURL,
+			Scopes:       a.Scopes,
+		}
+		if a.Audience != "" {
+			cc.EndpointParams = url.Values{"audience": {a.Audience}}
+		}
+		ctx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Transport: base})
+		return bearerAuth{source: cc.TokenSource(ctx)}, nil
+	case AuthKerberos:
+		return newKerberosAuth(a)
+	default:
+		return nil, nil
+	}
+}
+
+// basicAuth sends HTTP basic credentials. Like the Trino driver, it only
+// does so over HTTPS so passwords are never sent in the clear.
+type basicAuth struct {
+	user     string
+	password string
+}
+
+func (b basicAuth) authenticate(req *http.Request) error {
+	if req.URL.Scheme == "https" {
+		req.SetBasicAuth(b.user, b.password)
+	}
+	return nil
+}
+
+// bearerAuth sends the access token from source. The OAuth2 client
+// credentials source caches its token and fetches a new one as it expires.
+type bearerAuth struct {
+	source oauth2.TokenSource
+}
+
+func (b bearerAuth) authenticate(req *http.Request) error {
+	token, err := b.source.Token()
+	if err != nil {
+		return fmt.Errorf("failed to get access token: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	return nil
+}
+
+// fileTokenSource reads an access token from a file, re-reading it every
+// tokenFileRefresh.
+type fileTokenSource struct {
+	path string
+
+	mu    sync.Mutex
+	token string
+	read  time.Time
+}
+
+// Token implements oauth2.TokenSource.
+func (f *fileTokenSource) Token() (*oauth2.Token, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.token == "" || time.Since(f.read) >= tokenFileRefresh {
+		data, err := os.ReadFile(f.path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read token file: %w", err)
+		}
+		token := strings.TrimSpace(string(data))
+		if token == "" {
+			return nil, fmt.Errorf("token file %s is empty", f.path)
+		}
+		f.token = token
+		f.read = time.Now()
+	}
+	return &oauth2.Token{AccessToken: f.token}, nil
+}
+
+// kerberosAuth sends a SPNEGO token for the coordinator's service principal.
+// The client logs in to the KDC on first use.
+type kerberosAuth struct {
+	client  *krbclient.Client
+	service string
+
+	mu       sync.Mutex
+	loggedIn bool
+}
+
+func newKerberosAuth(a AuthConfig) (*kerberosAuth, error) {
+	kt, err := keytab.Load(a.KerberosKeytab)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kerberos keytab: %w", err)
+	}
+	configPath := a.KerberosConfig
+	if configPath == "" {
+		configPath = DefaultKerberosConfigPath
+	}
+	krbCfg, err := krbconfig.Load(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kerberos config: %w", err)
+	}
+	service := a.KerberosServiceName
+	if service == "" {
+		service = "trino"
+	}
+	return &kerberosAuth{
+		client:  krbclient.NewWithKeytab(a.KerberosPrincipal, a.KerberosRealm, kt, krbCfg),
+		service: service,
+	}, nil
+}
+
+func (k *kerberosAuth) authenticate(req *http.Request) error {
+	k.mu.Lock()
+	if !k.loggedIn {
+		if err := k.client.Login(); err != nil {
+			k.mu.Unlock()
+			return fmt.Errorf("kerberos login failed: %w", err)
+		}
+		k.loggedIn = true
+	}
+	k.mu.Unlock()
+
+	if err := spnego.SetSPNEGOHeader(k.client, req, k.service+"/"+req.URL.Hostname()); err != nil {
+		return fmt.Errorf("failed to set kerberos header: %w", err)
+	}
+	return nil
+}
+
+// authTransport adds credentials to each request before passing it to base.
+type authTransport struct {
+	base http.RoundTripper
+	auth authenticator
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// A RoundTripper must not modify the caller's request
+	req = req.Clone(req.Context())
+	if err := t.auth.authenticate(req); err != nil {
+		return nil, err
+	}
+	return t.base.RoundTrip(req)
+}
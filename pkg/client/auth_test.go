@@ -0,0 +1,379 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAuthConfig_ResolvedMethod(t *testing.T) {
+	tests := []struct {
+		name     string
+		auth     AuthConfig
+		password string
+		want     AuthMethod
+	}{
+		{name: "nothing set", want: AuthNone},
+		{name: "password", password: "secret", want: AuthBasic},
+		{name: "token", auth: AuthConfig{Token: "t"}, password: "secret", want: AuthJWT},
+		{name: "token file", auth: AuthConfig{TokenFile: "/run/token"}, want: AuthJWT},
+		{name: "token url", auth: AuthConfig{TokenURL: "https://idp/token"}, want: AuthOAuth2},
+		{name: "keytab", auth: AuthConfig{KerberosKeytab: "/etc/trino.keytab"}, want: AuthKerberos},
+		{name: "client cert", auth: AuthConfig{ClientCert: "/etc/tls/client.crt"}, want: AuthCertificate},
+		{name: "explicit method wins", auth: AuthConfig{Method: AuthNone, Token: "t"}, want: AuthNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.auth.ResolvedMethod(tt.password); got != tt.want {
+				t.Errorf("ResolvedMethod() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfig_Validate_Auth(t *testing.T) {
+	tests := []struct {
+		name    string
+		user    string
+		auth    AuthConfig
+		wantErr string
+	}{
+		{name: "jwt without user", auth: AuthConfig{Token: "t"}},
+		{name: "oauth2", auth: AuthConfig{TokenURL: "https://idp/token", ClientID: "id", ClientSecret: "s"}},
+		{
+			name:    "oauth2 missing secret",
+			auth:    AuthConfig{TokenURL: "https://idp/token", ClientID: "id"},
+			wantErr: "oauth2 auth requires a token URL, client ID, and client secret",
+		},
+		{
+			name:    "basic without password",
+			user:    "admin",
+			auth:    AuthConfig{Method: AuthBasic},
+			wantErr: "basic auth requires a password",
+		},
+		{
+			name:    "kerberos missing principal",
+			auth:    AuthConfig{KerberosKeytab: "/etc/trino.keytab"},
+			wantErr: "kerberos auth requires a principal, realm, and keytab",
+		},
+		{
+			name:    "certificate missing key",
+			auth:    AuthConfig{ClientCert: "/etc/tls/client.crt"},
+			wantErr: "certificate auth requires a client certificate and key",
+		},
+		{name: "unknown method", user: "admin", auth: AuthConfig{Method: "ldap"}, wantErr: `unknown auth method "ldap"`},
+		{name: "none requires user", auth: AuthConfig{Method: AuthNone}, wantErr: "user is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Config{Host: "localhost", Port: 8080, User: tt.user, Auth: tt.auth}
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestApplyAuthEnv(t *testing.T) {
+	t.Setenv("TRINO_AUTH_METHOD", "OAuth2")
+	t.Setenv("TRINO_OAUTH2_TOKEN_URL", "https://idp.example.com/token")
+	t.Setenv("TRINO_OAUTH2_CLIENT_ID", "mcp")
+	t.Setenv("TRINO_OAUTH2_CLIENT_SECRET", "secret")
+	t.Setenv("TRINO_OAUTH2_SCOPES", "trino,openid")
+	t.Setenv("TRINO_KERBEROS_SERVICE_NAME", "HTTP")
+
+	got := ApplyAuthEnv(AuthConfig{Audience: "kept"})
+
+	if got.Method != AuthOAuth2 {
+		t.Errorf("Method = %q, want oauth2", got.Method)
+	}
+	if got.TokenURL != "https://idp.example.com/token" || got.ClientID != "mcp" || got.ClientSecret != "secret" {
+		t.Errorf("unexpected OAuth2 settings: %+v", got)
+	}
+	if len(got.Scopes) != 2 || got.Scopes[0] != "trino" || got.Scopes[1] != "openid" {
+		t.Errorf("Scopes = %v, want [trino openid]", got.Scopes)
+	}
+	if got.Audience != "kept" {
+		t.Errorf("Audience = %q, want unset env to keep %q", got.Audience, "kept")
+	}
+	if got.KerberosServiceName != "HTTP" {
+		t.Errorf("KerberosServiceName = %q, want HTTP", got.KerberosServiceName)
+	}
+}
+
+func TestClient_Auth_JWT(t *testing.T) {
+	f := newFakeTrino(t)
+	cfg := testServerConfig(t, f.Server)
+	cfg.User = ""
+	cfg.Auth = AuthConfig{Token: "jwt-token"}
+
+	c, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	defer func() { _ = c.Close() }()
+
+	if _, err := c.Query(context.Background(), "SELECT 'ok' AS value", DefaultQueryOptions()); err != nil {
+		t.Fatalf("Query() error: %v", err)
+	}
+	if err := c.CancelQuery(context.Background(), fakeQueryID); err != nil {
+		t.Fatalf("CancelQuery() error: %v", err)
+	}
+
+	requests := f.Requests()
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 statement request, got %d", len(requests))
+	}
+	if got := requests[0].Header.Get("Authorization"); got != "Bearer jwt-token" {
+		t.Errorf("statement Authorization = %q, want bearer token", got)
+	}
+	if got := f.Cancels()[0].Header.Get("Authorization"); got != "Bearer jwt-token" {
+		t.Errorf("cancel Authorization = %q, want bearer token", got)
+	}
+}
+
+func TestClient_Auth_BasicOnlyOverHTTPS(t *testing.T) {
+	f := newFakeTrino(t)
+	cfg := testServerConfig(t, f.Server)
+	cfg.Password = "secret"
+
+	c, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	defer func() { _ = c.Close() }()
+
+	if _, err := c.Query(context.Background(), "SELECT 'ok' AS value", DefaultQueryOptions()); err != nil {
+		t.Fatalf("Query() error: %v", err)
+	}
+	if got := f.Requests()[0].Header.Get("Authorization"); got != "" {
+		t.Errorf("expected no credentials over plain HTTP, got Authorization %q", got)
+	}
+}
+
+// fakeTokenEndpoint is an OAuth2 token endpoint that issues numbered tokens.
+type fakeTokenEndpoint struct {
+	*httptest.Server
+	expiresIn int
+
+	mu      sync.Mutex
+	fetches int
+	form    map[string]string
+}
+
+func newFakeTokenEndpoint(t *testing.T, expiresIn int) *fakeTokenEndpoint {
+	t.Helper()
+	e := &fakeTokenEndpoint{expiresIn: expiresIn}
+	e.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		id, secret, _ := r.BasicAuth()
+
+		e.mu.Lock()
+		e.fetches++
+		e.form = map[string]string{
+			"grant_type":    r.PostForm.Get("grant_type"),
+			"scope":         r.PostForm.Get("scope"),
+			"audience":      r.PostForm.Get("audience"),
+			"client_id":     id,
+			"client_secret": secret,
+		}
+		token := fmt.Sprintf("token-%d", e.fetches)
+		e.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": token,
+			"token_type":   "Bearer",
+			"expires_in":   e.expiresIn,
+		})
+	}))
+	t.Cleanup(e.Close)
+	return e
+}
+
+func (e *fakeTokenEndpoint) Fetches() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.fetches
+}
+
+func TestClient_Auth_OAuth2(t *testing.T) {
+	tests := []struct {
+		name        string
+		expiresIn   int
+		wantFetches int
+		wantLast    string
+	}{
+		// Tokens are reused until they expire
+		{name: "cached", expiresIn: 3600, wantFetches: 1, wantLast: "Bearer token-1"},
+		// Tokens within the refresh window are replaced before each request
+		{name: "refreshed", expiresIn: 1, wantFetches: 2, wantLast: "Bearer token-2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idp := newFakeTokenEndpoint(t, tt.expiresIn)
+			f := newFakeTrino(t)
+			cfg := testServerConfig(t, f.Server)
+			cfg.Auth = AuthConfig{
+				TokenURL:     idp.URL,
+				ClientID:     "mcp-trino",
+				ClientSecret: "s3cret",
+				Scopes:       []string{"trino"},
+				Audience:     "trino-cluster",
+			}
+
+			c, err := New(cfg)
+			if err != nil {
+				t.Fatalf("New() error: %v", err)
+			}
+			defer func() { _ = c.Close() }()
+
+			// CancelQuery makes exactly one coordinator request, unlike a
+			// query's polling, so the expected fetch count is exact
+			if err := c.CancelQuery(context.Background(), fakeQueryID); err != nil {
+				t.Fatalf("CancelQuery() error: %v", err)
+			}
+			if err := c.CancelQuery(context.Background(), fakeQueryID); err != nil {
+				t.Fatalf("CancelQuery() error: %v", err)
+			}
+
+			if got := idp.Fetches(); got != tt.wantFetches {
+				t.Errorf("token fetches = %d, want %d", got, tt.wantFetches)
+			}
+			cancels := f.Cancels()
+			if got := cancels[len(cancels)-1].Header.Get("Authorization"); got != tt.wantLast {
+				t.Errorf("Authorization = %q, want %q", got, tt.wantLast)
+			}
+
+			idp.mu.Lock()
+			form := idp.form
+			idp.mu.Unlock()
+			want := map[string]string{
+				"grant_type":    "client_credentials",
+				"scope":         "trino",
+				"audience":      "trino-cluster",
+				"client_id":     "mcp-trino",
+				"client_secret": "s3cret",
+			}
+			for k, v := range want {
+				if form[k] != v {
+					t.Errorf("token request %s = %q, want %q", k, form[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestClient_Auth_OAuth2_TokenError(t *testing.T) {
+	idp := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, `{"error":"invalid_client"}`, http.StatusUnauthorized)
+	}))
+	defer idp.Close()
+	f := newFakeTrino(t)
+	cfg := testServerConfig(t, f.Server)
+	cfg.Auth = AuthConfig{TokenURL: idp.URL, ClientID: "mcp-trino", ClientSecret: "wrong"}
+
+	c, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	defer func() { _ = c.Close() }()
+
+	_, err = c.Query(context.Background(), "SELECT 1", DefaultQueryOptions())
+	if err == nil || !strings.Contains(err.Error(), "failed to get access token") {
+		t.Errorf("Query() error = %v, want access token error", err)
+	}
+	if len(f.Requests()) != 0 {
+		t.Error("expected no unauthenticated request to reach the coordinator")
+	}
+}
+
+func TestFileTokenSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(path, []byte("first\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	src := &fileTokenSource{path: path}
+
+	tok, err := src.Token()
+	if err != nil || tok.AccessToken != "first" {
+		t.Fatalf("Token() = %v, %v; want first", tok, err)
+	}
+
+	if err := os.WriteFile(path, []byte("second"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if tok, _ := src.Token(); tok.AccessToken != "first" {
+		t.Errorf("expected cached token before refresh, got %q", tok.AccessToken)
+	}
+
+	src.read = time.Now().Add(-tokenFileRefresh)
+	if tok, _ := src.Token(); tok.AccessToken != "second" {
+		t.Errorf("expected rotated token after refresh, got %q", tok.AccessToken)
+	}
+
+	if _, err := (&fileTokenSource{path: filepath.Join(t.TempDir(), "missing")}).Token(); err == nil {
+		t.Error("expected error for missing token file")
+	}
+}
+
+func TestNew_AuthSetupErrors(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name    string
+		auth    AuthConfig
+		wantErr string
+	}{
+		{
+			name: "missing keytab",
+			auth: AuthConfig{
+				KerberosPrincipal: "mcp",
+				KerberosRealm:     "EXAMPLE.COM",
+				KerberosKeytab:    filepath.Join(dir, "missing.keytab"),
+			},
+			wantErr: "failed to load kerberos keytab",
+		},
+		{
+			name: "missing client certificate",
+			auth: AuthConfig{
+				ClientCert: filepath.Join(dir, "client.crt"),
+				ClientKey:  filepath.Join(dir, "client.key"),
+			},
+			wantErr: "failed to load client certificate",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.User = "mcp"
+			cfg.Auth = tt.auth
+
+			_, err := New(cfg)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("New() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// cancelQueryTimeout bounds the DELETE sent when a query's context ends.
+const cancelQueryTimeout = 5 * time.Second
+
+// CancelQuery asks the coordinator to cancel the query with the given ID.
+// The request is made as the session user from ctx (see WithUser), or the
+// configured user, and authenticates like the connection's queries.
+// Cancelling a query that has already finished is not an error.
+func (c *Client) CancelQuery(ctx context.Context, queryID string) error {
+	if queryID == "" {
+		return fmt.Errorf("query ID is required")
+	}
+
+	endpoint := c.config.baseURL() + "/v1/query/" + url.PathEscape(queryID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build cancel request: %w", err)
+	}
+
+	user := UserFromContext(ctx)
+	if user == "" {
+		user = c.config.User
+	}
+	if user != "" {
+		req.Header.Set(trinoUserHeader, user)
+	}
+	if c.config.Source != "" {
+		req.Header.Set("X-Trino-Source", c.config.Source)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to cancel query %s: %w", queryID, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound, http.StatusGone:
+		return nil
+	default:
+		return fmt.Errorf("failed to cancel query %s: coordinator returned %s", queryID, resp.Status)
+	}
+}
+
+// cancelOnDone arranges for the query identified by queryID to be cancelled
+// on the coordinator if ctx ends before the returned stop func is called. The
+// database/sql driver only abandons its rows on cancellation, which would
+// leave the query running on the cluster.
+func (c *Client) cancelOnDone(ctx context.Context, queryID func() string) (stop func() bool) {
+	return context.AfterFunc(ctx, func() {
+		id := queryID()
+		if id == "" {
+			return
+		}
+		// Keep the session user from ctx, but not its cancellation
+		cancelCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cancelQueryTimeout)
+		defer cancel()
+		_ = c.CancelQuery(cancelCtx, id)
+	})
+}
@@ -0,0 +1,126 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClient_CancelQuery(t *testing.T) {
+	f := newFakeTrino(t)
+	c := newFakeTrinoClient(t, f)
+
+	if err := c.CancelQuery(WithUser(context.Background(), "alice"), fakeQueryID); err != nil {
+		t.Fatalf("CancelQuery() error: %v", err)
+	}
+
+	cancels := f.Cancels()
+	if len(cancels) != 1 {
+		t.Fatalf("expected 1 cancel request, got %d", len(cancels))
+	}
+	if cancels[0].Query != fakeQueryID {
+		t.Errorf("expected query %s to be cancelled, got %q", fakeQueryID, cancels[0].Query)
+	}
+	if got := cancels[0].Header.Get(trinoUserHeader); got != "alice" {
+		t.Errorf("expected X-Trino-User alice, got %q", got)
+	}
+
+	if err := c.CancelQuery(context.Background(), ""); err == nil {
+		t.Error("expected error for empty query ID")
+	}
+}
+
+func TestClient_CancelQuery_Status(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr bool
+	}{
+		{name: "no content", status: http.StatusNoContent},
+		{name: "already finished", status: http.StatusNotFound},
+		{name: "unauthorized", status: http.StatusUnauthorized, wantErr: true},
+		{name: "server error", status: http.StatusInternalServerError, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			c := NewWithDB(nil, testServerConfig(t, srv))
+
+			err := c.CancelQuery(context.Background(), fakeQueryID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CancelQuery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), fakeQueryID) {
+				t.Errorf("expected error to name the query, got %v", err)
+			}
+		})
+	}
+}
+
+func TestClient_QueryStream_CancelsOnContextDone(t *testing.T) {
+	f := newFakeTrino(t)
+	f.hold = true
+	c := newFakeTrinoClient(t, f)
+
+	ctx, cancel := context.WithCancel(WithUser(context.Background(), "alice"))
+	rows, err := c.QueryStream(ctx, "SELECT 'ok' AS value", QueryOptions{})
+	if err != nil {
+		t.Fatalf("QueryStream() error: %v", err)
+	}
+	defer func() { _ = rows.Close() }()
+	if !rows.Next() {
+		t.Fatalf("expected first row, err: %v", rows.Err())
+	}
+
+	// The query ID arrives through the driver's asynchronous progress callback
+	waitFor(t, func() bool { return rows.Stats().QueryID != "" })
+
+	// The driver only cancels from Rows.Close, so a cancel arriving while
+	// the rows are still open comes from the client
+	cancel()
+	waitFor(t, func() bool { return len(f.Cancels()) > 0 })
+	cancels := f.Cancels()
+	if cancels[0].Query != fakeQueryID {
+		t.Errorf("expected query %s to be cancelled, got %q", fakeQueryID, cancels[0].Query)
+	}
+	if got := cancels[0].Header.Get(trinoUserHeader); got != "alice" {
+		t.Errorf("expected X-Trino-User alice, got %q", got)
+	}
+}
+
+func TestClient_Query_NoCancelAfterFinish(t *testing.T) {
+	f := newFakeTrino(t)
+	c := newFakeTrinoClient(t, f)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if _, err := c.Query(ctx, "SELECT 'ok' AS value", DefaultQueryOptions()); err != nil {
+		t.Fatalf("Query() error: %v", err)
+	}
+	cancel()
+
+	// A finished query needs no cancel; give a stray one time to arrive
+	time.Sleep(50 * time.Millisecond)
+	if cancels := f.Cancels(); len(cancels) != 0 {
+		t.Errorf("expected no cancel for a finished query, got %d", len(cancels))
+	}
+}
+
+// waitFor polls cond until it holds, failing the test after five seconds.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for condition")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
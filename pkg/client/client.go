@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"strings"
 	"sync"
 	"time"
@@ -14,8 +15,10 @@ import (
 
 // Client is a wrapper around the Trino database connection.
 type Client struct {
-	db     *sql.DB
-	config Config
+	db           *sql.DB
+	config       Config
+	httpClient   *http.Client // coordinator requests outside the driver, e.g. CancelQuery
+	driverClient string       // custom_client name registered with the driver by New
 }
 
 // New creates a new Trino client with the given configuration.
@@ -24,33 +27,57 @@ func New(cfg Config) (*Client, error) {
 		return nil, fmt.Errorf("invalid config: %w", err)
 	}
 
-	db, err := sql.Open("trino", cfg.DSN())
+	// The driver's requests go through a statementTransport so that query
+	// statistics and warnings, which the driver drops, can be reported
+	httpClient, err := newHTTPClient(cfg)
 	if err != nil {
+		return nil, err
+	}
+	driverClient, err := registerDriverClient(httpClient)
+	if err != nil {
+		return nil, err
+	}
+
+	// httpClient authenticates every request, so the driver must not add
+	// basic auth from the DSN itself
+	driverCfg := cfg
+	driverCfg.Password = ""
+	db, err := sql.Open("trino", driverCfg.DSN()+"&custom_client="+driverClient)
+	if err != nil {
+		trino.DeregisterCustomClient(driverClient)
 		return nil, fmt.Errorf("failed to open connection: %w", err)
 	}
 
 	// Set connection pool settings
-	db.SetMaxOpenConns(10)
-	db.SetMaxIdleConns(5)
-	db.SetConnMaxLifetime(5 * time.Minute)
+	cfg.Pool.apply(db)
 
 	return &Client{
-		db:     db,
-		config: cfg,
+		db:           db,
+		config:       cfg,
+		httpClient:   httpClient,
+		driverClient: driverClient,
 	}, nil
 }
 
 // NewWithDB creates a new client with an existing database connection.
 // This is primarily useful for testing with mock databases.
 func NewWithDB(db *sql.DB, cfg Config) *Client {
+	httpClient, err := newHTTPClient(cfg)
+	if err != nil {
+		httpClient = &http.Client{Transport: errTransport{err: err}}
+	}
 	return &Client{
-		db:     db,
-		config: cfg,
+		db:         db,
+		config:     cfg,
+		httpClient: httpClient,
 	}
 }
 
 // Close closes the database connection.
 func (c *Client) Close() error {
+	if c.driverClient != "" {
+		defer trino.DeregisterCustomClient(c.driverClient)
+	}
 	return c.db.Close()
 }
 
@@ -69,8 +96,9 @@ type QueryResult struct {
 	Columns []ColumnInfo     `json:"columns"`
 	Rows    []map[string]any `json:"rows"`
 	Stats   QueryStats       `json:"stats"`
-	// Note: Trino REST API returns warnings, but trino-go-client v0.333.0
-	// does not expose them. See: https://github.com/trinodb/trino-go-client/issues
+
+	// Warnings are the warnings Trino reported for the query.
+	Warnings []QueryWarning `json:"warnings,omitempty"`
 }
 
 // ColumnInfo describes a column in the result set.
@@ -81,27 +109,69 @@ type ColumnInfo struct {
 }
 
 // QueryStats contains execution statistics.
+//
+// The fields after QueryID come from the coordinator and are only set for
+// clients created with New; they reflect the query as of the last page read.
 type QueryStats struct {
 	RowCount     int    `json:"row_count"`
 	DurationMs   int64  `json:"duration_ms"`
 	Truncated    bool   `json:"truncated"`
 	LimitApplied int    `json:"limit_applied,omitempty"`
 	QueryID      string `json:"query_id,omitempty"`
+
+	// Attempts is the number of times the statement was run, more than
+	// one when transient failures were retried (see Config.Retry).
+	Attempts int `json:"attempts,omitempty"`
+
+	State           string `json:"state,omitempty"`
+	CPUTimeMs       int64  `json:"cpu_time_ms,omitempty"`
+	WallTimeMs      int64  `json:"wall_time_ms,omitempty"`
+	QueuedTimeMs    int64  `json:"queued_time_ms,omitempty"`
+	ProcessedRows   int64  `json:"processed_rows,omitempty"`
+	ProcessedBytes  int64  `json:"processed_bytes,omitempty"`
+	PeakMemoryBytes int64  `json:"peak_memory_bytes,omitempty"`
+	Splits          int    `json:"splits,omitempty"`
 }
 
 // QueryOptions configures query execution.
 type QueryOptions struct {
 	// Limit is the maximum number of rows to return. Default: 1000.
+	// For queries, the limit is also added to the SQL as LIMIT Limit+1 so
+	// Trino stops early; the extra row is how truncation is detected.
 	Limit int
 
+	// DisableLimitPushdown runs the SQL unchanged and applies Limit only
+	// while reading rows. The full query then runs on Trino.
+	DisableLimitPushdown bool
+
 	// Timeout is the query timeout. Uses client default if not set.
 	Timeout time.Duration
 
-	// Catalog overrides the default catalog for this query.
+	// Catalog overrides the default catalog for this query, so unqualified
+	// table names resolve against it.
 	Catalog string
 
 	// Schema overrides the default schema for this query.
 	Schema string
+
+	// SessionProperties sets Trino session properties for this query, e.g.
+	// {"query_max_execution_time": "10m"}. Catalog session properties are
+	// named "catalog.property".
+	SessionProperties map[string]string
+
+	// ClientTags are sent to Trino with the query, e.g. for resource group
+	// selection.
+	ClientTags []string
+
+	// Roles maps a catalog, or "system", to the role the query runs with.
+	// "ALL" and "NONE" are passed through as is.
+	Roles map[string]string
+
+	// Args are bound, in order, to the ? placeholders in the statement,
+	// which then runs as a Trino prepared statement. Values are serialized
+	// by the driver: use trino.Date, trino.Timestamp, and trino.Numeric for
+	// dates, timestamps, and decimals; float64 is not supported.
+	Args []any
 }
 
 // DefaultQueryOptions returns default query options.
@@ -134,113 +204,57 @@ func (p *queryProgressUpdater) QueryID() string {
 }
 
 // Query executes a SQL query and returns the results.
+// Rows are read through QueryStream and buffered up to opts.Limit
+// (default 1000); use QueryStream directly for large results.
 func (c *Client) Query(ctx context.Context, sqlQuery string, opts QueryOptions) (*QueryResult, error) {
-	start := time.Now()
-
-	// Apply timeout
-	timeout := c.config.Timeout
-	if opts.Timeout > 0 {
-		timeout = opts.Timeout
-	}
-	ctx, cancel := context.WithTimeout(ctx, timeout)
-	defer cancel()
-
 	// Apply limit if not already in query
-	limit := opts.Limit
-	if limit <= 0 {
-		limit = 1000
+	if opts.Limit <= 0 {
+		opts.Limit = 1000
 	}
 
-	// Set up progress updater to capture query ID
-	progressUpdater := &queryProgressUpdater{}
-
-	// Execute query with progress callback to capture query ID.
-	// The progress callback is a Trino-specific feature. If the driver doesn't
-	// support it (e.g., when using sqlmock for testing), fall back to a simple query.
-	rows, err := c.db.QueryContext(ctx, sqlQuery,
-		sql.Named("X-Trino-Progress-Callback", trino.ProgressUpdater(progressUpdater)),
-		sql.Named("X-Trino-Progress-Callback-Period", 100*time.Millisecond),
-	)
+	start := time.Now()
+	sqlQuery, optArgs, err := prepareQuery(sqlQuery, opts)
 	if err != nil {
-		// Check if the error is due to unsupported argument type (e.g., when using sqlmock).
-		// In that case, retry without the progress callback.
-		if strings.Contains(err.Error(), "unsupported type") {
-			rows, err = c.db.QueryContext(ctx, sqlQuery)
-			if err != nil {
-				return nil, fmt.Errorf("query failed: %w", err)
-			}
-			progressUpdater = nil // Clear so we don't try to get QueryID
-		} else {
-			return nil, fmt.Errorf("query failed: %w", err)
-		}
+		return nil, err
 	}
-	defer func() { _ = rows.Close() }()
 
-	// Get column info
-	columnTypes, err := rows.ColumnTypes()
+	// Rows are buffered rather than handed to the caller, so unlike
+	// QueryStream a read statement that fails part way through can be
+	// retried from the start
+	var result *QueryResult
+	attempts, err := c.withRetry(ctx, sqlQuery, func() error {
+		result, err = c.readQuery(ctx, sqlQuery, optArgs, opts)
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get column types: %w", err)
+		return nil, err
 	}
+	result.Stats.DurationMs = time.Since(start).Milliseconds()
+	result.Stats.Attempts = attempts
+	return result, nil
+}
 
-	columns := make([]ColumnInfo, len(columnTypes))
-	for i, ct := range columnTypes {
-		nullable, _ := ct.Nullable()
-		columns[i] = ColumnInfo{
-			Name:     ct.Name(),
-			Type:     ct.DatabaseTypeName(),
-			Nullable: nullable,
-		}
+// readQuery makes one attempt at running a statement prepared by
+// prepareQuery and buffers its rows.
+func (c *Client) readQuery(ctx context.Context, sqlQuery string, optArgs []any, opts QueryOptions) (*QueryResult, error) {
+	rows, err := c.startQuery(ctx, sqlQuery, optArgs, opts)
+	if err != nil {
+		return nil, err
 	}
+	defer func() { _ = rows.Close() }()
 
-	// Scan rows
 	result := &QueryResult{
-		Columns: columns,
+		Columns: rows.Columns(),
 		Rows:    make([]map[string]any, 0),
 	}
-
-	rowCount := 0
-	truncated := false
-
-	for rows.Next() {
-		if rowCount >= limit {
-			truncated = true
-			break
-		}
-
-		// Create scan destinations
-		values := make([]any, len(columns))
-		valuePtrs := make([]any, len(columns))
-		for i := range values {
-			valuePtrs[i] = &values[i]
-		}
-
-		if err := rows.Scan(valuePtrs...); err != nil {
-			return nil, fmt.Errorf("failed to scan row: %w", err)
-		}
-
-		// Convert to map
-		row := make(map[string]any)
-		for i, col := range columns {
-			row[col.Name] = convertValue(values[i])
-		}
+	for row := range rows.All() {
 		result.Rows = append(result.Rows, row)
-		rowCount++
 	}
-
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("row iteration error: %w", err)
+		return nil, err
 	}
-
-	stats := QueryStats{
-		RowCount:     rowCount,
-		DurationMs:   time.Since(start).Milliseconds(),
-		Truncated:    truncated,
-		LimitApplied: limit,
-	}
-	if progressUpdater != nil {
-		stats.QueryID = progressUpdater.QueryID()
-	}
-	result.Stats = stats
+	result.Stats = rows.Stats()
+	result.Warnings = rows.Warnings()
 
 	return result, nil
 }
@@ -270,19 +284,20 @@ func (c *Client) Explain(ctx context.Context, sqlQuery string, explainType Expla
 	// Trino EXPLAIN syntax: EXPLAIN (TYPE <type>) <statement>
 	explainSQL := fmt.Sprintf("EXPLAIN (TYPE %s) %s", explainType, sqlQuery) // #nosec G201 -- explainType is from enum, sqlQuery is validated
 
-	rows, err := c.db.QueryContext(ctx, explainSQL)
-	if err != nil {
-		return nil, fmt.Errorf("explain failed: %w", err)
-	}
-	defer func() { _ = rows.Close() }()
-
 	var planLines []string
-	for rows.Next() {
-		var line string
-		if err := rows.Scan(&line); err != nil {
-			return nil, fmt.Errorf("failed to scan explain output: %w", err)
+	err := c.readRows(ctx, explainSQL, "explain failed", func(rows *sql.Rows) error {
+		planLines = nil
+		for rows.Next() {
+			var line string
+			if err := rows.Scan(&line); err != nil {
+				return fmt.Errorf("failed to scan explain output: %w", err)
+			}
+			planLines = append(planLines, line)
 		}
-		planLines = append(planLines, line)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return &ExplainResult{
@@ -291,12 +306,20 @@ func (c *Client) Explain(ctx context.Context, sqlQuery string, explainType Expla
 	}, nil
 }
 
-// TableInfo holds metadata about a table.
+// Table types reported in TableInfo.Type.
+const (
+	TableTypeTable            = "TABLE"
+	TableTypeView             = "VIEW"
+	TableTypeMaterializedView = "MATERIALIZED VIEW"
+)
+
+// TableInfo holds metadata about a table, view, or materialized view.
 type TableInfo struct {
 	Catalog string      `json:"catalog"`
 	Schema  string      `json:"schema"`
 	Name    string      `json:"name"`
-	Type    string      `json:"type"` // TABLE, VIEW, etc.
+	Type    string      `json:"type"` // TableTypeTable, TableTypeView, or TableTypeMaterializedView
+	Comment string      `json:"comment,omitempty"`
 	Columns []ColumnDef `json:"columns,omitempty"`
 }
 
@@ -310,19 +333,20 @@ type ColumnDef struct {
 
 // ListCatalogs returns available catalogs.
 func (c *Client) ListCatalogs(ctx context.Context) ([]string, error) {
-	rows, err := c.db.QueryContext(ctx, "SHOW CATALOGS")
-	if err != nil {
-		return nil, fmt.Errorf("failed to list catalogs: %w", err)
-	}
-	defer func() { _ = rows.Close() }()
-
 	var catalogs []string
-	for rows.Next() {
-		var catalog string
-		if err := rows.Scan(&catalog); err != nil {
-			return nil, fmt.Errorf("failed to scan catalog: %w", err)
+	err := c.readRows(ctx, "SHOW CATALOGS", "failed to list catalogs", func(rows *sql.Rows) error {
+		catalogs = nil
+		for rows.Next() {
+			var catalog string
+			if err := rows.Scan(&catalog); err != nil {
+				return fmt.Errorf("failed to scan catalog: %w", err)
+			}
+			catalogs = append(catalogs, catalog)
 		}
-		catalogs = append(catalogs, catalog)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 	return catalogs, nil
 }
@@ -335,24 +359,26 @@ func (c *Client) ListSchemas(ctx context.Context, catalog string) ([]string, err
 
 	// #nosec G201 -- identifiers are safely quoted via QuoteIdentifier
 	query := fmt.Sprintf("SHOW SCHEMAS FROM %s", QuoteIdentifier(catalog))
-	rows, err := c.db.QueryContext(ctx, query)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list schemas: %w", err)
-	}
-	defer func() { _ = rows.Close() }()
-
 	var schemas []string
-	for rows.Next() {
-		var schema string
-		if err := rows.Scan(&schema); err != nil {
-			return nil, fmt.Errorf("failed to scan schema: %w", err)
+	err := c.readRows(ctx, query, "failed to list schemas", func(rows *sql.Rows) error {
+		schemas = nil
+		for rows.Next() {
+			var schema string
+			if err := rows.Scan(&schema); err != nil {
+				return fmt.Errorf("failed to scan schema: %w", err)
+			}
+			schemas = append(schemas, schema)
 		}
-		schemas = append(schemas, schema)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 	return schemas, nil
 }
 
-// ListTables returns tables in the given catalog and schema.
+// ListTables returns the tables, views, and materialized views in the given
+// catalog and schema, with their types and comments.
 func (c *Client) ListTables(ctx context.Context, catalog, schema string) ([]TableInfo, error) {
 	if catalog == "" {
 		catalog = c.config.Catalog
@@ -361,31 +387,76 @@ func (c *Client) ListTables(ctx context.Context, catalog, schema string) ([]Tabl
 		schema = c.config.Schema
 	}
 
-	// #nosec G201 -- identifiers are safely quoted via QuoteIdentifier
-	query := fmt.Sprintf("SHOW TABLES FROM %s.%s", QuoteIdentifier(catalog), QuoteIdentifier(schema))
-	rows, err := c.db.QueryContext(ctx, query)
+	var tables []TableInfo
+	err := c.readRows(ctx, tablesQuery(catalog, schema, ""), "failed to list tables", func(rows *sql.Rows) error {
+		tables = nil
+		for rows.Next() {
+			info, err := scanTableInfo(rows, catalog, schema)
+			if err != nil {
+				return err
+			}
+			tables = append(tables, info)
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list tables: %w", err)
+		return nil, err
 	}
-	defer func() { _ = rows.Close() }()
+	return tables, nil
+}
 
-	var tables []TableInfo
-	for rows.Next() {
-		var name string
-		if err := rows.Scan(&name); err != nil {
-			return nil, fmt.Errorf("failed to scan table: %w", err)
+// tablesQuery returns a query listing the objects of a schema as rows of
+// name, information_schema table type, materialized view flag, and
+// comment. information_schema.tables does not tell materialized views
+// apart from tables, so they are identified via
+// system.metadata.materialized_views. A non-empty table restricts the
+// query to that object.
+func tablesQuery(catalog, schema, table string) string {
+	cat, sch := quoteLiteral(catalog), quoteLiteral(schema)
+	tableFilter := func(column string) string {
+		if table == "" {
+			return ""
 		}
-		tables = append(tables, TableInfo{
-			Catalog: catalog,
-			Schema:  schema,
-			Name:    name,
-			Type:    "TABLE",
-		})
+		return fmt.Sprintf(" AND %s = %s", column, quoteLiteral(table))
+	}
+
+	// #nosec G201 -- identifiers and literals are safely quoted
+	return fmt.Sprintf(`SELECT t.table_name, t.table_type, mv.name IS NOT NULL, tc.comment
+FROM %s.information_schema.tables t
+LEFT JOIN system.metadata.materialized_views mv
+  ON mv.catalog_name = %s AND mv.schema_name = %s%s AND mv.name = t.table_name
+LEFT JOIN system.metadata.table_comments tc
+  ON tc.catalog_name = %s AND tc.schema_name = %s%s AND tc.table_name = t.table_name
+WHERE t.table_schema = %s%s
+ORDER BY t.table_name`,
+		QuoteIdentifier(catalog),
+		cat, sch, tableFilter("mv.name"),
+		cat, sch, tableFilter("tc.table_name"),
+		sch, tableFilter("t.table_name"))
+}
+
+// scanTableInfo scans a row of tablesQuery.
+func scanTableInfo(rows *sql.Rows, catalog, schema string) (TableInfo, error) {
+	var name, tableType string
+	var materialized bool
+	var comment sql.NullString
+	if err := rows.Scan(&name, &tableType, &materialized, &comment); err != nil {
+		return TableInfo{}, fmt.Errorf("failed to scan table: %w", err)
 	}
-	return tables, nil
+
+	info := TableInfo{Catalog: catalog, Schema: schema, Name: name, Type: TableTypeTable, Comment: comment.String}
+	switch {
+	case materialized:
+		info.Type = TableTypeMaterializedView
+	case tableType == "VIEW":
+		info.Type = TableTypeView
+	}
+	return info, nil
 }
 
-// DescribeTable returns detailed information about a table.
+// DescribeTable returns detailed information about a table, view, or
+// materialized view. Objects missing from information_schema.tables, such
+// as hidden metadata tables, are described as tables.
 func (c *Client) DescribeTable(ctx context.Context, catalog, schema, table string) (*TableInfo, error) {
 	if catalog == "" {
 		catalog = c.config.Catalog
@@ -394,41 +465,84 @@ func (c *Client) DescribeTable(ctx context.Context, catalog, schema, table strin
 		schema = c.config.Schema
 	}
 
+	info := &TableInfo{
+		Catalog: catalog,
+		Schema:  schema,
+		Name:    table,
+		Type:    TableTypeTable,
+	}
+	err := c.readRows(ctx, tablesQuery(catalog, schema, table), "failed to describe table", func(rows *sql.Rows) error {
+		for rows.Next() {
+			found, err := scanTableInfo(rows, catalog, schema)
+			if err != nil {
+				return err
+			}
+			info.Type, info.Comment = found.Type, found.Comment
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	// #nosec G201 -- identifiers are safely quoted via QuoteIdentifier
 	query := fmt.Sprintf(
 		"DESCRIBE %s.%s.%s",
 		QuoteIdentifier(catalog), QuoteIdentifier(schema), QuoteIdentifier(table),
 	)
-	rows, err := c.db.QueryContext(ctx, query)
+	err = c.readRows(ctx, query, "failed to describe table", func(rows *sql.Rows) error {
+		info.Columns = make([]ColumnDef, 0)
+		for rows.Next() {
+			var col ColumnDef
+			var extra, comment sql.NullString
+			if err := rows.Scan(&col.Name, &col.Type, &extra, &comment); err != nil {
+				return fmt.Errorf("failed to scan column: %w", err)
+			}
+			if extra.Valid {
+				col.Nullable = extra.String
+			}
+			if comment.Valid {
+				col.Comment = comment.String
+			}
+			info.Columns = append(info.Columns, col)
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to describe table: %w", err)
+		return nil, err
 	}
-	defer func() { _ = rows.Close() }()
 
-	info := &TableInfo{
-		Catalog: catalog,
-		Schema:  schema,
-		Name:    table,
-		Type:    "TABLE",
-		Columns: make([]ColumnDef, 0),
-	}
+	return info, nil
+}
 
-	for rows.Next() {
-		var col ColumnDef
-		var extra, comment sql.NullString
-		if err := rows.Scan(&col.Name, &col.Type, &extra, &comment); err != nil {
-			return nil, fmt.Errorf("failed to scan column: %w", err)
-		}
-		if extra.Valid {
-			col.Nullable = extra.String
+// readRows runs a read statement generated by the client, such as SHOW
+// CATALOGS, and passes its rows to read, retrying transient failures as
+// configured by Config.Retry. read is called once per attempt and must
+// reset anything it collects. Errors running the statement are prefixed
+// with failMsg; errors from read are returned as is.
+func (c *Client) readRows(ctx context.Context, query, failMsg string, read func(*sql.Rows) error) error {
+	var readErr error
+	_, err := c.withRetry(ctx, query, func() error {
+		readErr = nil
+		rows, err := c.db.QueryContext(ctx, query, sessionArgs(ctx)...)
+		if err != nil {
+			return err
 		}
-		if comment.Valid {
-			col.Comment = comment.String
+		defer func() { _ = rows.Close() }()
+
+		if readErr = read(rows); readErr != nil {
+			return readErr
 		}
-		info.Columns = append(info.Columns, col)
+		return rows.Err()
+	})
+	switch {
+	case err == nil:
+		return nil
+	case readErr != nil:
+		return readErr
+	default:
+		return fmt.Errorf("%s: %w", failMsg, err)
 	}
-
-	return info, nil
 }
 
 // QuoteIdentifier wraps a SQL identifier in double quotes for safe use in queries.
@@ -440,6 +554,11 @@ func QuoteIdentifier(name string) string {
 	return `"` + escaped + `"`
 }
 
+// quoteLiteral quotes a string as a SQL string literal.
+func quoteLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
 // convertValue converts database values to JSON-friendly types.
 func convertValue(v any) any {
 	if v == nil {
@@ -290,6 +290,9 @@ func TestIntegration_CreateAndQueryTable(t *testing.T) {
 	for _, tbl := range tables {
 		if tbl.Name == "test_integration_table" {
 			found = true
+			if tbl.Type != TableTypeTable {
+				t.Errorf("Expected type %s, got %s", TableTypeTable, tbl.Type)
+			}
 			break
 		}
 	}
@@ -365,6 +365,9 @@ func TestClient_ListSchemas(t *testing.T) {
 	})
 }
 
+// tableColumns are the columns of the table listing query.
+var tableColumns = []string{"table_name", "table_type", "_col2", "comment"}
+
 func TestClient_ListTables(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -382,12 +385,12 @@ func TestClient_ListTables(t *testing.T) {
 	client := NewWithDB(db, cfg)
 
 	t.Run("successful list", func(t *testing.T) {
-		rows := sqlmock.NewRows([]string{"Table"}).
-			AddRow("users").
-			AddRow("orders").
-			AddRow("products")
+		rows := sqlmock.NewRows(tableColumns).
+			AddRow("users", "BASE TABLE", false, "Registered users").
+			AddRow("daily_orders", "BASE TABLE", true, nil).
+			AddRow("active_users", "VIEW", false, nil)
 
-		mock.ExpectQuery(`SHOW TABLES FROM "hive"\."sales"`).WillReturnRows(rows)
+		mock.ExpectQuery(`FROM "hive"\.information_schema\.tables t.*WHERE t\.table_schema = 'sales'`).WillReturnRows(rows)
 
 		tables, err := client.ListTables(context.Background(), "hive", "sales")
 		if err != nil {
@@ -395,7 +398,7 @@ func TestClient_ListTables(t *testing.T) {
 		}
 
 		if len(tables) != 3 {
-			t.Errorf("expected 3 tables, got %d", len(tables))
+			t.Fatalf("expected 3 tables, got %d", len(tables))
 		}
 		if tables[0].Name != "users" {
 			t.Errorf("expected first table 'users', got %q", tables[0].Name)
@@ -406,11 +409,29 @@ func TestClient_ListTables(t *testing.T) {
 		if tables[0].Schema != "sales" {
 			t.Errorf("expected schema 'sales', got %q", tables[0].Schema)
 		}
+		if tables[0].Comment != "Registered users" {
+			t.Errorf("expected comment 'Registered users', got %q", tables[0].Comment)
+		}
+		wantTypes := []string{TableTypeTable, TableTypeMaterializedView, TableTypeView}
+		for i, want := range wantTypes {
+			if tables[i].Type != want {
+				t.Errorf("table %q type = %q, want %q", tables[i].Name, tables[i].Type, want)
+			}
+		}
+	})
+
+	t.Run("quotes literals", func(t *testing.T) {
+		mock.ExpectQuery(`WHERE t\.table_schema = 'o''brien'`).WillReturnRows(sqlmock.NewRows(tableColumns))
+
+		if _, err := client.ListTables(context.Background(), "hive", "o'brien"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
 	})
 
 	t.Run("uses defaults when empty", func(t *testing.T) {
-		rows := sqlmock.NewRows([]string{"Table"}).AddRow("test")
-		mock.ExpectQuery(`SHOW TABLES FROM "default_catalog"\."default_schema"`).WillReturnRows(rows)
+		rows := sqlmock.NewRows(tableColumns).AddRow("test", "BASE TABLE", false, nil)
+		mock.ExpectQuery(`FROM "default_catalog"\.information_schema\.tables t.*WHERE t\.table_schema = 'default_schema'`).
+			WillReturnRows(rows)
 
 		tables, err := client.ListTables(context.Background(), "", "")
 		if err != nil {
@@ -423,7 +444,7 @@ func TestClient_ListTables(t *testing.T) {
 	})
 
 	t.Run("error", func(t *testing.T) {
-		mock.ExpectQuery("SHOW TABLES").WillReturnError(sqlmock.ErrCancelled)
+		mock.ExpectQuery("information_schema.tables").WillReturnError(sqlmock.ErrCancelled)
 
 		_, err := client.ListTables(context.Background(), "hive", "sales")
 		if err == nil {
@@ -454,6 +475,8 @@ func TestClient_DescribeTable(t *testing.T) {
 			AddRow("name", "varchar", "", "User name").
 			AddRow("email", "varchar", "NULL", nil)
 
+		mock.ExpectQuery(`information_schema\.tables.*AND t\.table_name = 'users'`).
+			WillReturnRows(sqlmock.NewRows(tableColumns).AddRow("users", "VIEW", false, "Users with activity"))
 		mock.ExpectQuery(`DESCRIBE "hive"\."sales"\."users"`).WillReturnRows(rows)
 
 		info, err := client.DescribeTable(context.Background(), "hive", "sales", "users")
@@ -461,6 +484,13 @@ func TestClient_DescribeTable(t *testing.T) {
 			t.Fatalf("unexpected error: %v", err)
 		}
 
+		if info.Type != TableTypeView {
+			t.Errorf("expected type VIEW, got %q", info.Type)
+		}
+		if info.Comment != "Users with activity" {
+			t.Errorf("expected comment 'Users with activity', got %q", info.Comment)
+		}
+
 		if info.Catalog != "hive" {
 			t.Errorf("expected catalog 'hive', got %q", info.Catalog)
 		}
@@ -485,6 +515,7 @@ func TestClient_DescribeTable(t *testing.T) {
 		rows := sqlmock.NewRows([]string{"Column", "Type", "Extra", "Comment"}).
 			AddRow("id", "int", "", nil)
 
+		mock.ExpectQuery(`FROM "default_catalog"\.information_schema\.tables`).WillReturnRows(sqlmock.NewRows(tableColumns))
 		mock.ExpectQuery(`DESCRIBE "default_catalog"\."default_schema"\."test"`).WillReturnRows(rows)
 
 		info, err := client.DescribeTable(context.Background(), "", "", "test")
@@ -495,9 +526,13 @@ func TestClient_DescribeTable(t *testing.T) {
 		if info.Catalog != "default_catalog" {
 			t.Errorf("expected default catalog, got %q", info.Catalog)
 		}
+		if info.Type != TableTypeTable {
+			t.Errorf("expected objects missing from information_schema to be tables, got %q", info.Type)
+		}
 	})
 
 	t.Run("error", func(t *testing.T) {
+		mock.ExpectQuery("information_schema.tables").WillReturnRows(sqlmock.NewRows(tableColumns))
 		mock.ExpectQuery("DESCRIBE").WillReturnError(sqlmock.ErrCancelled)
 
 		_, err := client.DescribeTable(context.Background(), "hive", "sales", "nonexistent")
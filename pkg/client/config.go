@@ -3,6 +3,7 @@ package client
 
 import (
 	"fmt"
+	"net"
 	"os"
 	"strconv"
 	"time"
@@ -16,12 +17,17 @@ type Config struct {
 	// Port is the Trino server port. Default: 443 for SSL, 8080 otherwise.
 	Port int
 
-	// User is the Trino username for authentication.
+	// User is the Trino username. It is required unless Auth identifies the
+	// user, e.g. with a JWT or Kerberos principal.
 	User string
 
 	// Password is the Trino password (optional, for password auth).
 	Password string
 
+	// Auth selects how to authenticate. The zero value uses basic auth when
+	// Password is set and sends no credentials otherwise.
+	Auth AuthConfig
+
 	// Catalog is the default catalog to use.
 	Catalog string
 
@@ -34,9 +40,21 @@ type Config struct {
 	// SSLVerify enables SSL certificate verification. Default: true.
 	SSLVerify bool
 
+	// TLS customizes HTTPS connections: trusted CAs, a client certificate,
+	// the verified server name, and the minimum TLS version.
+	TLS TLSConfig
+
 	// Timeout is the default query timeout. Default: 120s.
 	Timeout time.Duration
 
+	// Pool sizes the connection pool, which bounds concurrent queries.
+	// Zero fields use the defaults (see PoolConfig).
+	Pool PoolConfig
+
+	// Retry controls retries of read statements after transient failures.
+	// Default: DefaultRetryConfig. Each attempt gets the full Timeout.
+	Retry RetryConfig
+
 	// Source identifies this client to Trino. Default: "mcp-trino".
 	Source string
 }
@@ -49,6 +67,7 @@ func DefaultConfig() Config {
 		SSL:       false,
 		SSLVerify: true,
 		Timeout:   120 * time.Second,
+		Retry:     DefaultRetryConfig(),
 		Source:    "mcp-trino",
 		Catalog:   "memory",
 		Schema:    "default",
@@ -67,11 +86,18 @@ func DefaultConfig() Config {
 //   - TRINO_SSL_VERIFY: Verify SSL certificates (true/false)
 //   - TRINO_TIMEOUT: Query timeout in seconds
 //   - TRINO_SOURCE: Client source identifier
+//
+// TLS, auth, pool, and retry settings are read as described in ApplyTLSEnv,
+// ApplyAuthEnv, ApplyPoolEnv, and ApplyRetryEnv.
 func FromEnv() Config {
 	cfg := DefaultConfig()
 	cfg = applyHostEnv(cfg)
 	cfg = applyConnectionEnv(cfg)
 	cfg = applyOptionsEnv(cfg)
+	cfg.TLS = ApplyTLSEnv(cfg.TLS)
+	cfg.Auth = ApplyAuthEnv(cfg.Auth)
+	cfg.Pool = ApplyPoolEnv(cfg.Pool)
+	cfg.Retry = ApplyRetryEnv(cfg.Retry)
 	return cfg
 }
 
@@ -158,16 +184,35 @@ func (c Config) DSN() string {
 	return dsn
 }
 
+// baseURL returns the coordinator's base URL, e.g. https://trino.example.com:443.
+func (c Config) baseURL() string {
+	scheme := "http"
+	if c.SSL {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s", scheme, net.JoinHostPort(c.Host, strconv.Itoa(c.Port)))
+}
+
 // Validate checks if the configuration is valid.
 func (c Config) Validate() error {
 	if c.Host == "" {
 		return fmt.Errorf("host is required")
 	}
-	if c.User == "" {
+	method := c.Auth.ResolvedMethod(c.Password)
+	if c.User == "" && (method == AuthNone || method == AuthBasic) {
 		return fmt.Errorf("user is required")
 	}
 	if c.Port <= 0 || c.Port > 65535 {
 		return fmt.Errorf("port must be between 1 and 65535")
 	}
+	if err := c.TLS.validate(); err != nil {
+		return fmt.Errorf("invalid tls: %w", err)
+	}
+	if err := c.Auth.validate(c.Password); err != nil {
+		return fmt.Errorf("invalid auth: %w", err)
+	}
+	if err := c.Retry.validate(); err != nil {
+		return fmt.Errorf("invalid retry: %w", err)
+	}
 	return nil
 }
@@ -0,0 +1,166 @@
+package client
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/txn2/mcp-trino/pkg/sqlparse"
+)
+
+// TableDDL holds the table properties of a CREATE TABLE or CREATE
+// MATERIALIZED VIEW statement as printed by SHOW CREATE, with the layout
+// properties of the Hive, Iceberg, and Delta Lake connectors picked out.
+type TableDDL struct {
+	// Properties maps each property of the WITH clause to its value as
+	// written, e.g. format to 'PARQUET'.
+	Properties map[string]string `json:"properties,omitempty"`
+
+	// Format is the file format, e.g. PARQUET.
+	Format string `json:"format,omitempty"`
+
+	// Location is the table's storage location.
+	Location string `json:"location,omitempty"`
+
+	// Partitioning lists the partition columns or, for Iceberg, the
+	// partition transforms as declared, e.g. day(created_at).
+	Partitioning []string `json:"partitioning,omitempty"`
+
+	// PartitionColumns lists the columns Partitioning refers to. Filtering
+	// on them lets Trino skip partitions.
+	PartitionColumns []string `json:"partition_columns,omitempty"`
+
+	// BucketedBy and BucketCount describe Hive bucketing.
+	BucketedBy  []string `json:"bucketed_by,omitempty"`
+	BucketCount int      `json:"bucket_count,omitempty"`
+
+	// SortedBy lists the sort order within files or buckets, e.g. id DESC.
+	SortedBy []string `json:"sorted_by,omitempty"`
+}
+
+// ParseTableDDL extracts the table properties from the WITH clause of a
+// statement printed by SHOW CREATE TABLE or SHOW CREATE MATERIALIZED VIEW.
+// A statement without properties, such as a view definition, gives an
+// empty TableDDL.
+func ParseTableDDL(ddl string) TableDDL {
+	var out TableDDL
+	props := tableProperties(ddl)
+	if len(props) == 0 {
+		return out
+	}
+	out.Properties = props
+
+	out.Format, _ = unquoteLiteral(props["format"])
+	if loc, ok := unquoteLiteral(props["location"]); ok {
+		out.Location = loc
+	} else {
+		out.Location, _ = unquoteLiteral(props["external_location"])
+	}
+
+	// Hive and Delta Lake partition by columns, Iceberg by transforms
+	out.Partitioning = stringLiterals(props["partitioned_by"])
+	if len(out.Partitioning) == 0 {
+		out.Partitioning = stringLiterals(props["partitioning"])
+	}
+	for _, p := range out.Partitioning {
+		if col := partitionColumn(p); col != "" && !slices.Contains(out.PartitionColumns, col) {
+			out.PartitionColumns = append(out.PartitionColumns, col)
+		}
+	}
+
+	out.BucketedBy = stringLiterals(props["bucketed_by"])
+	out.BucketCount, _ = strconv.Atoi(props["bucket_count"])
+	out.SortedBy = stringLiterals(props["sorted_by"])
+	return out
+}
+
+// tableProperties returns the properties of the first top-level WITH ( ... )
+// clause of ddl. A common table expression's WITH is followed by a name,
+// not a parenthesis, so the query of a materialized view is not mistaken
+// for properties.
+func tableProperties(ddl string) map[string]string {
+	tokens, _ := sqlparse.Scan(ddl) // SHOW CREATE output is well formed
+	start := -1
+	for i := 0; i+1 < len(tokens); i++ {
+		if tokens[i].Depth == 0 && tokens[i].Is("WITH") && tokens[i+1].Text == "(" {
+			start = i + 2
+			break
+		}
+	}
+	if start < 0 {
+		return nil
+	}
+
+	props := make(map[string]string)
+	for i := start; i < len(tokens) && tokens[i].Depth > 0; {
+		// name = value, up to a comma or the closing parenthesis
+		if i+2 >= len(tokens) || tokens[i+1].Text != "=" {
+			break
+		}
+		name := sqlparse.Identifier(tokens[i].Text)
+		j, brackets := i+2, 0
+		for ; j < len(tokens) && tokens[j].Depth > 0; j++ {
+			t := tokens[j]
+			if t.Depth == 1 && brackets == 0 && t.Text == "," {
+				break
+			}
+			switch t.Text {
+			case "[":
+				brackets++
+			case "]":
+				brackets--
+			}
+		}
+		if j > i+2 {
+			props[name] = ddl[tokens[i+2].Pos:tokens[j-1].End()]
+		}
+		i = j + 1
+	}
+	return props
+}
+
+// unquoteLiteral returns the contents of a SQL string literal such as
+// 'it”s', and false if s is not one.
+func unquoteLiteral(s string) (string, bool) {
+	if len(s) < 2 || s[0] != '\'' || sqlparse.QuotedLen(s) != len(s) {
+		return "", false
+	}
+	return strings.ReplaceAll(s[1:len(s)-1], "''", "'"), true
+}
+
+// stringLiterals returns the string literals of a value such as
+// ARRAY['ds', 'region'].
+func stringLiterals(value string) []string {
+	tokens, _ := sqlparse.Scan(value)
+	var out []string
+	for _, t := range tokens {
+		if t.Kind != sqlparse.String {
+			continue
+		}
+		if s, ok := unquoteLiteral(t.Text); ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// partitionColumn returns the column of a partitioning entry: the entry
+// itself for a column, or the first argument of an Iceberg transform such
+// as bucket(id, 16).
+func partitionColumn(entry string) string {
+	tokens, _ := sqlparse.Scan(entry)
+	if len(tokens) == 0 {
+		return ""
+	}
+	col := tokens[0]
+	if len(tokens) > 2 && tokens[1].Text == "(" {
+		col = tokens[2]
+	}
+	if col.Kind != sqlparse.Word && col.Kind != sqlparse.Quoted {
+		return ""
+	}
+	if col.Kind == sqlparse.Quoted {
+		return sqlparse.Identifier(col.Text)
+	}
+	return col.Text
+}
@@ -0,0 +1,116 @@
+package client
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTableDDL(t *testing.T) {
+	tests := []struct {
+		name string
+		ddl  string
+		want TableDDL
+	}{
+		{
+			name: "hive",
+			ddl: `CREATE TABLE hive.sales.orders (
+   id bigint,
+   total decimal(10,2),
+   ds varchar,
+   region varchar
+)
+COMMENT 'All orders'
+WITH (
+   bucket_count = 32,
+   bucketed_by = ARRAY['id'],
+   external_location = 's3://warehouse/orders',
+   format = 'ORC',
+   partitioned_by = ARRAY['ds','region'],
+   sorted_by = ARRAY['id DESC']
+)`,
+			want: TableDDL{
+				Properties: map[string]string{
+					"bucket_count":      "32",
+					"bucketed_by":       "ARRAY['id']",
+					"external_location": "'s3://warehouse/orders'",
+					"format":            "'ORC'",
+					"partitioned_by":    "ARRAY['ds','region']",
+					"sorted_by":         "ARRAY['id DESC']",
+				},
+				Format:           "ORC",
+				Location:         "s3://warehouse/orders",
+				Partitioning:     []string{"ds", "region"},
+				PartitionColumns: []string{"ds", "region"},
+				BucketedBy:       []string{"id"},
+				BucketCount:      32,
+				SortedBy:         []string{"id DESC"},
+			},
+		},
+		{
+			name: "iceberg",
+			ddl: `CREATE TABLE iceberg.events.clicks (
+   id bigint,
+   "Event Time" timestamp(6) with time zone,
+   user_id bigint
+)
+WITH (
+   format = 'PARQUET',
+   format_version = 2,
+   location = 's3://lake/clicks-8f2c',
+   partitioning = ARRAY['day("Event Time")','bucket(user_id, 16)','user_id']
+)`,
+			want: TableDDL{
+				Properties: map[string]string{
+					"format":         "'PARQUET'",
+					"format_version": "2",
+					"location":       "'s3://lake/clicks-8f2c'",
+					"partitioning":   `ARRAY['day("Event Time")','bucket(user_id, 16)','user_id']`,
+				},
+				Format:           "PARQUET",
+				Location:         "s3://lake/clicks-8f2c",
+				Partitioning:     []string{`day("Event Time")`, "bucket(user_id, 16)", "user_id"},
+				PartitionColumns: []string{"Event Time", "user_id"},
+			},
+		},
+		{
+			name: "materialized view",
+			ddl: `CREATE MATERIALIZED VIEW iceberg.events.daily AS
+WITH totals AS (SELECT 1 AS n)
+SELECT * FROM totals`,
+			want: TableDDL{},
+		},
+		{
+			name: "materialized view with properties",
+			ddl: `CREATE MATERIALIZED VIEW iceberg.events.daily
+WITH (
+   format = 'ORC',
+   partitioning = ARRAY['ds']
+) AS
+WITH totals AS (SELECT 1 AS n)
+SELECT * FROM totals`,
+			want: TableDDL{
+				Properties:       map[string]string{"format": "'ORC'", "partitioning": "ARRAY['ds']"},
+				Format:           "ORC",
+				Partitioning:     []string{"ds"},
+				PartitionColumns: []string{"ds"},
+			},
+		},
+		{
+			name: "view",
+			ddl:  `CREATE VIEW hive.sales.summary SECURITY DEFINER AS SELECT region, sum(total) FROM orders GROUP BY region`,
+			want: TableDDL{},
+		},
+		{
+			name: "column properties are not table properties",
+			ddl:  `CREATE TABLE memory.default.t (id bigint WITH (comment_ = 'x'))`,
+			want: TableDDL{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseTableDDL(tt.ddl); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseTableDDL() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
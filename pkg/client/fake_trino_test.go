@@ -0,0 +1,217 @@
+package client
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeTrino is a minimal Trino coordinator that answers every statement with
+// a one-row result and records the requests it receives.
+type fakeTrino struct {
+	*httptest.Server
+
+	// hold keeps every query RUNNING after its first row until it is
+	// cancelled.
+	hold bool
+
+	// failures is the number of statements, from the first, that fail with
+	// failureName instead of running.
+	failures    int
+	failureName string
+
+	mu        sync.Mutex
+	requests  []fakeRequest
+	cancels   []fakeRequest
+	cancelled bool
+}
+
+// fakeQueryID is the query ID fakeTrino assigns to every statement.
+const fakeQueryID = "20250101_000000_00001_fake"
+
+// fakeColumns is the single varchar column of every fakeTrino result.
+var fakeColumns = []map[string]any{{
+	"name":          "value",
+	"type":          "varchar",
+	"typeSignature": map[string]any{"rawType": "varchar", "arguments": []any{}},
+}}
+
+// fakeTableColumns are the columns of a fakeTrino result for a table
+// listing query, which reads information_schema.tables.
+var fakeTableColumns = []map[string]any{
+	{"name": "table_name", "type": "varchar", "typeSignature": map[string]any{"rawType": "varchar", "arguments": []any{}}},
+	{"name": "table_type", "type": "varchar", "typeSignature": map[string]any{"rawType": "varchar", "arguments": []any{}}},
+	{"name": "_col2", "type": "boolean", "typeSignature": map[string]any{"rawType": "boolean", "arguments": []any{}}},
+	{"name": "comment", "type": "varchar", "typeSignature": map[string]any{"rawType": "varchar", "arguments": []any{}}},
+}
+
+// fakeFinishedStats are the stats of every finished fakeTrino query.
+var fakeFinishedStats = map[string]any{
+	"state":            "FINISHED",
+	"totalSplits":      12,
+	"cpuTimeMillis":    340,
+	"wallTimeMillis":   510,
+	"queuedTimeMillis": 7,
+	"processedRows":    1500,
+	"processedBytes":   2048,
+	"peakMemoryBytes":  4096,
+}
+
+// fakeWarnings are the warnings of every fakeTrino query. The first is
+// already reported while the query is queued.
+var fakeWarnings = []map[string]any{
+	{"warningCode": map[string]any{"code": 1, "name": "DEPRECATED_FUNCTION"}, "message": "function foo is deprecated"},
+	{"warningCode": map[string]any{"code": 2, "name": "TOO_MANY_STAGES"}, "message": "query has 150 stages"},
+}
+
+// fakeRequest is a statement request received by fakeTrino.
+type fakeRequest struct {
+	Header http.Header
+	Query  string
+}
+
+func newFakeTrino(t *testing.T) *fakeTrino {
+	t.Helper()
+	f := &fakeTrino{}
+	f.Server = httptest.NewServer(http.HandlerFunc(f.serve))
+	t.Cleanup(f.Close)
+	return f
+}
+
+func (f *fakeTrino) serve(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/v1/statement":
+		body, _ := io.ReadAll(r.Body)
+		f.mu.Lock()
+		f.requests = append(f.requests, fakeRequest{Header: r.Header.Clone(), Query: string(body)})
+		fail := len(f.requests) <= f.failures
+		f.mu.Unlock()
+
+		if fail {
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"id":    fakeQueryID,
+				"stats": map[string]any{"state": "FAILED"},
+				"error": map[string]any{
+					"message":   "statement failed with " + f.failureName,
+					"errorName": f.failureName,
+					"errorType": "INTERNAL_ERROR",
+				},
+			})
+			return
+		}
+
+		// Like a real coordinator, queue the query and serve results from nextUri
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"id":       fakeQueryID,
+			"nextUri":  f.URL + "/v1/statement/executing/" + fakeQueryID + "/1",
+			"stats":    map[string]any{"state": "QUEUED"},
+			"warnings": fakeWarnings[:1],
+		})
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/v1/statement/executing/") && f.running():
+		time.Sleep(20 * time.Millisecond)
+		columns, row := f.result()
+		resp := map[string]any{
+			"id":      fakeQueryID,
+			"nextUri": f.URL + "/v1/statement/executing/" + fakeQueryID + "/2",
+			"columns": columns,
+			"stats":   map[string]any{"state": "RUNNING"},
+		}
+		if strings.HasSuffix(r.URL.Path, "/1") {
+			resp["data"] = [][]any{row}
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/v1/statement/executing/"):
+		columns, row := f.result()
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"id":       fakeQueryID,
+			"columns":  columns,
+			"data":     [][]any{row},
+			"stats":    fakeFinishedStats,
+			"warnings": fakeWarnings,
+		})
+	case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/v1/query/"):
+		f.mu.Lock()
+		f.cancels = append(f.cancels, fakeRequest{Header: r.Header.Clone(), Query: strings.TrimPrefix(r.URL.Path, "/v1/query/")})
+		f.cancelled = true
+		f.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// result returns the columns and the single row of the latest statement's
+// result: a table named "ok" for table listings, and "ok" otherwise.
+func (f *fakeTrino) result() ([]map[string]any, []any) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if n := len(f.requests); n > 0 && strings.Contains(f.requests[n-1].Query, "information_schema.tables") {
+		return fakeTableColumns, []any{"ok", "BASE TABLE", false, nil}
+	}
+	return fakeColumns, []any{"ok"}
+}
+
+// running reports whether a held query is still running.
+func (f *fakeTrino) running() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.hold && !f.cancelled
+}
+
+// Cancels returns the query cancellations received so far; Query holds the
+// cancelled query ID.
+func (f *fakeTrino) Cancels() []fakeRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]fakeRequest(nil), f.cancels...)
+}
+
+// Requests returns the statement requests received so far.
+func (f *fakeTrino) Requests() []fakeRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]fakeRequest(nil), f.requests...)
+}
+
+// testServerConfig returns a Config pointing at srv as user "service".
+func testServerConfig(t *testing.T, srv *httptest.Server) Config {
+	t.Helper()
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	port, err := strconv.Atoi(u.Port())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return Config{
+		Host:    u.Hostname(),
+		Port:    port,
+		User:    "service",
+		Catalog: "memory",
+		Schema:  "default",
+		Timeout: 10 * time.Second,
+		Source:  "mcp-trino-test",
+	}
+}
+
+// newFakeTrinoClient returns a Client connected to f as user "service".
+func newFakeTrinoClient(t *testing.T, f *fakeTrino) *Client {
+	t.Helper()
+	c, err := New(testServerConfig(t, f.Server))
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	t.Cleanup(func() { _ = c.Close() })
+	return c
+}
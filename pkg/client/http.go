@@ -0,0 +1,47 @@
+package client
+
+import "net/http"
+
+// newHTTPClient returns the HTTP client for coordinator requests, both the
+// driver's and those made directly such as CancelQuery. It applies the
+// connection's TLS settings and authenticates every request with the
+// configured AuthConfig.
+func newHTTPClient(cfg Config) (*http.Client, error) {
+	var transport *http.Transport
+	if t, ok := http.DefaultTransport.(*http.Transport); ok {
+		transport = t.Clone()
+	} else {
+		transport = &http.Transport{}
+	}
+
+	tlsConfig, err := newTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	// The OAuth2 token endpoint is another host, so it shares the trusted
+	// CAs but not the coordinator's server name override
+	tokenTransport := transport.Clone()
+	tokenTransport.TLSClientConfig.ServerName = ""
+
+	auth, err := newAuthenticator(cfg, tokenTransport)
+	if err != nil {
+		return nil, err
+	}
+	if auth == nil {
+		return &http.Client{Transport: transport}, nil
+	}
+	return &http.Client{Transport: &authTransport{base: transport, auth: auth}}, nil
+}
+
+// errTransport fails every request with err. NewWithDB uses it when the
+// configured TLS or auth cannot be set up, since it has no error return.
+type errTransport struct {
+	err error
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t errTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, t.err
+}
@@ -0,0 +1,76 @@
+package client
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/txn2/mcp-trino/pkg/sqlparse"
+)
+
+// pushDownLimit rewrites a read query so Trino returns at most limit rows,
+// letting the cluster stop early instead of running the query to
+// completion for rows the client discards. It appends a LIMIT clause, or
+// lowers an existing top-level LIMIT that is larger, rather than wrapping
+// the query, because Trino ignores ORDER BY in a subquery without LIMIT.
+//
+// Statements that are not queries, contain several statements, end in
+// FETCH FIRST, or have a LIMIT it cannot read are returned unchanged with
+// ok false; the limit is then only applied while reading rows.
+func pushDownLimit(sqlQuery string, limit int) (string, bool) {
+	tokens, err := sqlparse.Scan(sqlQuery)
+	if err != nil {
+		return sqlQuery, false
+	}
+	for len(tokens) > 0 && tokens[len(tokens)-1].Text == ";" {
+		tokens = tokens[:len(tokens)-1]
+	}
+	if len(tokens) == 0 || !isQueryStart(tokens[0]) {
+		return sqlQuery, false
+	}
+
+	for i, t := range tokens {
+		switch {
+		case t.Kind == sqlparse.Symbol && t.Text == ";":
+			return sqlQuery, false
+		case t.Depth > 0:
+			continue
+		case t.Is("FETCH"):
+			return sqlQuery, false
+		case t.Is("LIMIT"):
+			return lowerLimit(sqlQuery, tokens[i+1:], limit)
+		}
+	}
+
+	// Start a new line in case the statement ends in a line comment
+	last := tokens[len(tokens)-1]
+	return sqlQuery[:last.End()] + "\nLIMIT " + strconv.Itoa(limit), true
+}
+
+// isQueryStart reports whether a statement starting with t is a query.
+func isQueryStart(t sqlparse.Token) bool {
+	return t.Is("SELECT") || t.Is("WITH") || t.Is("VALUES") || t.Is("TABLE") ||
+		(t.Kind == sqlparse.Symbol && t.Text == "(")
+}
+
+// lowerLimit handles a query with a top-level LIMIT, given the tokens after
+// it. The clause must end the query; its count is lowered to limit if larger.
+func lowerLimit(sqlQuery string, after []sqlparse.Token, limit int) (string, bool) {
+	if len(after) != 1 {
+		return sqlQuery, false
+	}
+	count := after[0]
+	if count.Is("ALL") {
+		return sqlQuery[:count.Pos] + strconv.Itoa(limit) + sqlQuery[count.End():], true
+	}
+	if count.Kind != sqlparse.Number {
+		return sqlQuery, false // e.g. a ? placeholder
+	}
+	n, err := strconv.Atoi(strings.ReplaceAll(count.Text, "_", ""))
+	if err != nil {
+		return sqlQuery, false
+	}
+	if n > limit {
+		return sqlQuery[:count.Pos] + strconv.Itoa(limit) + sqlQuery[count.End():], true
+	}
+	return sqlQuery, true
+}
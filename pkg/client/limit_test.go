@@ -0,0 +1,133 @@
+package client
+
+import (
+	"context"
+	"testing"
+)
+
+func TestPushDownLimit(t *testing.T) {
+	tests := []struct {
+		name   string
+		sql    string
+		want   string
+		wantOK bool
+	}{
+		{
+			name:   "select",
+			sql:    "SELECT * FROM orders",
+			want:   "SELECT * FROM orders\nLIMIT 11",
+			wantOK: true,
+		},
+		{
+			name:   "lowercase with trailing semicolon",
+			sql:    "select * from orders;  ",
+			want:   "select * from orders\nLIMIT 11",
+			wantOK: true,
+		},
+		{
+			name:   "order by stays on the outer query",
+			sql:    "SELECT id FROM orders ORDER BY total DESC",
+			want:   "SELECT id FROM orders ORDER BY total DESC\nLIMIT 11",
+			wantOK: true,
+		},
+		{
+			name:   "cte",
+			sql:    "WITH big AS (SELECT * FROM orders WHERE total > 100 LIMIT 500) SELECT * FROM big",
+			want:   "WITH big AS (SELECT * FROM orders WHERE total > 100 LIMIT 500) SELECT * FROM big\nLIMIT 11",
+			wantOK: true,
+		},
+		{
+			name:   "union limits the whole set operation",
+			sql:    "SELECT id FROM a UNION ALL SELECT id FROM b",
+			want:   "SELECT id FROM a UNION ALL SELECT id FROM b\nLIMIT 11",
+			wantOK: true,
+		},
+		{
+			name:   "parenthesized union",
+			sql:    "(SELECT id FROM a LIMIT 100) UNION (SELECT id FROM b)",
+			want:   "(SELECT id FROM a LIMIT 100) UNION (SELECT id FROM b)\nLIMIT 11",
+			wantOK: true,
+		},
+		{
+			name:   "trailing line comment",
+			sql:    "SELECT 1 -- one",
+			want:   "SELECT 1\nLIMIT 11",
+			wantOK: true,
+		},
+		{
+			name:   "offset",
+			sql:    "SELECT * FROM orders ORDER BY id OFFSET 20",
+			want:   "SELECT * FROM orders ORDER BY id OFFSET 20\nLIMIT 11",
+			wantOK: true,
+		},
+		{
+			name:   "smaller limit kept",
+			sql:    "SELECT * FROM orders LIMIT 5",
+			want:   "SELECT * FROM orders LIMIT 5",
+			wantOK: true,
+		},
+		{
+			name:   "larger limit lowered",
+			sql:    "SELECT * FROM orders ORDER BY id LIMIT 100000;",
+			want:   "SELECT * FROM orders ORDER BY id LIMIT 11;",
+			wantOK: true,
+		},
+		{
+			name:   "limit all lowered",
+			sql:    "SELECT * FROM orders LIMIT ALL",
+			want:   "SELECT * FROM orders LIMIT 11",
+			wantOK: true,
+		},
+		{name: "placeholder limit", sql: "SELECT * FROM orders LIMIT ?", want: "SELECT * FROM orders LIMIT ?"},
+		{
+			name: "fetch first",
+			sql:  "SELECT * FROM orders FETCH FIRST 100 ROWS ONLY",
+			want: "SELECT * FROM orders FETCH FIRST 100 ROWS ONLY",
+		},
+		{
+			name: "fetch first with ties",
+			sql:  "SELECT * FROM orders ORDER BY total FETCH FIRST 3 ROWS WITH TIES",
+			want: "SELECT * FROM orders ORDER BY total FETCH FIRST 3 ROWS WITH TIES",
+		},
+		{name: "limit inside string", sql: "SELECT 'LIMIT 5' AS s", want: "SELECT 'LIMIT 5' AS s\nLIMIT 11", wantOK: true},
+		{name: "show", sql: "SHOW CATALOGS", want: "SHOW CATALOGS"},
+		{name: "explain", sql: "EXPLAIN SELECT 1", want: "EXPLAIN SELECT 1"},
+		{name: "insert", sql: "INSERT INTO t SELECT * FROM s", want: "INSERT INTO t SELECT * FROM s"},
+		{name: "multiple statements", sql: "SELECT 1; SELECT 2", want: "SELECT 1; SELECT 2"},
+		{name: "unterminated string", sql: "SELECT 'oops", want: "SELECT 'oops"},
+		{name: "empty", sql: "  ", want: "  "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := pushDownLimit(tt.sql, 11)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("pushDownLimit(%q) = %q, %v; want %q, %v", tt.sql, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestClient_Query_LimitPushdown(t *testing.T) {
+	f := newFakeTrino(t)
+	c := newFakeTrinoClient(t, f)
+
+	if _, err := c.Query(context.Background(), "SELECT 'ok' AS value", QueryOptions{Limit: 2}); err != nil {
+		t.Fatalf("Query() error: %v", err)
+	}
+	if _, err := c.Query(context.Background(), "SELECT 'ok' AS value",
+		QueryOptions{Limit: 2, DisableLimitPushdown: true}); err != nil {
+		t.Fatalf("Query() error: %v", err)
+	}
+
+	requests := f.Requests()
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 statement requests, got %d", len(requests))
+	}
+	if got, want := requests[0].Query, "SELECT 'ok' AS value\nLIMIT 3"; got != want {
+		t.Errorf("pushed down statement = %q, want %q", got, want)
+	}
+	if got, want := requests[1].Query, "SELECT 'ok' AS value"; got != want {
+		t.Errorf("opted out statement = %q, want %q", got, want)
+	}
+}
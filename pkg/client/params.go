@@ -0,0 +1,32 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/txn2/mcp-trino/pkg/sqlparse"
+)
+
+// countPlaceholders returns the number of ? parameter placeholders in a SQL
+// statement, skipping string literals, quoted identifiers, and comments.
+func countPlaceholders(sqlQuery string) int {
+	tokens, _ := sqlparse.Scan(sqlQuery) // count what precedes an unterminated quote
+	n := 0
+	for _, t := range tokens {
+		if t.Kind == sqlparse.Symbol && t.Text == "?" {
+			n++
+		}
+	}
+	return n
+}
+
+// checkArgs verifies that args match the statement's placeholders, so a
+// mismatch is reported before the statement is sent.
+func checkArgs(sqlQuery string, args []any) error {
+	if len(args) == 0 {
+		return nil
+	}
+	if want := countPlaceholders(sqlQuery); want != len(args) {
+		return fmt.Errorf("statement has %d parameter placeholders but %d arguments were given", want, len(args))
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/trinodb/trino-go-client/trino"
+)
+
+func TestCountPlaceholders(t *testing.T) {
+	tests := []struct {
+		sql  string
+		want int
+	}{
+		{sql: "SELECT 1", want: 0},
+		{sql: "SELECT * FROM t WHERE a = ? AND b = ?", want: 2},
+		{sql: "SELECT '?' FROM t WHERE a = ?", want: 1},
+		{sql: "SELECT 'it''s ?' FROM t WHERE a = ?", want: 1},
+		{sql: `SELECT "col?" FROM t WHERE a = ?`, want: 1},
+		{sql: "SELECT a -- where b = ?\nFROM t WHERE c = ?", want: 1},
+		{sql: "SELECT /* ? */ a FROM t WHERE c IN (?, ?)", want: 2},
+		{sql: "SELECT 'unterminated ?", want: 0},
+		{sql: "SELECT a /* unterminated ?", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sql, func(t *testing.T) {
+			if got := countPlaceholders(tt.sql); got != tt.want {
+				t.Errorf("countPlaceholders(%q) = %d, want %d", tt.sql, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_QueryArgs(t *testing.T) {
+	f := newFakeTrino(t)
+	c := newFakeTrinoClient(t, f)
+
+	opts := DefaultQueryOptions()
+	opts.Args = []any{int64(42), "O'Brien", trino.Date(2024, 1, 2), trino.Numeric("19.99"), nil}
+	sqlQuery := "SELECT * FROM orders WHERE id = ? AND name = ? AND day = ? AND price = ? AND note IS DISTINCT FROM ?"
+	_, err := c.Query(context.Background(), sqlQuery, opts)
+	if err != nil {
+		t.Fatalf("Query() error: %v", err)
+	}
+
+	requests := f.Requests()
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 statement request, got %d", len(requests))
+	}
+	// The driver prepares the statement via a header and executes it with
+	// the serialized arguments
+	want := "EXECUTE _trino_go USING 42, 'O''Brien', DATE '2024-01-02', 19.99, NULL"
+	if requests[0].Query != want {
+		t.Errorf("statement = %q, want %q", requests[0].Query, want)
+	}
+	prepared, err := url.QueryUnescape(strings.TrimPrefix(requests[0].Header.Get("X-Trino-Prepared-Statement"), "_trino_go="))
+	if want := sqlQuery + "\nLIMIT 1001"; err != nil || prepared != want {
+		t.Errorf("prepared statement = %q, want %q", prepared, want)
+	}
+}
+
+func TestClient_QueryArgs_Mismatch(t *testing.T) {
+	f := newFakeTrino(t)
+	c := newFakeTrinoClient(t, f)
+
+	opts := DefaultQueryOptions()
+	opts.Args = []any{int64(1), int64(2)}
+	_, err := c.Query(context.Background(), "SELECT * FROM t WHERE id = ?", opts)
+	if err == nil || !strings.Contains(err.Error(), "1 parameter placeholders but 2 arguments") {
+		t.Errorf("Query() error = %v, want placeholder mismatch", err)
+	}
+	if len(f.Requests()) != 0 {
+		t.Error("expected the statement not to be sent")
+	}
+}
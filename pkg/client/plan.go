@@ -0,0 +1,354 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// QueryPlan is a query plan as returned by EXPLAIN (FORMAT JSON). A
+// logical plan is a single tree; a distributed plan is one tree per
+// fragment, connected by remote sources.
+type QueryPlan struct {
+	Type      ExplainType    `json:"type"`
+	Fragments []PlanFragment `json:"fragments"`
+}
+
+// PlanFragment is a fragment of a distributed plan. The only fragment of a
+// logical plan has an empty ID.
+type PlanFragment struct {
+	ID   string    `json:"id,omitempty"`
+	Root *PlanNode `json:"root"`
+}
+
+// PlanNode is an operator of a query plan, such as a TableScan or an
+// InnerJoin.
+type PlanNode struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+
+	// Descriptor holds the operator's main attributes, e.g. the table of a
+	// scan or the distribution of a join.
+	Descriptor map[string]string `json:"descriptor,omitempty"`
+
+	Outputs []PlanSymbol `json:"outputs,omitempty"`
+
+	// Details holds further lines of the operator's description, such as
+	// the column assignments and constraints of a scan.
+	Details []string `json:"details,omitempty"`
+
+	// Estimates are the planner's estimates, one per operator an entry
+	// combines, e.g. scan, filter, and project for a ScanFilterProject.
+	// The last estimate is the node's output.
+	Estimates []PlanEstimate `json:"estimates,omitempty"`
+
+	Children []*PlanNode `json:"children,omitempty"`
+}
+
+// PlanSymbol is an output column of a plan node.
+type PlanSymbol struct {
+	Symbol string `json:"symbol"`
+	Type   string `json:"type"`
+}
+
+// PlanEstimate holds the planner's estimates for an operator. Nil fields
+// are unknown, typically because the table has no statistics.
+type PlanEstimate struct {
+	OutputRowCount    *float64 `json:"output_row_count,omitempty"`
+	OutputSizeInBytes *float64 `json:"output_size_in_bytes,omitempty"`
+	CPUCost           *float64 `json:"cpu_cost,omitempty"`
+	MemoryCost        *float64 `json:"memory_cost,omitempty"`
+	NetworkCost       *float64 `json:"network_cost,omitempty"`
+}
+
+// Output returns the estimate of the node's output, which may be empty.
+func (n *PlanNode) Output() PlanEstimate {
+	if len(n.Estimates) == 0 {
+		return PlanEstimate{}
+	}
+	return n.Estimates[len(n.Estimates)-1]
+}
+
+// Walk calls fn for every node of the plan, parents before children, with
+// the ID of the node's fragment.
+func (p *QueryPlan) Walk(fn func(fragment string, node *PlanNode)) {
+	for _, f := range p.Fragments {
+		walkPlan(f.Root, func(n *PlanNode) { fn(f.ID, n) })
+	}
+}
+
+func walkPlan(n *PlanNode, fn func(*PlanNode)) {
+	if n == nil {
+		return
+	}
+	fn(n)
+	for _, child := range n.Children {
+		walkPlan(child, fn)
+	}
+}
+
+// ExplainPlan returns the plan of a query as a tree, using EXPLAIN (FORMAT
+// JSON). Only logical and distributed plans have a JSON format.
+func (c *Client) ExplainPlan(ctx context.Context, sqlQuery string, explainType ExplainType) (*QueryPlan, error) {
+	if explainType == "" {
+		explainType = ExplainLogical
+	}
+	if explainType != ExplainLogical && explainType != ExplainDistributed {
+		return nil, fmt.Errorf("explain type %s has no JSON plan", explainType)
+	}
+
+	explainSQL := fmt.Sprintf("EXPLAIN (TYPE %s, FORMAT JSON) %s", explainType, sqlQuery) // #nosec G201 -- explainType is checked above
+
+	var planJSON strings.Builder
+	err := c.readRows(ctx, explainSQL, "explain failed", func(rows *sql.Rows) error {
+		planJSON.Reset()
+		for rows.Next() {
+			var line string
+			if err := rows.Scan(&line); err != nil {
+				return fmt.Errorf("failed to scan explain output: %w", err)
+			}
+			planJSON.WriteString(line)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ParsePlanJSON(planJSON.String(), explainType)
+}
+
+// ParsePlanJSON parses the output of EXPLAIN (FORMAT JSON): a single node
+// tree for a logical plan, or an object of trees keyed by fragment ID for
+// a distributed plan.
+func ParsePlanJSON(data string, explainType ExplainType) (*QueryPlan, error) {
+	plan := &QueryPlan{Type: explainType}
+
+	var root rawPlanNode
+	if err := json.Unmarshal([]byte(data), &root); err == nil && root.Name != "" {
+		plan.Fragments = []PlanFragment{{Root: root.node()}}
+		return plan, nil
+	}
+
+	var fragments map[string]rawPlanNode
+	if err := json.Unmarshal([]byte(data), &fragments); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON plan: %w", err)
+	}
+	for id, f := range fragments {
+		plan.Fragments = append(plan.Fragments, PlanFragment{ID: id, Root: f.node()})
+	}
+	// Fragment IDs are numbers; fragment 0 is the root of the query
+	slices.SortFunc(plan.Fragments, func(a, b PlanFragment) int {
+		ai, aErr := strconv.Atoi(a.ID)
+		bi, bErr := strconv.Atoi(b.ID)
+		if aErr != nil || bErr != nil {
+			return strings.Compare(a.ID, b.ID)
+		}
+		return ai - bi
+	})
+	return plan, nil
+}
+
+// rawPlanNode is a node as Trino's JSON plan renders it.
+type rawPlanNode struct {
+	ID         string            `json:"id"`
+	Name       string            `json:"name"`
+	Descriptor map[string]string `json:"descriptor"`
+	Outputs    []PlanSymbol      `json:"outputs"`
+	Details    []string          `json:"details"`
+	Estimates  []struct {
+		OutputRowCount    planNumber `json:"outputRowCount"`
+		OutputSizeInBytes planNumber `json:"outputSizeInBytes"`
+		CPUCost           planNumber `json:"cpuCost"`
+		MemoryCost        planNumber `json:"memoryCost"`
+		NetworkCost       planNumber `json:"networkCost"`
+	} `json:"estimates"`
+	Children []rawPlanNode `json:"children"`
+}
+
+func (r rawPlanNode) node() *PlanNode {
+	n := &PlanNode{
+		ID:         r.ID,
+		Name:       r.Name,
+		Descriptor: r.Descriptor,
+		Outputs:    r.Outputs,
+		Details:    r.Details,
+	}
+	for _, e := range r.Estimates {
+		n.Estimates = append(n.Estimates, PlanEstimate{
+			OutputRowCount:    e.OutputRowCount.value(),
+			OutputSizeInBytes: e.OutputSizeInBytes.value(),
+			CPUCost:           e.CPUCost.value(),
+			MemoryCost:        e.MemoryCost.value(),
+			NetworkCost:       e.NetworkCost.value(),
+		})
+	}
+	for _, child := range r.Children {
+		n.Children = append(n.Children, child.node())
+	}
+	return n
+}
+
+// planNumber is an estimate in a JSON plan. Unknown estimates are NaN,
+// which Trino writes as the string "NaN".
+type planNumber struct {
+	f     float64
+	valid bool
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *planNumber) UnmarshalJSON(b []byte) error {
+	// NaN, null, and anything else unparsable are unknown
+	f, err := strconv.ParseFloat(strings.Trim(string(b), `"`), 64)
+	*n = planNumber{f: f, valid: err == nil && !math.IsNaN(f) && !math.IsInf(f, 0)}
+	return nil
+}
+
+func (n planNumber) value() *float64 {
+	if !n.valid {
+		return nil
+	}
+	return &n.f
+}
+
+// Kinds of PlanFinding.
+const (
+	FindingFullTableScan           = "full_table_scan"
+	FindingCrossJoin               = "cross_join"
+	FindingMissingPartitionPruning = "missing_partition_pruning"
+	FindingLargeBroadcastJoin      = "large_broadcast_join"
+)
+
+// LargeBroadcastBytes is the estimated build side size above which a
+// broadcast (replicated) join is reported: every worker holds a copy of
+// the build side in memory.
+const LargeBroadcastBytes = 100 << 20
+
+// PlanAnalysis summarizes a query plan: the estimates of each operator and
+// the patterns that commonly make a query slow or expensive.
+type PlanAnalysis struct {
+	// Output is the estimate of the query's result.
+	Output PlanEstimate `json:"output"`
+
+	Nodes    []PlanNodeSummary `json:"nodes"`
+	Findings []PlanFinding     `json:"findings,omitempty"`
+}
+
+// PlanNodeSummary is an operator with its output estimate.
+type PlanNodeSummary struct {
+	Fragment string       `json:"fragment,omitempty"`
+	ID       string       `json:"id"`
+	Name     string       `json:"name"`
+	Table    string       `json:"table,omitempty"`
+	Estimate PlanEstimate `json:"estimate"`
+}
+
+// PlanFinding is a pattern in a plan worth a second look, such as a scan
+// that reads a whole table.
+type PlanFinding struct {
+	Kind    string `json:"kind"`
+	NodeID  string `json:"node_id"`
+	Table   string `json:"table,omitempty"`
+	Message string `json:"message"`
+}
+
+// AnalyzePlan summarizes plan and reports:
+//   - full table scans: scans with neither a filter nor a constraint
+//     pushed into the connector
+//   - cross joins
+//   - missing partition pruning: scans reading a partition key column
+//     without a constraint on it
+//   - large broadcast joins: replicated joins whose build side is
+//     estimated above LargeBroadcastBytes
+//
+// The findings are heuristics over what EXPLAIN shows. A connector that
+// prunes partitions without listing them in the plan, such as Iceberg,
+// is not reported as missing partition pruning.
+func AnalyzePlan(plan *QueryPlan) PlanAnalysis {
+	var out PlanAnalysis
+	if len(plan.Fragments) > 0 && plan.Fragments[0].Root != nil {
+		out.Output = plan.Fragments[0].Root.Output()
+	}
+	plan.Walk(func(fragment string, n *PlanNode) {
+		table := n.Descriptor["table"]
+		out.Nodes = append(out.Nodes, PlanNodeSummary{
+			Fragment: fragment, ID: n.ID, Name: n.Name, Table: table, Estimate: n.Output(),
+		})
+		out.Findings = append(out.Findings, nodeFindings(n, table)...)
+	})
+	return out
+}
+
+func nodeFindings(n *PlanNode, table string) []PlanFinding {
+	var findings []PlanFinding
+	add := func(kind, msg string) {
+		findings = append(findings, PlanFinding{Kind: kind, NodeID: n.ID, Table: table, Message: msg})
+	}
+
+	if table != "" {
+		if isFullScan(n) {
+			add(FindingFullTableScan, fmt.Sprintf("%s reads all of %s%s", n.Name, table, rowsSuffix(n.Output())))
+		}
+		if cols := unconstrainedPartitionKeys(n.Details); len(cols) > 0 {
+			add(FindingMissingPartitionPruning, fmt.Sprintf(
+				"%s reads every partition of %s: no filter on partition key %s", n.Name, table, strings.Join(cols, ", ")))
+		}
+	}
+
+	if n.Name == "CrossJoin" {
+		add(FindingCrossJoin, "CrossJoin pairs every row of one input with every row of the other"+rowsSuffix(n.Output()))
+	}
+
+	if n.Descriptor["distribution"] == "REPLICATED" && len(n.Children) > 1 {
+		if size := n.Children[1].Output().OutputSizeInBytes; size != nil && *size > LargeBroadcastBytes {
+			add(FindingLargeBroadcastJoin, fmt.Sprintf(
+				"%s broadcasts an estimated %.0f MiB build side to every worker", n.Name, *size/(1<<20)))
+		}
+	}
+	return findings
+}
+
+// isFullScan reports whether a scan node reads its table without any
+// predicate: no filter, no constraint pushed into the table handle, and
+// no domain on any column.
+func isFullScan(n *PlanNode) bool {
+	if n.Descriptor["filterPredicate"] != "" || strings.Contains(n.Descriptor["table"], "constraint") {
+		return false
+	}
+	for _, d := range n.Details {
+		if strings.HasPrefix(strings.TrimSpace(d), "::") {
+			return false
+		}
+	}
+	return true
+}
+
+// unconstrainedPartitionKeys returns the partition key columns of a scan,
+// listed in its details as "ds := ds:varchar:PARTITION_KEY", that are not
+// followed by a domain line such as ":: [[2024-01-01]]".
+func unconstrainedPartitionKeys(details []string) []string {
+	var cols []string
+	for i, d := range details {
+		if !strings.Contains(d, ":PARTITION_KEY") {
+			continue
+		}
+		if i+1 < len(details) && strings.HasPrefix(strings.TrimSpace(details[i+1]), "::") {
+			continue
+		}
+		if col, _, ok := strings.Cut(d, ":="); ok {
+			cols = append(cols, strings.TrimSpace(col))
+		}
+	}
+	return cols
+}
+
+func rowsSuffix(e PlanEstimate) string {
+	if e.OutputRowCount == nil {
+		return ""
+	}
+	return fmt.Sprintf(" (estimated %.0f rows)", *e.OutputRowCount)
+}
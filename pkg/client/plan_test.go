@@ -0,0 +1,166 @@
+package client
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func readPlan(t *testing.T, name string, explainType ExplainType) *QueryPlan {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan, err := ParsePlanJSON(string(data), explainType)
+	if err != nil {
+		t.Fatalf("ParsePlanJSON() error: %v", err)
+	}
+	return plan
+}
+
+func TestParsePlanJSON(t *testing.T) {
+	t.Run("logical", func(t *testing.T) {
+		plan := readPlan(t, "plan_logical.json", ExplainLogical)
+		if len(plan.Fragments) != 1 || plan.Fragments[0].ID != "" {
+			t.Fatalf("expected one unnamed fragment, got %+v", plan.Fragments)
+		}
+		root := plan.Fragments[0].Root
+		if root.Name != "Output" || len(root.Children) != 1 {
+			t.Fatalf("unexpected root: %+v", root)
+		}
+		if rows := root.Output().OutputRowCount; rows == nil || *rows != 1.5e9 {
+			t.Errorf("root rows = %v, want 1.5e9", rows)
+		}
+		if root.Output().CPUCost != nil {
+			t.Errorf("NaN cpu cost should be unknown, got %v", *root.Output().CPUCost)
+		}
+
+		scan := root.Children[0].Children[0]
+		if scan.Descriptor["table"] != "hive:sales:orders" || len(scan.Estimates) != 2 {
+			t.Errorf("unexpected scan: %+v", scan)
+		}
+		if rows := scan.Output().OutputRowCount; rows == nil || *rows != 1e7 {
+			t.Errorf("scan output rows = %v, want the last estimate, 1e7", rows)
+		}
+		if !reflect.DeepEqual(scan.Outputs, []PlanSymbol{{Symbol: "orderkey", Type: "bigint"}}) {
+			t.Errorf("scan outputs = %+v", scan.Outputs)
+		}
+	})
+
+	t.Run("distributed", func(t *testing.T) {
+		plan := readPlan(t, "plan_distributed.json", ExplainDistributed)
+		var ids []string
+		for _, f := range plan.Fragments {
+			ids = append(ids, f.ID)
+		}
+		if !reflect.DeepEqual(ids, []string{"0", "1", "2"}) {
+			t.Errorf("fragment IDs = %v, want [0 1 2]", ids)
+		}
+
+		var names []string
+		plan.Walk(func(fragment string, n *PlanNode) { names = append(names, fragment+":"+n.Name) })
+		want := []string{"0:Output", "1:InnerJoin", "1:ScanFilterProject", "1:LocalExchange", "1:RemoteSource", "2:TableScan"}
+		if !reflect.DeepEqual(names, want) {
+			t.Errorf("Walk() visited %v, want %v", names, want)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		if _, err := ParsePlanJSON("- Output[orderkey]", ExplainLogical); err == nil {
+			t.Error("expected error for a text plan")
+		}
+	})
+}
+
+func TestAnalyzePlan(t *testing.T) {
+	tests := []struct {
+		file        string
+		explainType ExplainType
+		want        []PlanFinding
+	}{
+		{
+			file:        "plan_logical.json",
+			explainType: ExplainLogical,
+			want: []PlanFinding{
+				{
+					Kind: FindingCrossJoin, NodeID: "4",
+					Message: "CrossJoin pairs every row of one input with every row of the other (estimated 1500000000 rows)",
+				},
+				{
+					Kind: FindingMissingPartitionPruning, NodeID: "0", Table: "hive:sales:orders",
+					Message: "ScanFilter reads every partition of hive:sales:orders: no filter on partition key ds",
+				},
+				{
+					Kind: FindingFullTableScan, NodeID: "1", Table: "hive:sales:customer",
+					Message: "TableScan reads all of hive:sales:customer (estimated 150 rows)",
+				},
+			},
+		},
+		{
+			file:        "plan_distributed.json",
+			explainType: ExplainDistributed,
+			want: []PlanFinding{
+				{
+					Kind: FindingLargeBroadcastJoin, NodeID: "5",
+					Message: "InnerJoin broadcasts an estimated 572 MiB build side to every worker",
+				},
+				{
+					Kind: FindingFullTableScan, NodeID: "3", Table: "hive:sales:customer",
+					Message: "TableScan reads all of hive:sales:customer (estimated 30000000 rows)",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			analysis := AnalyzePlan(readPlan(t, tt.file, tt.explainType))
+			if !reflect.DeepEqual(analysis.Findings, tt.want) {
+				t.Errorf("findings:\ngot:  %+v\nwant: %+v", analysis.Findings, tt.want)
+			}
+			if len(analysis.Nodes) == 0 || analysis.Output.OutputRowCount == nil {
+				t.Errorf("expected node summaries and an output estimate, got %+v", analysis)
+			}
+		})
+	}
+}
+
+func TestClient_ExplainPlan(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	client := NewWithDB(db, Config{Host: "localhost", Port: 8080, User: "test", Timeout: 30 * time.Second})
+
+	t.Run("logical", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"plan"}).
+			AddRow(`{"id": "0", "name": "Output", "estimates": [{"outputRowCount": 1.0}],`).
+			AddRow(`"children": [{"id": "1", "name": "Values"}]}`)
+		mock.ExpectQuery(`EXPLAIN \(TYPE LOGICAL, FORMAT JSON\) SELECT 1`).WillReturnRows(rows)
+
+		plan, err := client.ExplainPlan(context.Background(), "SELECT 1", "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if plan.Type != ExplainLogical || plan.Fragments[0].Root.Children[0].Name != "Values" {
+			t.Errorf("unexpected plan: %+v", plan)
+		}
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		if _, err := client.ExplainPlan(context.Background(), "SELECT 1", ExplainIO); err == nil {
+			t.Error("expected error for IO explain")
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
@@ -0,0 +1,172 @@
+package client
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Connection pool defaults, used for zero PoolConfig fields.
+const (
+	DefaultMaxOpenConns    = 10
+	DefaultMaxIdleConns    = 5
+	DefaultConnMaxLifetime = 5 * time.Minute
+)
+
+// PoolConfig sizes the client's pool of driver connections. A connection
+// is held for the duration of each query, so MaxOpenConns bounds the
+// number of concurrent queries; further queries wait for a connection.
+//
+// Zero fields use the defaults. A negative MaxOpenConns removes the
+// limit, a negative MaxIdleConns keeps no idle connections, and a negative
+// ConnMaxLifetime or ConnMaxIdleTime lets connections live indefinitely.
+type PoolConfig struct {
+	// MaxOpenConns is the maximum number of open connections. Default: 10.
+	MaxOpenConns int `json:"max_open_conns,omitempty" yaml:"max_open_conns,omitempty"`
+
+	// MaxIdleConns is the maximum number of idle connections kept for
+	// reuse. Default: 5.
+	MaxIdleConns int `json:"max_idle_conns,omitempty" yaml:"max_idle_conns,omitempty"`
+
+	// ConnMaxLifetime closes connections this long after they were opened.
+	// Default: 5m.
+	ConnMaxLifetime time.Duration `json:"conn_max_lifetime,omitempty" yaml:"conn_max_lifetime,omitempty"`
+
+	// ConnMaxIdleTime closes connections idle for this long. Default: no limit.
+	ConnMaxIdleTime time.Duration `json:"conn_max_idle_time,omitempty" yaml:"conn_max_idle_time,omitempty"`
+}
+
+// UnmarshalJSON accepts durations as strings such as "5m", matching the
+// YAML config file, as well as numbers of nanoseconds.
+func (p *PoolConfig) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		MaxOpenConns    int             `json:"max_open_conns"`
+		MaxIdleConns    int             `json:"max_idle_conns"`
+		ConnMaxLifetime json.RawMessage `json:"conn_max_lifetime"`
+		ConnMaxIdleTime json.RawMessage `json:"conn_max_idle_time"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	lifetime, err := parseJSONDuration(raw.ConnMaxLifetime)
+	if err != nil {
+		return fmt.Errorf("conn_max_lifetime: %w", err)
+	}
+	idleTime, err := parseJSONDuration(raw.ConnMaxIdleTime)
+	if err != nil {
+		return fmt.Errorf("conn_max_idle_time: %w", err)
+	}
+	*p = PoolConfig{
+		MaxOpenConns:    raw.MaxOpenConns,
+		MaxIdleConns:    raw.MaxIdleConns,
+		ConnMaxLifetime: lifetime,
+		ConnMaxIdleTime: idleTime,
+	}
+	return nil
+}
+
+// parseJSONDuration parses a JSON duration string or nanosecond count.
+// An absent value is zero.
+func parseJSONDuration(b json.RawMessage) (time.Duration, error) {
+	if len(b) == 0 || string(b) == "null" {
+		return 0, nil
+	}
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		return time.ParseDuration(s)
+	}
+	var n int64
+	if err := json.Unmarshal(b, &n); err != nil {
+		return 0, fmt.Errorf("invalid duration %s", b)
+	}
+	return time.Duration(n), nil
+}
+
+// ApplyPoolEnv overrides pool settings from environment variables:
+//   - TRINO_MAX_OPEN_CONNS: maximum open connections
+//   - TRINO_MAX_IDLE_CONNS: maximum idle connections
+//   - TRINO_CONN_MAX_LIFETIME: maximum connection age, e.g. 5m
+//   - TRINO_CONN_MAX_IDLE_TIME: maximum connection idle time, e.g. 1m
+func ApplyPoolEnv(p PoolConfig) PoolConfig {
+	setInt := func(dst *int, key string) {
+		if v := os.Getenv(key); v != "" {
+			if n, err := strconv.Atoi(v); err == nil {
+				*dst = n
+			}
+		}
+	}
+	setDuration := func(dst *time.Duration, key string) {
+		if v := os.Getenv(key); v != "" {
+			if d, err := time.ParseDuration(v); err == nil {
+				*dst = d
+			}
+		}
+	}
+
+	setInt(&p.MaxOpenConns, "TRINO_MAX_OPEN_CONNS")
+	setInt(&p.MaxIdleConns, "TRINO_MAX_IDLE_CONNS")
+	setDuration(&p.ConnMaxLifetime, "TRINO_CONN_MAX_LIFETIME")
+	setDuration(&p.ConnMaxIdleTime, "TRINO_CONN_MAX_IDLE_TIME")
+	return p
+}
+
+// apply configures db's pool, using the defaults for zero fields.
+func (p PoolConfig) apply(db *sql.DB) {
+	maxOpen, maxIdle, lifetime := p.MaxOpenConns, p.MaxIdleConns, p.ConnMaxLifetime
+	if maxOpen == 0 {
+		maxOpen = DefaultMaxOpenConns
+	}
+	if maxIdle == 0 {
+		maxIdle = DefaultMaxIdleConns
+	}
+	if lifetime == 0 {
+		lifetime = DefaultConnMaxLifetime
+	}
+
+	// database/sql treats zero and negative values alike as "no limit",
+	// except for idle connections, where both mean none are kept
+	db.SetMaxOpenConns(maxOpen)
+	db.SetMaxIdleConns(maxIdle)
+	db.SetConnMaxLifetime(lifetime)
+	db.SetConnMaxIdleTime(p.ConnMaxIdleTime)
+}
+
+// PoolStats is a snapshot of the client's connection pool. A WaitCount
+// that keeps growing means queries are waiting for a free connection,
+// i.e. MaxOpenConns is too low for the load.
+type PoolStats struct {
+	// MaxOpenConnections is the configured limit; 0 means no limit.
+	MaxOpenConnections int `json:"max_open_connections"`
+
+	OpenConnections int `json:"open_connections"`
+	InUse           int `json:"in_use"`
+	Idle            int `json:"idle"`
+
+	// WaitCount and WaitDurationMs total the waits for a free connection.
+	WaitCount      int64 `json:"wait_count"`
+	WaitDurationMs int64 `json:"wait_duration_ms"`
+
+	// Connections closed by the idle limit, idle time, and lifetime settings.
+	MaxIdleClosed     int64 `json:"max_idle_closed"`
+	MaxIdleTimeClosed int64 `json:"max_idle_time_closed"`
+	MaxLifetimeClosed int64 `json:"max_lifetime_closed"`
+}
+
+// PoolStats returns the current connection pool statistics.
+func (c *Client) PoolStats() PoolStats {
+	s := c.db.Stats()
+	return PoolStats{
+		MaxOpenConnections: s.MaxOpenConnections,
+		OpenConnections:    s.OpenConnections,
+		InUse:              s.InUse,
+		Idle:               s.Idle,
+		WaitCount:          s.WaitCount,
+		WaitDurationMs:     s.WaitDuration.Milliseconds(),
+		MaxIdleClosed:      s.MaxIdleClosed,
+		MaxIdleTimeClosed:  s.MaxIdleTimeClosed,
+		MaxLifetimeClosed:  s.MaxLifetimeClosed,
+	}
+}
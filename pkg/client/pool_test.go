@@ -0,0 +1,113 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestPoolConfig_Apply(t *testing.T) {
+	tests := []struct {
+		name        string
+		pool        PoolConfig
+		wantMaxOpen int
+	}{
+		{"defaults", PoolConfig{}, DefaultMaxOpenConns},
+		{"custom", PoolConfig{MaxOpenConns: 50, MaxIdleConns: 20}, 50},
+		{"unlimited", PoolConfig{MaxOpenConns: -1}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeTrino(t)
+			cfg := testServerConfig(t, f.Server)
+			cfg.Pool = tt.pool
+			c, err := New(cfg)
+			if err != nil {
+				t.Fatalf("New() error: %v", err)
+			}
+			defer func() { _ = c.Close() }()
+
+			if got := c.PoolStats().MaxOpenConnections; got != tt.wantMaxOpen {
+				t.Errorf("MaxOpenConnections = %d, want %d", got, tt.wantMaxOpen)
+			}
+		})
+	}
+}
+
+func TestClient_PoolStats(t *testing.T) {
+	f := newFakeTrino(t)
+	c := newFakeTrinoClient(t, f)
+
+	if stats := c.PoolStats(); stats.OpenConnections != 0 || stats.InUse != 0 {
+		t.Errorf("expected an empty pool before any query, got %+v", stats)
+	}
+
+	rows, err := c.QueryStream(context.Background(), "SELECT 1", QueryOptions{})
+	if err != nil {
+		t.Fatalf("QueryStream() error: %v", err)
+	}
+	if got := c.PoolStats().InUse; got != 1 {
+		t.Errorf("InUse during query = %d, want 1", got)
+	}
+	_ = rows.Close()
+
+	stats := c.PoolStats()
+	if stats.InUse != 0 || stats.Idle != 1 || stats.OpenConnections != 1 {
+		t.Errorf("expected one idle connection after the query, got %+v", stats)
+	}
+}
+
+func TestPoolConfig_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		want    PoolConfig
+		wantErr bool
+	}{
+		{
+			name: "duration strings",
+			json: `{"max_open_conns": 40, "max_idle_conns": 10, "conn_max_lifetime": "30m", "conn_max_idle_time": "90s"}`,
+			want: PoolConfig{MaxOpenConns: 40, MaxIdleConns: 10, ConnMaxLifetime: 30 * time.Minute, ConnMaxIdleTime: 90 * time.Second},
+		},
+		{
+			name: "nanoseconds",
+			json: `{"conn_max_lifetime": 60000000000}`,
+			want: PoolConfig{ConnMaxLifetime: time.Minute},
+		},
+		{name: "empty", json: `{}`, want: PoolConfig{}},
+		{name: "invalid duration", json: `{"conn_max_lifetime": "soon"}`, wantErr: true},
+		{name: "invalid type", json: `{"conn_max_idle_time": true}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got PoolConfig
+			err := json.Unmarshal([]byte(tt.json), &got)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unmarshal error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyPoolEnv(t *testing.T) {
+	t.Setenv("TRINO_MAX_OPEN_CONNS", "64")
+	t.Setenv("TRINO_MAX_IDLE_CONNS", "16")
+	t.Setenv("TRINO_CONN_MAX_LIFETIME", "15m")
+	t.Setenv("TRINO_CONN_MAX_IDLE_TIME", "not-a-duration")
+
+	got := ApplyPoolEnv(PoolConfig{ConnMaxIdleTime: time.Minute})
+	want := PoolConfig{MaxOpenConns: 64, MaxIdleConns: 16, ConnMaxLifetime: 15 * time.Minute, ConnMaxIdleTime: time.Minute}
+	if got != want {
+		t.Errorf("ApplyPoolEnv() = %+v, want %+v", got, want)
+	}
+}
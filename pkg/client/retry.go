@@ -0,0 +1,198 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/trinodb/trino-go-client/trino"
+
+	"github.com/txn2/mcp-trino/pkg/sqlparse"
+)
+
+// RetryConfig controls how read statements are retried after transient
+// failures, such as a coordinator restarting. Only statements that cannot
+// change data are retried: queries, SHOW, DESCRIBE, and EXPLAIN without
+// ANALYZE. The zero value makes a single attempt.
+type RetryConfig struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	// Zero or one disables retries.
+	MaxAttempts int `json:"max_attempts,omitempty" yaml:"max_attempts,omitempty"`
+
+	// InitialBackoff is the wait before the first retry. Each later wait
+	// doubles, up to MaxBackoff, and is randomly shortened by up to half
+	// so clients restarted together do not retry in lockstep.
+	InitialBackoff time.Duration `json:"initial_backoff,omitempty" yaml:"initial_backoff,omitempty"`
+
+	// MaxBackoff caps the wait between attempts.
+	MaxBackoff time.Duration `json:"max_backoff,omitempty" yaml:"max_backoff,omitempty"`
+
+	// Retryable reports whether an error is worth retrying. Default:
+	// IsRetryable.
+	Retryable func(error) bool `json:"-" yaml:"-"`
+}
+
+// DefaultRetryConfig returns the retry policy used by DefaultConfig: three
+// attempts, waiting about 500ms and then 1s.
+func DefaultRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxAttempts:    3,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+	}
+}
+
+// ApplyRetryEnv overrides retry settings from environment variables:
+//   - TRINO_RETRY_MAX_ATTEMPTS: total attempts per read statement (1 disables retries)
+//   - TRINO_RETRY_INITIAL_BACKOFF: wait before the first retry, e.g. 500ms
+//   - TRINO_RETRY_MAX_BACKOFF: maximum wait between attempts, e.g. 5s
+func ApplyRetryEnv(r RetryConfig) RetryConfig {
+	setDuration := func(dst *time.Duration, key string) {
+		if v := os.Getenv(key); v != "" {
+			if d, err := time.ParseDuration(v); err == nil {
+				*dst = d
+			}
+		}
+	}
+
+	if v := os.Getenv("TRINO_RETRY_MAX_ATTEMPTS"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			r.MaxAttempts = n
+		}
+	}
+	setDuration(&r.InitialBackoff, "TRINO_RETRY_INITIAL_BACKOFF")
+	setDuration(&r.MaxBackoff, "TRINO_RETRY_MAX_BACKOFF")
+	return r
+}
+
+// validate checks the retry settings.
+func (r RetryConfig) validate() error {
+	if r.MaxAttempts < 0 {
+		return fmt.Errorf("max attempts must not be negative")
+	}
+	if r.InitialBackoff < 0 || r.MaxBackoff < 0 {
+		return fmt.Errorf("backoff must not be negative")
+	}
+	return nil
+}
+
+// backoff returns the wait before the given retry, starting at 1.
+func (r RetryConfig) backoff(retry int) time.Duration {
+	d := r.InitialBackoff
+	for i := 1; i < retry && (r.MaxBackoff <= 0 || d < r.MaxBackoff); i++ {
+		d *= 2
+	}
+	if r.MaxBackoff > 0 && d > r.MaxBackoff {
+		d = r.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+	// #nosec G404 -- jitter does not need a cryptographic source
+	return d - rand.N(d/2+1)
+}
+
+// retryableErrorNames are the Trino error names of failures that a later
+// attempt can succeed past.
+var retryableErrorNames = map[string]bool{
+	"SERVER_STARTING_UP":       true,
+	"SERVER_SHUTTING_DOWN":     true,
+	"NO_NODES_AVAILABLE":       true,
+	"REMOTE_HOST_GONE":         true,
+	"REMOTE_TASK_MISMATCH":     true,
+	"PAGE_TRANSPORT_TIMEOUT":   true,
+	"TOO_MANY_REQUESTS_FAILED": true,
+}
+
+// IsRetryable reports whether err is a transient failure: the coordinator
+// starting up or shutting down, a lost worker, HTTP 429, 502, 503 or 504,
+// or a connection that was refused, reset, or closed mid-response.
+// Cancellations and context errors are never retryable.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, trino.ErrQueryCancelled) {
+		return false
+	}
+
+	var trinoErr *trino.ErrTrino
+	if errors.As(err, &trinoErr) {
+		return retryableErrorNames[trinoErr.ErrorName]
+	}
+	var failed *trino.ErrQueryFailed
+	if errors.As(err, &failed) {
+		switch failed.StatusCode {
+		case http.StatusTooManyRequests, http.StatusBadGateway,
+			http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+	}
+
+	if errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	// The coordinator closed the connection without responding
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) && errors.Is(urlErr.Err, io.EOF) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// isReadStatement reports whether sqlQuery is a single statement that
+// cannot change data, so running it again is safe.
+func isReadStatement(sqlQuery string) bool {
+	statements, err := sqlparse.Classify(sqlQuery)
+	if err != nil || len(statements) != 1 {
+		return false
+	}
+	switch s := statements[0]; s.Kind {
+	case sqlparse.KindQuery, sqlparse.KindShow:
+		return true
+	case sqlparse.KindExplain:
+		// EXPLAIN ANALYZE runs the statement
+		return !s.Analyze
+	}
+	return false
+}
+
+// withRetry calls attempt until it succeeds, fails with an error that is not
+// retryable, or the configured attempts are used up, waiting between
+// attempts. Statements that could change data get a single attempt. It
+// returns the number of attempts made.
+func (c *Client) withRetry(ctx context.Context, sqlQuery string, attempt func() error) (int, error) {
+	policy := c.config.Retry
+	retryable := policy.Retryable
+	if retryable == nil {
+		retryable = IsRetryable
+	}
+	maxAttempts := policy.MaxAttempts
+	if maxAttempts < 1 || !isReadStatement(sqlQuery) {
+		maxAttempts = 1
+	}
+
+	for n := 1; ; n++ {
+		err := attempt()
+		if err == nil || n >= maxAttempts || ctx.Err() != nil || !retryable(err) {
+			return n, err
+		}
+
+		timer := time.NewTimer(policy.backoff(n))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return n, err
+		case <-timer.C:
+		}
+	}
+}
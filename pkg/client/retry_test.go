@@ -0,0 +1,284 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/trinodb/trino-go-client/trino"
+)
+
+func TestIsRetryable(t *testing.T) {
+	trinoErr := func(name string) error {
+		return fmt.Errorf("query failed: %w", &trino.ErrQueryFailed{
+			StatusCode: 200,
+			Reason:     &trino.ErrTrino{ErrorName: name, ErrorType: "INTERNAL_ERROR"},
+		})
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"server starting up", trinoErr("SERVER_STARTING_UP"), true},
+		{"no nodes available", trinoErr("NO_NODES_AVAILABLE"), true},
+		{"syntax error", trinoErr("SYNTAX_ERROR"), false},
+		{"table not found", trinoErr("TABLE_NOT_FOUND"), false},
+		{"service unavailable", &trino.ErrQueryFailed{StatusCode: 503, Reason: errors.New("busy")}, true},
+		{"bad gateway", &trino.ErrQueryFailed{StatusCode: 502, Reason: errors.New("proxy")}, true},
+		{"unauthorized", &trino.ErrQueryFailed{StatusCode: 401, Reason: errors.New("denied")}, false},
+		{"connection refused", &url.Error{Op: "Post", URL: "http://trino", Err: syscall.ECONNREFUSED}, true},
+		{"connection reset", fmt.Errorf("query failed: %w", syscall.ECONNRESET), true},
+		{"closed without response", &url.Error{Op: "Post", URL: "http://trino", Err: io.EOF}, true},
+		{"unexpected EOF", io.ErrUnexpectedEOF, true},
+		{"cancelled by user", trino.ErrQueryCancelled, false},
+		{"context cancelled", fmt.Errorf("query failed: %w", context.Canceled), false},
+		{"deadline exceeded", &url.Error{Op: "Get", URL: "http://trino", Err: context.DeadlineExceeded}, false},
+		{"other", errors.New("boom"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryable(tt.err); got != tt.want {
+				t.Errorf("IsRetryable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsReadStatement(t *testing.T) {
+	tests := []struct {
+		sql  string
+		want bool
+	}{
+		{"SELECT 1", true},
+		{"  select * from t;", true},
+		{"WITH x AS (SELECT 1) SELECT * FROM x", true},
+		{"(SELECT 1) UNION (SELECT 2)", true},
+		{"VALUES 1, 2", true},
+		{"TABLE orders", true},
+		{"SHOW CATALOGS", true},
+		{"DESCRIBE orders", true},
+		{"EXPLAIN SELECT 1", true},
+		{"EXPLAIN (TYPE IO) SELECT 1", true},
+		{"/* comment */ SELECT 1", true},
+		{"EXPLAIN ANALYZE SELECT 1", false},
+		{"INSERT INTO t SELECT 1", false},
+		{"WITH x AS (SELECT 1) INSERT INTO t SELECT * FROM x", false},
+		{"SET SESSION query_max_run_time = '1h'", false},
+		{"CREATE TABLE t AS SELECT 1", false},
+		{"SELECT 1; DELETE FROM t", false},
+		{"CALL system.flush_metadata_cache()", false},
+		{"SELECT 'unterminated", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := isReadStatement(tt.sql); got != tt.want {
+			t.Errorf("isReadStatement(%q) = %v, want %v", tt.sql, got, tt.want)
+		}
+	}
+}
+
+func TestRetryConfig_Backoff(t *testing.T) {
+	r := RetryConfig{InitialBackoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond}
+	tests := []struct {
+		retry int
+		max   time.Duration
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{3, 300 * time.Millisecond},
+		{10, 300 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		for range 20 {
+			got := r.backoff(tt.retry)
+			if got > tt.max || got < tt.max/2 {
+				t.Errorf("backoff(%d) = %v, want between %v and %v", tt.retry, got, tt.max/2, tt.max)
+			}
+		}
+	}
+
+	if got := (RetryConfig{}).backoff(1); got != 0 {
+		t.Errorf("zero config backoff = %v, want 0", got)
+	}
+}
+
+func TestRetryConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		retry   RetryConfig
+		wantErr string
+	}{
+		{"zero value", RetryConfig{}, ""},
+		{"defaults", DefaultRetryConfig(), ""},
+		{"negative attempts", RetryConfig{MaxAttempts: -1}, "max attempts must not be negative"},
+		{"negative backoff", RetryConfig{InitialBackoff: -time.Second}, "backoff must not be negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.User = "service"
+			cfg.Retry = tt.retry
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestApplyRetryEnv(t *testing.T) {
+	t.Setenv("TRINO_RETRY_MAX_ATTEMPTS", "5")
+	t.Setenv("TRINO_RETRY_INITIAL_BACKOFF", "250ms")
+	t.Setenv("TRINO_RETRY_MAX_BACKOFF", "invalid")
+
+	got := ApplyRetryEnv(DefaultRetryConfig())
+	if got.MaxAttempts != 5 {
+		t.Errorf("MaxAttempts = %d, want 5", got.MaxAttempts)
+	}
+	if got.InitialBackoff != 250*time.Millisecond {
+		t.Errorf("InitialBackoff = %v, want 250ms", got.InitialBackoff)
+	}
+	if got.MaxBackoff != 5*time.Second {
+		t.Errorf("MaxBackoff = %v, want default 5s kept", got.MaxBackoff)
+	}
+}
+
+// newRetryingClient returns a Client for f that makes up to maxAttempts
+// attempts with negligible backoff.
+func newRetryingClient(t *testing.T, f *fakeTrino, maxAttempts int) *Client {
+	t.Helper()
+	cfg := testServerConfig(t, f.Server)
+	cfg.Retry = RetryConfig{MaxAttempts: maxAttempts, InitialBackoff: time.Millisecond}
+	c, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	t.Cleanup(func() { _ = c.Close() })
+	return c
+}
+
+func TestClient_Query_Retry(t *testing.T) {
+	tests := []struct {
+		name         string
+		sql          string
+		failures     int
+		failureName  string
+		maxAttempts  int
+		wantErr      bool
+		wantRequests int
+	}{
+		{"recovers after startup", "SELECT 1", 2, "SERVER_STARTING_UP", 3, false, 3},
+		{"gives up after max attempts", "SELECT 1", 3, "SERVER_STARTING_UP", 3, true, 3},
+		{"retries disabled", "SELECT 1", 1, "SERVER_STARTING_UP", 0, true, 1},
+		{"permanent error", "SELECT 1", 1, "SYNTAX_ERROR", 3, true, 1},
+		{"write statement", "INSERT INTO t VALUES (1)", 1, "SERVER_STARTING_UP", 3, true, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeTrino(t)
+			f.failures = tt.failures
+			f.failureName = tt.failureName
+			c := newRetryingClient(t, f, tt.maxAttempts)
+
+			result, err := c.Query(context.Background(), tt.sql, QueryOptions{DisableLimitPushdown: true})
+			if got := len(f.Requests()); got != tt.wantRequests {
+				t.Errorf("coordinator received %d statements, want %d", got, tt.wantRequests)
+			}
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), tt.failureName) {
+					t.Errorf("Query() error = %v, want %s", err, tt.failureName)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Query() error: %v", err)
+			}
+			if result.Stats.Attempts != tt.wantRequests {
+				t.Errorf("Stats.Attempts = %d, want %d", result.Stats.Attempts, tt.wantRequests)
+			}
+			if len(result.Rows) != 1 {
+				t.Errorf("expected 1 row, got %d", len(result.Rows))
+			}
+		})
+	}
+}
+
+func TestClient_QueryStream_Retry(t *testing.T) {
+	f := newFakeTrino(t)
+	f.failures = 1
+	f.failureName = "NO_NODES_AVAILABLE"
+	c := newRetryingClient(t, f, 3)
+
+	rows, err := c.QueryStream(context.Background(), "SELECT 1", QueryOptions{})
+	if err != nil {
+		t.Fatalf("QueryStream() error: %v", err)
+	}
+	defer func() { _ = rows.Close() }()
+	for rows.Next() {
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("Err() = %v", err)
+	}
+	if got := rows.Stats().Attempts; got != 2 {
+		t.Errorf("Stats().Attempts = %d, want 2", got)
+	}
+}
+
+func TestClient_Listing_Retry(t *testing.T) {
+	f := newFakeTrino(t)
+	f.failures = 1
+	f.failureName = "SERVER_SHUTTING_DOWN"
+	c := newRetryingClient(t, f, 2)
+
+	catalogs, err := c.ListCatalogs(context.Background())
+	if err != nil {
+		t.Fatalf("ListCatalogs() error: %v", err)
+	}
+	if len(catalogs) != 1 || catalogs[0] != "ok" {
+		t.Errorf("ListCatalogs() = %v, want [ok]", catalogs)
+	}
+	if got := len(f.Requests()); got != 2 {
+		t.Errorf("coordinator received %d statements, want 2", got)
+	}
+}
+
+func TestClient_Retry_StopsWhenContextEnds(t *testing.T) {
+	f := newFakeTrino(t)
+	f.failures = 10
+	f.failureName = "SERVER_STARTING_UP"
+	cfg := testServerConfig(t, f.Server)
+	cfg.Retry = RetryConfig{MaxAttempts: 10, InitialBackoff: time.Hour}
+	c, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	defer func() { _ = c.Close() }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := c.Query(ctx, "SELECT 1", QueryOptions{}); err == nil {
+		t.Fatal("expected error")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Query() took %v, want it to stop when the context ends", elapsed)
+	}
+	if got := len(f.Requests()); got != 1 {
+		t.Errorf("coordinator received %d statements, want 1", got)
+	}
+}
@@ -0,0 +1,99 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Trino client protocol request headers.
+const (
+	// trinoUserHeader is the request header naming the Trino session user.
+	trinoUserHeader = "X-Trino-User"
+
+	trinoCatalogHeader    = "X-Trino-Catalog"
+	trinoSchemaHeader     = "X-Trino-Schema"
+	trinoSessionHeader    = "X-Trino-Session"
+	trinoClientTagsHeader = "X-Trino-Client-Tags"
+	trinoRoleHeader       = "X-Trino-Role"
+)
+
+// sessionPropertyPattern matches system ("query_max_run_time") and catalog
+// ("hive.insert_existing_partitions_behavior") session property names.
+var sessionPropertyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// userKey is the context key for the impersonated user.
+type userKey struct{}
+
+// WithUser returns a context that runs queries as the given Trino user.
+// The connection's configured credentials still authenticate the request;
+// Trino must allow the configured user to impersonate user.
+func WithUser(ctx context.Context, user string) context.Context {
+	return context.WithValue(ctx, userKey{}, user)
+}
+
+// UserFromContext returns the user set by WithUser, or "" if none.
+func UserFromContext(ctx context.Context) string {
+	if user, ok := ctx.Value(userKey{}).(string); ok {
+		return user
+	}
+	return ""
+}
+
+// sessionArgs returns the per-request driver arguments derived from ctx.
+// They are passed to every query so the session user is applied consistently.
+func sessionArgs(ctx context.Context) []any {
+	var args []any
+	if user := UserFromContext(ctx); user != "" {
+		args = append(args, sql.Named(trinoUserHeader, user))
+	}
+	return args
+}
+
+// optionArgs returns the driver arguments that apply opts' catalog, schema,
+// session properties, client tags, and roles to a single query.
+func optionArgs(opts QueryOptions) ([]any, error) {
+	var args []any
+	if opts.Catalog != "" {
+		args = append(args, sql.Named(trinoCatalogHeader, opts.Catalog))
+	}
+	if opts.Schema != "" {
+		args = append(args, sql.Named(trinoSchemaHeader, opts.Schema))
+	}
+
+	if len(opts.SessionProperties) > 0 {
+		names := make([]string, 0, len(opts.SessionProperties))
+		for name := range opts.SessionProperties {
+			if !sessionPropertyPattern.MatchString(name) {
+				return nil, fmt.Errorf("invalid session property name %q", name)
+			}
+			names = append(names, name)
+		}
+		slices.Sort(names)
+
+		// Trino URL-decodes each value, so commas and equals signs are safe
+		entries := make([]string, len(names))
+		for i, name := range names {
+			entries[i] = name + "=" + url.QueryEscape(opts.SessionProperties[name])
+		}
+		args = append(args, sql.Named(trinoSessionHeader, strings.Join(entries, ",")))
+	}
+
+	if len(opts.ClientTags) > 0 {
+		for _, tag := range opts.ClientTags {
+			if tag == "" || strings.Contains(tag, ",") {
+				return nil, fmt.Errorf("invalid client tag %q", tag)
+			}
+		}
+		args = append(args, sql.Named(trinoClientTagsHeader, strings.Join(opts.ClientTags, ",")))
+	}
+
+	if len(opts.Roles) > 0 {
+		args = append(args, sql.Named(trinoRoleHeader, opts.Roles))
+	}
+	return args, nil
+}
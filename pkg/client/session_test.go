@@ -0,0 +1,140 @@
+package client
+
+import (
+	"context"
+	"testing"
+)
+
+func TestUserFromContext(t *testing.T) {
+	if got := UserFromContext(context.Background()); got != "" {
+		t.Errorf("expected empty user, got %q", got)
+	}
+	if got := UserFromContext(WithUser(context.Background(), "alice")); got != "alice" {
+		t.Errorf("expected alice, got %q", got)
+	}
+}
+
+func TestClient_Impersonation(t *testing.T) {
+	fake := newFakeTrino(t)
+	c := newFakeTrinoClient(t, fake)
+
+	calls := []struct {
+		name string
+		run  func(ctx context.Context) error
+	}{
+		{name: "Query", run: func(ctx context.Context) error {
+			_, err := c.Query(ctx, "SELECT 'ok'", DefaultQueryOptions())
+			return err
+		}},
+		{name: "Explain", run: func(ctx context.Context) error {
+			_, err := c.Explain(ctx, "SELECT 1", ExplainLogical)
+			return err
+		}},
+		{name: "ListCatalogs", run: func(ctx context.Context) error {
+			_, err := c.ListCatalogs(ctx)
+			return err
+		}},
+		{name: "ListSchemas", run: func(ctx context.Context) error {
+			_, err := c.ListSchemas(ctx, "memory")
+			return err
+		}},
+		{name: "ListTables", run: func(ctx context.Context) error {
+			_, err := c.ListTables(ctx, "memory", "default")
+			return err
+		}},
+	}
+
+	for _, tt := range calls {
+		t.Run(tt.name, func(t *testing.T) {
+			before := len(fake.Requests())
+
+			if err := tt.run(WithUser(context.Background(), "alice")); err != nil {
+				t.Fatalf("impersonated call error: %v", err)
+			}
+			if err := tt.run(context.Background()); err != nil {
+				t.Fatalf("service call error: %v", err)
+			}
+
+			reqs := fake.Requests()[before:]
+			if len(reqs) != 2 {
+				t.Fatalf("expected 2 requests, got %d", len(reqs))
+			}
+			if got := reqs[0].Header.Get(trinoUserHeader); got != "alice" {
+				t.Errorf("expected impersonated user alice, got %q", got)
+			}
+			if got := reqs[1].Header.Get(trinoUserHeader); got != "service" {
+				t.Errorf("expected service user, got %q", got)
+			}
+		})
+	}
+}
+
+func TestClient_QueryOptions(t *testing.T) {
+	fake := newFakeTrino(t)
+	c := newFakeTrinoClient(t, fake)
+
+	opts := DefaultQueryOptions()
+	opts.Catalog = "hive"
+	opts.Schema = "sales"
+	opts.SessionProperties = map[string]string{
+		"query_max_execution_time":                 "10m",
+		"hive.insert_existing_partitions_behavior": "OVERWRITE",
+		"optimize_hash_generation":                 "a,b=c",
+	}
+	opts.ClientTags = []string{"mcp", "adhoc"}
+	opts.Roles = map[string]string{"system": "analyst"}
+
+	if _, err := c.Query(context.Background(), "SELECT 'ok'", opts); err != nil {
+		t.Fatalf("Query() error: %v", err)
+	}
+	if _, err := c.Query(context.Background(), "SELECT 'ok'", DefaultQueryOptions()); err != nil {
+		t.Fatalf("Query() error: %v", err)
+	}
+
+	reqs := fake.Requests()
+	if len(reqs) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(reqs))
+	}
+
+	want := map[string]string{
+		trinoCatalogHeader: "hive",
+		trinoSchemaHeader:  "sales",
+		trinoSessionHeader: "hive.insert_existing_partitions_behavior=OVERWRITE," +
+			"optimize_hash_generation=a%2Cb%3Dc,query_max_execution_time=10m",
+		trinoClientTagsHeader: "mcp,adhoc",
+		trinoRoleHeader:       "system=ROLE{analyst}",
+	}
+	for header, value := range want {
+		if got := reqs[0].Header.Get(header); got != value {
+			t.Errorf("expected %s %q, got %q", header, value, got)
+		}
+	}
+
+	// Per-query options must not leak into later queries on the pool
+	for header := range want {
+		if got := reqs[1].Header.Get(header); got != "" {
+			t.Errorf("expected no %s on the next query, got %q", header, got)
+		}
+	}
+}
+
+func TestOptionArgs_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		opts QueryOptions
+	}{
+		{name: "session property with spaces", opts: QueryOptions{SessionProperties: map[string]string{"a b": "1"}}},
+		{name: "session property with equals", opts: QueryOptions{SessionProperties: map[string]string{"a=b": "1"}}},
+		{name: "nested session property", opts: QueryOptions{SessionProperties: map[string]string{"a.b.c": "1"}}},
+		{name: "empty client tag", opts: QueryOptions{ClientTags: []string{""}}},
+		{name: "client tag with comma", opts: QueryOptions{ClientTags: []string{"a,b"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := optionArgs(tt.opts); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/trinodb/trino-go-client/trino"
+)
+
+// QueryWarning is a warning Trino attached to a query, such as use of a
+// deprecated function or a partition filter that could not be pushed down.
+type QueryWarning struct {
+	Code    int    `json:"code"`
+	Name    string `json:"name"`
+	Message string `json:"message"`
+}
+
+// statementStats is the subset of the Trino statement protocol's stats
+// object that QueryStats reports.
+type statementStats struct {
+	State            string `json:"state"`
+	TotalSplits      int    `json:"totalSplits"`
+	CPUTimeMillis    int64  `json:"cpuTimeMillis"`
+	WallTimeMillis   int64  `json:"wallTimeMillis"`
+	QueuedTimeMillis int64  `json:"queuedTimeMillis"`
+	ProcessedRows    int64  `json:"processedRows"`
+	ProcessedBytes   int64  `json:"processedBytes"`
+	PeakMemoryBytes  int64  `json:"peakMemoryBytes"`
+}
+
+// statementResponse is the part of a statement protocol response that
+// statementObserver records.
+type statementResponse struct {
+	ID      string `json:"id"`
+	Columns []struct {
+		Type string `json:"type"`
+	} `json:"columns"`
+	Stats    statementStats `json:"stats"`
+	Warnings []struct {
+		WarningCode struct {
+			Code int    `json:"code"`
+			Name string `json:"name"`
+		} `json:"warningCode"`
+		Message string `json:"message"`
+	} `json:"warnings"`
+}
+
+// statementObserver collects the query ID, column types, statistics, and
+// warnings from the statement protocol responses of one query. The driver
+// does not expose them, so statementTransport feeds the observer as
+// responses arrive.
+type statementObserver struct {
+	mu          sync.Mutex
+	queryID     string
+	columnTypes []string
+	stats       statementStats
+	warnings    []QueryWarning
+}
+
+// record merges a statement response. Stats are cumulative, so the latest
+// response wins; warnings are repeated across responses and deduplicated.
+func (o *statementObserver) record(resp statementResponse) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if resp.ID != "" {
+		o.queryID = resp.ID
+	}
+	if o.columnTypes == nil && len(resp.Columns) > 0 {
+		o.columnTypes = make([]string, len(resp.Columns))
+		for i, col := range resp.Columns {
+			o.columnTypes[i] = col.Type
+		}
+	}
+	if resp.Stats.State != "" {
+		o.stats = resp.Stats
+	}
+	for _, w := range resp.Warnings {
+		warning := QueryWarning{Code: w.WarningCode.Code, Name: w.WarningCode.Name, Message: w.Message}
+		if !containsWarning(o.warnings, warning) {
+			o.warnings = append(o.warnings, warning)
+		}
+	}
+}
+
+// QueryID returns the query ID seen in the responses, if any.
+func (o *statementObserver) QueryID() string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.queryID
+}
+
+// ColumnTypes returns the Trino types of the result columns, such as
+// decimal(10,2), or nil if no response has described them yet.
+func (o *statementObserver) ColumnTypes() []string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.columnTypes
+}
+
+// apply copies the observed statistics into stats.
+func (o *statementObserver) apply(stats *QueryStats) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if stats.QueryID == "" {
+		stats.QueryID = o.queryID
+	}
+	stats.State = o.stats.State
+	stats.CPUTimeMs = o.stats.CPUTimeMillis
+	stats.WallTimeMs = o.stats.WallTimeMillis
+	stats.QueuedTimeMs = o.stats.QueuedTimeMillis
+	stats.ProcessedRows = o.stats.ProcessedRows
+	stats.ProcessedBytes = o.stats.ProcessedBytes
+	stats.PeakMemoryBytes = o.stats.PeakMemoryBytes
+	stats.Splits = o.stats.TotalSplits
+}
+
+// Warnings returns the warnings seen so far.
+func (o *statementObserver) Warnings() []QueryWarning {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return append([]QueryWarning(nil), o.warnings...)
+}
+
+func containsWarning(warnings []QueryWarning, w QueryWarning) bool {
+	for _, existing := range warnings {
+		if existing == w {
+			return true
+		}
+	}
+	return false
+}
+
+// statementObserverKey is the context key for the statementObserver of the
+// query whose driver requests carry the context.
+type statementObserverKey struct{}
+
+// withStatementObserver returns a context whose driver requests report to o.
+func withStatementObserver(ctx context.Context, o *statementObserver) context.Context {
+	return context.WithValue(ctx, statementObserverKey{}, o)
+}
+
+// statementTransport is the driver's HTTP transport. It passes requests
+// through to base and, when the request context carries a
+// statementObserver, records each statement protocol response in it.
+type statementTransport struct {
+	base http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *statementTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	observer, ok := req.Context().Value(statementObserverKey{}).(*statementObserver)
+	if !ok || resp.StatusCode != http.StatusOK || !strings.HasPrefix(req.URL.Path, "/v1/statement") {
+		return resp, nil
+	}
+
+	// The driver decodes the same body, so buffer it and hand back a copy
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read statement response: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	var parsed statementResponse
+	if json.Unmarshal(body, &parsed) == nil {
+		observer.record(parsed)
+	}
+	return resp, nil
+}
+
+// customClientSeq numbers the driver HTTP clients registered by New.
+var customClientSeq atomic.Uint64
+
+// registerDriverClient registers an HTTP client for the driver that wraps
+// httpClient's transport in a statementTransport, and returns the name to
+// pass as the DSN's custom_client parameter.
+func registerDriverClient(httpClient *http.Client) (string, error) {
+	name := fmt.Sprintf("mcp-trino-%d", customClientSeq.Add(1))
+	driverClient := &http.Client{Transport: &statementTransport{base: httpClient.Transport}}
+	if err := trino.RegisterCustomClient(name, driverClient); err != nil {
+		return "", fmt.Errorf("failed to register HTTP client: %w", err)
+	}
+	return name, nil
+}
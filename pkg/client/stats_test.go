@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestClient_Query_StatsAndWarnings(t *testing.T) {
+	f := newFakeTrino(t)
+	c := newFakeTrinoClient(t, f)
+
+	result, err := c.Query(context.Background(), "SELECT 'ok' AS value", DefaultQueryOptions())
+	if err != nil {
+		t.Fatalf("Query() error: %v", err)
+	}
+
+	stats := result.Stats
+	if stats.QueryID != fakeQueryID {
+		t.Errorf("expected query ID %s, got %q", fakeQueryID, stats.QueryID)
+	}
+	want := QueryStats{
+		RowCount:        1,
+		DurationMs:      stats.DurationMs,
+		LimitApplied:    1000,
+		QueryID:         fakeQueryID,
+		Attempts:        1,
+		State:           "FINISHED",
+		CPUTimeMs:       340,
+		WallTimeMs:      510,
+		QueuedTimeMs:    7,
+		ProcessedRows:   1500,
+		ProcessedBytes:  2048,
+		PeakMemoryBytes: 4096,
+		Splits:          12,
+	}
+	if stats != want {
+		t.Errorf("unexpected stats:\n got %+v\nwant %+v", stats, want)
+	}
+
+	wantWarnings := []QueryWarning{
+		{Code: 1, Name: "DEPRECATED_FUNCTION", Message: "function foo is deprecated"},
+		{Code: 2, Name: "TOO_MANY_STAGES", Message: "query has 150 stages"},
+	}
+	if !reflect.DeepEqual(result.Warnings, wantWarnings) {
+		t.Errorf("unexpected warnings: %+v", result.Warnings)
+	}
+}
+
+func TestClient_Query_StatsWithoutDriver(t *testing.T) {
+	c, mock := newStreamMock(t)
+	mock.ExpectQuery("SELECT").WillReturnRows(mock.NewRows([]string{"id"}).AddRow(1))
+
+	result, err := c.Query(context.Background(), "SELECT id FROM t", DefaultQueryOptions())
+	if err != nil {
+		t.Fatalf("Query() error: %v", err)
+	}
+	if result.Stats.State != "" || result.Stats.CPUTimeMs != 0 || result.Warnings != nil {
+		t.Errorf("expected no coordinator stats without the driver, got %+v %+v", result.Stats, result.Warnings)
+	}
+}
+
+func TestStatementObserver_Record(t *testing.T) {
+	var o statementObserver
+	o.record(statementResponse{ID: "q1", Stats: statementStats{State: "RUNNING", CPUTimeMillis: 10}})
+	o.record(statementResponse{Stats: statementStats{State: "FINISHED", CPUTimeMillis: 25}})
+	o.record(statementResponse{})
+
+	var stats QueryStats
+	o.apply(&stats)
+	if stats.QueryID != "q1" || stats.State != "FINISHED" || stats.CPUTimeMs != 25 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+
+	stats = QueryStats{QueryID: "from-progress"}
+	o.apply(&stats)
+	if stats.QueryID != "from-progress" {
+		t.Errorf("expected existing query ID to be kept, got %q", stats.QueryID)
+	}
+}
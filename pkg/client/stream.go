@@ -0,0 +1,368 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"iter"
+	"strings"
+	"time"
+
+	"github.com/trinodb/trino-go-client/trino"
+)
+
+// Rows is a streaming query result returned by QueryStream. Rows are fetched
+// from Trino as the caller advances, so memory use does not grow with the
+// result size and a slow consumer slows the fetch rather than buffering.
+//
+// Rows must be closed; closing early cancels the rest of the query. If the
+// context passed to QueryStream ends first, the query is also cancelled on
+// the coordinator (see Client.CancelQuery).
+//
+//	rows, err := c.QueryStream(ctx, "SELECT * FROM big_table", client.QueryOptions{})
+//	if err != nil {
+//	    return err
+//	}
+//	defer rows.Close()
+//	for rows.Next() {
+//	    process(rows.Row())
+//	}
+//	return rows.Err()
+type Rows struct {
+	rows       *sql.Rows
+	cancel     context.CancelFunc
+	stopCancel func() bool // stops the coordinator-side cancel registered for ctx
+	conn       *sql.Conn   // dedicated connection, discarded on Close; nil for pooled queries
+	columns    []ColumnInfo
+	types      []*trinoType          // per column; nil entries are converted generically
+	progress   *queryProgressUpdater // nil when the driver has no progress callback
+	observer   *statementObserver
+
+	values []any
+	ptrs   []any
+
+	limit     int
+	count     int
+	attempts  int
+	truncated bool
+	done      bool
+	err       error
+
+	start time.Time
+	end   time.Time
+}
+
+// QueryStream executes a SQL query and returns its rows as a stream.
+// Column metadata is available immediately through Rows.Columns.
+//
+// opts.Limit caps the number of rows read; zero or negative means no limit.
+// Unless opts.DisableLimitPushdown is set, queries are rewritten so Trino
+// enforces the limit too (see QueryOptions.Limit).
+// opts.Timeout (or the client default) bounds the whole stream, including
+// the time the caller spends consuming rows. The catalog, schema, session
+// properties, client tags, roles, and args in opts apply to this query only.
+//
+// Read statements that fail to start with a transient error are retried
+// as configured by Config.Retry; failures after the first row has been
+// returned are not, since the caller has already seen part of the result.
+func (c *Client) QueryStream(ctx context.Context, sqlQuery string, opts QueryOptions) (*Rows, error) {
+	start := time.Now()
+
+	sqlQuery, optArgs, err := prepareQuery(sqlQuery, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows *Rows
+	attempts, err := c.withRetry(ctx, sqlQuery, func() error {
+		rows, err = c.startQuery(ctx, sqlQuery, optArgs, opts)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	rows.start = start
+	rows.attempts = attempts
+	return rows, nil
+}
+
+// prepareQuery checks the statement's arguments and applies limit pushdown,
+// returning the SQL to run and the driver arguments for opts.
+func prepareQuery(sqlQuery string, opts QueryOptions) (string, []any, error) {
+	if err := checkArgs(sqlQuery, opts.Args); err != nil {
+		return "", nil, err
+	}
+	if opts.Limit > 0 && !opts.DisableLimitPushdown {
+		// One row more than the limit tells Rows whether the result was cut off
+		sqlQuery, _ = pushDownLimit(sqlQuery, opts.Limit+1)
+	}
+	optArgs, err := optionArgs(opts)
+	if err != nil {
+		return "", nil, err
+	}
+	return sqlQuery, optArgs, nil
+}
+
+// startQuery makes one attempt at running a statement prepared by
+// prepareQuery, returning once its columns are known.
+func (c *Client) startQuery(ctx context.Context, sqlQuery string, optArgs []any, opts QueryOptions) (*Rows, error) {
+	start := time.Now()
+
+	// Apply timeout; the stream owns the cancel func until Close
+	timeout := c.config.Timeout
+	if opts.Timeout > 0 {
+		timeout = opts.Timeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+
+	// The driver keeps X-Trino-Role on its connection after the query, so a
+	// query with roles gets a connection of its own that Close discards
+	// instead of returning it to the pool
+	var q queryer = c.db
+	var conn *sql.Conn
+	if len(opts.Roles) > 0 {
+		var err error
+		conn, err = c.db.Conn(ctx)
+		if err != nil {
+			cancel()
+			return nil, fmt.Errorf("failed to get connection: %w", err)
+		}
+		q = conn
+	}
+
+	// Set up progress updater to capture query ID, and an observer for the
+	// stats and warnings in the driver's responses
+	progressUpdater := &queryProgressUpdater{}
+	observer := &statementObserver{}
+	ctx = withStatementObserver(ctx, observer)
+
+	// If ctx ends while the query is running, cancel it on the coordinator
+	progress := progressUpdater // progressUpdater is cleared below if unsupported
+	stopCancel := c.cancelOnDone(ctx, func() string {
+		if id := progress.QueryID(); id != "" {
+			return id
+		}
+		return observer.QueryID()
+	})
+	release := func() {
+		stopCancel()
+		discardConn(conn)
+		cancel()
+	}
+
+	// Execute query with progress callback to capture query ID.
+	// The progress callback is a Trino-specific feature. If the driver doesn't
+	// support it (e.g., when using sqlmock for testing), fall back to a simple query.
+	args := append([]any{}, opts.Args...)
+	args = append(args, sessionArgs(ctx)...)
+	args = append(args, optArgs...)
+	rows, err := q.QueryContext(ctx, sqlQuery, append(args,
+		sql.Named("X-Trino-Progress-Callback", trino.ProgressUpdater(progressUpdater)),
+		sql.Named("X-Trino-Progress-Callback-Period", 100*time.Millisecond),
+	)...)
+	if err != nil {
+		// Check if the error is due to unsupported argument type (e.g., when using sqlmock).
+		// In that case, retry without the progress callback.
+		if !strings.Contains(err.Error(), "unsupported type") {
+			release()
+			return nil, fmt.Errorf("query failed: %w", err)
+		}
+		rows, err = q.QueryContext(ctx, sqlQuery, args...)
+		if err != nil {
+			release()
+			return nil, fmt.Errorf("query failed: %w", err)
+		}
+		progressUpdater = nil // Clear so we don't try to get QueryID
+	}
+
+	// Get column info
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		_ = rows.Close()
+		release()
+		return nil, fmt.Errorf("failed to get column types: %w", err)
+	}
+
+	columns := make([]ColumnInfo, len(columnTypes))
+	for i, ct := range columnTypes {
+		nullable, _ := ct.Nullable()
+		columns[i] = ColumnInfo{
+			Name:     ct.Name(),
+			Type:     ct.DatabaseTypeName(),
+			Nullable: nullable,
+		}
+	}
+
+	// The statement response's type strings are exact, e.g. decimal(10,2);
+	// the driver's type names drop parameters such as timestamp precision
+	observedTypes := observer.ColumnTypes()
+	types := make([]*trinoType, len(columns))
+	for i, col := range columns {
+		typ := strings.ToLower(col.Type)
+		if i < len(observedTypes) {
+			typ = observedTypes[i]
+		}
+		if typ != "" {
+			types[i] = parseTrinoType(typ)
+		}
+	}
+
+	// Scan destinations are reused for every row
+	values := make([]any, len(columns))
+	ptrs := make([]any, len(columns))
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+
+	return &Rows{
+		rows:       rows,
+		cancel:     cancel,
+		stopCancel: stopCancel,
+		conn:       conn,
+		columns:    columns,
+		types:      types,
+		progress:   progressUpdater,
+		observer:   observer,
+		values:     values,
+		ptrs:       ptrs,
+		limit:      opts.Limit,
+		start:      start,
+	}, nil
+}
+
+// Columns returns the result's column metadata.
+func (r *Rows) Columns() []ColumnInfo {
+	return r.columns
+}
+
+// Next advances to the next row, returning false when the result is
+// exhausted, the limit is reached, or an error occurs (see Err).
+func (r *Rows) Next() bool {
+	if r.done {
+		return false
+	}
+
+	if r.limit > 0 && r.count >= r.limit {
+		// Peek once so Stats can report whether rows were cut off
+		r.truncated = r.rows.Next()
+		r.finish(nil)
+		return false
+	}
+
+	if !r.rows.Next() {
+		var err error
+		if rowsErr := r.rows.Err(); rowsErr != nil {
+			err = fmt.Errorf("row iteration error: %w", rowsErr)
+		}
+		r.finish(err)
+		return false
+	}
+
+	if err := r.rows.Scan(r.ptrs...); err != nil {
+		r.finish(fmt.Errorf("failed to scan row: %w", err))
+		return false
+	}
+	r.count++
+	return true
+}
+
+// finish records the end of iteration and any error that ended it.
+func (r *Rows) finish(err error) {
+	r.stopCancel()
+	r.done = true
+	r.end = time.Now()
+	r.err = err
+}
+
+// Values returns the current row's values in column order, converted to
+// JSON-friendly types according to each column's Trino type. The returned
+// slice is not reused.
+func (r *Rows) Values() []any {
+	out := make([]any, len(r.values))
+	for i, v := range r.values {
+		out[i] = r.types[i].convert(v)
+	}
+	return out
+}
+
+// Row returns the current row as a map from column name to converted value.
+func (r *Rows) Row() map[string]any {
+	row := make(map[string]any, len(r.columns))
+	for i, col := range r.columns {
+		row[col.Name] = r.types[i].convert(r.values[i])
+	}
+	return row
+}
+
+// All returns an iterator over the remaining rows as maps. Check Err after
+// the loop; breaking out of the loop leaves the stream open until Close.
+func (r *Rows) All() iter.Seq[map[string]any] {
+	return func(yield func(map[string]any) bool) {
+		for r.Next() {
+			if !yield(r.Row()) {
+				return
+			}
+		}
+	}
+}
+
+// Err returns the error, if any, that ended iteration.
+func (r *Rows) Err() error {
+	return r.err
+}
+
+// Stats returns execution statistics for the rows read so far.
+func (r *Rows) Stats() QueryStats {
+	end := r.end
+	if end.IsZero() {
+		end = time.Now()
+	}
+
+	stats := QueryStats{
+		RowCount:     r.count,
+		DurationMs:   end.Sub(r.start).Milliseconds(),
+		Truncated:    r.truncated,
+		LimitApplied: r.limit,
+		Attempts:     r.attempts,
+	}
+	if r.progress != nil {
+		stats.QueryID = r.progress.QueryID()
+	}
+	r.observer.apply(&stats)
+	return stats
+}
+
+// Warnings returns the warnings Trino has reported for the query so far.
+func (r *Rows) Warnings() []QueryWarning {
+	return r.observer.Warnings()
+}
+
+// Close releases the stream. It is safe to call more than once.
+func (r *Rows) Close() error {
+	if !r.done {
+		r.done = true
+		r.end = time.Now()
+	}
+	r.stopCancel()
+	err := r.rows.Close()
+	discardConn(r.conn)
+	r.conn = nil
+	r.cancel()
+	return err
+}
+
+// queryer is the query method shared by *sql.DB and *sql.Conn.
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// discardConn closes conn's underlying driver connection rather than
+// returning it to the pool. A nil conn is ignored.
+func discardConn(conn *sql.Conn) {
+	if conn == nil {
+		return
+	}
+	_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+	_ = conn.Close()
+}
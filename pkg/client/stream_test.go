@@ -0,0 +1,214 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func newStreamMock(t *testing.T) (*Client, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return NewWithDB(db, Config{Host: "localhost", User: "test", Timeout: 30 * time.Second}), mock
+}
+
+func TestClient_QueryStream(t *testing.T) {
+	c, mock := newStreamMock(t)
+	mock.ExpectQuery("SELECT").WillReturnRows(
+		sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Alice").AddRow(2, []byte(`{"a":1}`)).AddRow(3, "Carol"),
+	)
+
+	rows, err := c.QueryStream(context.Background(), "SELECT id, name FROM users", QueryOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	cols := rows.Columns()
+	if len(cols) != 2 || cols[0].Name != "id" || cols[1].Name != "name" {
+		t.Fatalf("unexpected columns: %+v", cols)
+	}
+
+	if !rows.Next() {
+		t.Fatalf("expected first row, err: %v", rows.Err())
+	}
+	if row := rows.Row(); row["name"] != "Alice" {
+		t.Errorf("unexpected row: %v", row)
+	}
+	if !rows.Next() {
+		t.Fatal("expected second row")
+	}
+	if values := rows.Values(); len(values) != 2 {
+		t.Errorf("unexpected values: %v", values)
+	} else if m, ok := values[1].(map[string]any); !ok || m["a"] != float64(1) {
+		t.Errorf("expected JSON bytes to be decoded, got %#v", values[1])
+	}
+
+	var rest []map[string]any
+	for row := range rows.All() {
+		rest = append(rest, row)
+	}
+	if len(rest) != 1 || rest[0]["name"] != "Carol" {
+		t.Errorf("unexpected remaining rows: %v", rest)
+	}
+	if err := rows.Err(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	stats := rows.Stats()
+	if stats.RowCount != 3 || stats.Truncated || stats.LimitApplied != 0 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+	if rows.Next() {
+		t.Error("expected Next to stay false after the stream ends")
+	}
+	if err := rows.Close(); err != nil {
+		t.Errorf("Close error: %v", err)
+	}
+	if err := rows.Close(); err != nil {
+		t.Errorf("second Close error: %v", err)
+	}
+}
+
+func TestClient_QueryStream_Limit(t *testing.T) {
+	tests := []struct {
+		name          string
+		rows          int
+		limit         int
+		wantCount     int
+		wantTruncated bool
+	}{
+		{name: "truncated", rows: 3, limit: 2, wantCount: 2, wantTruncated: true},
+		{name: "exactly at limit", rows: 2, limit: 2, wantCount: 2, wantTruncated: false},
+		{name: "no limit", rows: 5, limit: 0, wantCount: 5, wantTruncated: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, mock := newStreamMock(t)
+			result := sqlmock.NewRows([]string{"id"})
+			for i := range tt.rows {
+				result.AddRow(i)
+			}
+			mock.ExpectQuery("SELECT").WillReturnRows(result)
+
+			rows, err := c.QueryStream(context.Background(), "SELECT id FROM t", QueryOptions{Limit: tt.limit})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer func() { _ = rows.Close() }()
+
+			count := 0
+			for rows.Next() {
+				count++
+			}
+			if count != tt.wantCount {
+				t.Errorf("expected %d rows, got %d", tt.wantCount, count)
+			}
+			if stats := rows.Stats(); stats.Truncated != tt.wantTruncated || stats.RowCount != tt.wantCount {
+				t.Errorf("unexpected stats: %+v", stats)
+			}
+		})
+	}
+}
+
+func TestClient_QueryStream_Errors(t *testing.T) {
+	t.Run("query error", func(t *testing.T) {
+		c, mock := newStreamMock(t)
+		mock.ExpectQuery("SELECT").WillReturnError(errors.New("syntax error"))
+
+		if _, err := c.QueryStream(context.Background(), "SELECT", QueryOptions{}); err == nil {
+			t.Error("expected error")
+		}
+	})
+
+	t.Run("row error", func(t *testing.T) {
+		c, mock := newStreamMock(t)
+		mock.ExpectQuery("SELECT").WillReturnRows(
+			sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2).RowError(1, errors.New("worker lost")),
+		)
+
+		rows, err := c.QueryStream(context.Background(), "SELECT id FROM t", QueryOptions{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer func() { _ = rows.Close() }()
+
+		count := 0
+		for range rows.All() {
+			count++
+		}
+		if count != 1 {
+			t.Errorf("expected 1 row before the error, got %d", count)
+		}
+		if err := rows.Err(); err == nil {
+			t.Error("expected iteration error")
+		}
+	})
+
+	t.Run("query returns row error", func(t *testing.T) {
+		c, mock := newStreamMock(t)
+		mock.ExpectQuery("SELECT").WillReturnRows(
+			sqlmock.NewRows([]string{"id"}).AddRow(1).RowError(0, errors.New("worker lost")),
+		)
+
+		if _, err := c.Query(context.Background(), "SELECT id FROM t", DefaultQueryOptions()); err == nil {
+			t.Error("expected Query to surface the iteration error")
+		}
+	})
+}
+
+func TestClient_QueryStream_Break(t *testing.T) {
+	c, mock := newStreamMock(t)
+	mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2).AddRow(3))
+
+	rows, err := c.QueryStream(context.Background(), "SELECT id FROM t", QueryOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for range rows.All() {
+		break
+	}
+	if stats := rows.Stats(); stats.RowCount != 1 {
+		t.Errorf("expected 1 row consumed, got %d", stats.RowCount)
+	}
+	if err := rows.Close(); err != nil {
+		t.Errorf("Close error: %v", err)
+	}
+	if rows.Next() {
+		t.Error("expected Next to be false after Close")
+	}
+}
+
+func TestClient_QueryStream_Driver(t *testing.T) {
+	f := newFakeTrino(t)
+	c := newFakeTrinoClient(t, f)
+
+	rows, err := c.QueryStream(context.Background(), "SELECT 'ok' AS value", QueryOptions{})
+	if err != nil {
+		t.Fatalf("QueryStream() error: %v", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	if cols := rows.Columns(); len(cols) != 1 || cols[0].Name != "value" {
+		t.Fatalf("unexpected columns: %+v", cols)
+	}
+	var got []map[string]any
+	for row := range rows.All() {
+		got = append(got, row)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("iteration error: %v", err)
+	}
+	if len(got) != 1 || got[0]["value"] != "ok" {
+		t.Errorf("unexpected rows: %v", got)
+	}
+}
@@ -0,0 +1,113 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// TLSConfig customizes TLS for HTTPS connections to Trino, e.g. to trust an
+// internal CA. The zero value verifies the server against the system roots.
+type TLSConfig struct {
+	// CAFile is a PEM bundle of CA certificates to trust in addition to the
+	// system roots.
+	CAFile string `json:"ca_file,omitempty" yaml:"ca_file,omitempty"`
+
+	// CertFile and KeyFile are a PEM client certificate and key presented in
+	// the handshake, e.g. to an mTLS proxy in front of Trino. To authenticate
+	// to Trino itself with a certificate, use AuthConfig's certificate method.
+	CertFile string `json:"cert_file,omitempty" yaml:"cert_file,omitempty"`
+	KeyFile  string `json:"key_file,omitempty" yaml:"key_file,omitempty"`
+
+	// ServerName overrides the host name the server certificate is verified
+	// against, for coordinators reached through an IP or an alias.
+	ServerName string `json:"server_name,omitempty" yaml:"server_name,omitempty"`
+
+	// MinVersion is the minimum TLS version: "1.2" or "1.3". Default: "1.2".
+	MinVersion string `json:"min_version,omitempty" yaml:"min_version,omitempty"`
+}
+
+// tlsVersions maps TLSConfig.MinVersion values to crypto/tls versions.
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// validate checks the settings that can be checked without reading files.
+func (t TLSConfig) validate() error {
+	if (t.CertFile == "") != (t.KeyFile == "") {
+		return fmt.Errorf("client certificate and key must be set together")
+	}
+	if _, ok := tlsVersions[t.MinVersion]; t.MinVersion != "" && !ok {
+		return fmt.Errorf("unsupported minimum TLS version %q (use 1.2 or 1.3)", t.MinVersion)
+	}
+	return nil
+}
+
+// ApplyTLSEnv overrides TLS settings from environment variables:
+//   - TRINO_SSL_CA_FILE: PEM bundle of CA certificates to trust
+//   - TRINO_SSL_CERT_FILE, TRINO_SSL_KEY_FILE: client certificate and key
+//   - TRINO_SSL_SERVER_NAME: host name to verify the server certificate against
+//   - TRINO_SSL_MIN_VERSION: minimum TLS version (1.2 or 1.3)
+func ApplyTLSEnv(t TLSConfig) TLSConfig {
+	setString := func(dst *string, key string) {
+		if v := os.Getenv(key); v != "" {
+			*dst = v
+		}
+	}
+
+	setString(&t.CAFile, "TRINO_SSL_CA_FILE")
+	setString(&t.CertFile, "TRINO_SSL_CERT_FILE")
+	setString(&t.KeyFile, "TRINO_SSL_KEY_FILE")
+	setString(&t.ServerName, "TRINO_SSL_SERVER_NAME")
+	setString(&t.MinVersion, "TRINO_SSL_MIN_VERSION")
+	return t
+}
+
+// newTLSConfig builds the TLS configuration for cfg's HTTPS connections,
+// loading the CA bundle and client certificate it names.
+func newTLSConfig(cfg Config) (*tls.Config, error) {
+	t := cfg.TLS
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: t.ServerName,
+	}
+	if v, ok := tlsVersions[t.MinVersion]; ok {
+		tlsConfig.MinVersion = v
+	}
+	if cfg.SSL && !cfg.SSLVerify {
+		//nolint:gosec // G402: verification is disabled explicitly via TRINO_SSL_VERIFY=false
+		tlsConfig.InsecureSkipVerify = true
+	}
+
+	if t.CAFile != "" {
+		pem, err := os.ReadFile(t.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA file %s", t.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	certFile, keyFile := t.CertFile, t.KeyFile
+	if cfg.Auth.ResolvedMethod(cfg.Password) == AuthCertificate {
+		certFile, keyFile = cfg.Auth.ClientCert, cfg.Auth.ClientKey
+	}
+	if certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
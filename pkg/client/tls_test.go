@@ -0,0 +1,253 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newFakeTrinoTLS starts a fakeTrino over HTTPS; configure adjusts the
+// server's TLS settings before it starts.
+func newFakeTrinoTLS(t *testing.T, configure func(*tls.Config)) *fakeTrino {
+	t.Helper()
+	f := &fakeTrino{}
+	f.Server = httptest.NewUnstartedServer(http.HandlerFunc(f.serve))
+	f.Config.ErrorLog = log.New(io.Discard, "", 0) // failed handshakes are expected
+	f.TLS = &tls.Config{MinVersion: tls.VersionTLS12}
+	if configure != nil {
+		configure(f.TLS)
+	}
+	f.StartTLS()
+	t.Cleanup(f.Close)
+	return f
+}
+
+// writeCAFile writes srv's certificate as a PEM CA bundle and returns its path.
+func writeCAFile(t *testing.T, srv *httptest.Server) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// writeClientCert writes a self-signed client certificate and key and
+// returns their paths.
+func writeClientCert(t *testing.T) (certFile, keyFile string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "mcp-trino"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	certFile = filepath.Join(dir, "client.crt")
+	keyFile = filepath.Join(dir, "client.key")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
+
+func TestClient_TLS(t *testing.T) {
+	requireClientCert := func(c *tls.Config) { c.ClientAuth = tls.RequireAnyClientCert }
+	maxTLS12 := func(c *tls.Config) { c.MaxVersion = tls.VersionTLS12 }
+
+	tests := []struct {
+		name      string
+		configure func(*tls.Config)
+		tls       func(caFile, certFile, keyFile string) TLSConfig
+		auth      func(certFile, keyFile string) AuthConfig
+		wantErr   string
+	}{
+		{
+			name:    "system roots reject internal CA",
+			tls:     func(_, _, _ string) TLSConfig { return TLSConfig{} },
+			wantErr: "certificate",
+		},
+		{
+			name: "CA bundle",
+			tls:  func(ca, _, _ string) TLSConfig { return TLSConfig{CAFile: ca} },
+		},
+		{
+			name: "server name override",
+			tls:  func(ca, _, _ string) TLSConfig { return TLSConfig{CAFile: ca, ServerName: "example.com"} },
+		},
+		{
+			name:    "server name mismatch",
+			tls:     func(ca, _, _ string) TLSConfig { return TLSConfig{CAFile: ca, ServerName: "trino.internal"} },
+			wantErr: "trino.internal",
+		},
+		{
+			name:      "minimum version not met",
+			configure: maxTLS12,
+			tls:       func(ca, _, _ string) TLSConfig { return TLSConfig{CAFile: ca, MinVersion: "1.3"} },
+			wantErr:   "version",
+		},
+		{
+			name:      "client certificate required",
+			configure: requireClientCert,
+			tls:       func(ca, _, _ string) TLSConfig { return TLSConfig{CAFile: ca} },
+			wantErr:   "certificate",
+		},
+		{
+			name:      "client certificate",
+			configure: requireClientCert,
+			tls: func(ca, cert, key string) TLSConfig {
+				return TLSConfig{CAFile: ca, CertFile: cert, KeyFile: key}
+			},
+		},
+		{
+			name:      "certificate auth",
+			configure: requireClientCert,
+			tls:       func(ca, _, _ string) TLSConfig { return TLSConfig{CAFile: ca} },
+			auth:      func(cert, key string) AuthConfig { return AuthConfig{ClientCert: cert, ClientKey: key} },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeTrinoTLS(t, tt.configure)
+			certFile, keyFile := writeClientCert(t)
+
+			cfg := testServerConfig(t, f.Server)
+			cfg.SSL = true
+			cfg.SSLVerify = true
+			cfg.TLS = tt.tls(writeCAFile(t, f.Server), certFile, keyFile)
+			if tt.auth != nil {
+				cfg.Auth = tt.auth(certFile, keyFile)
+			}
+
+			c, err := New(cfg)
+			if err != nil {
+				t.Fatalf("New() error: %v", err)
+			}
+			defer func() { _ = c.Close() }()
+
+			_, err = c.Query(context.Background(), "SELECT 'ok' AS value", DefaultQueryOptions())
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Query() error: %v", err)
+				}
+				if err := c.CancelQuery(context.Background(), fakeQueryID); err != nil {
+					t.Errorf("CancelQuery() error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Query() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTLSConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		tls     TLSConfig
+		wantErr string
+	}{
+		{name: "zero value"},
+		{name: "tls 1.3", tls: TLSConfig{MinVersion: "1.3"}},
+		{name: "cert without key", tls: TLSConfig{CertFile: "client.crt"}, wantErr: "must be set together"},
+		{name: "key without cert", tls: TLSConfig{KeyFile: "client.key"}, wantErr: "must be set together"},
+		{name: "unsupported version", tls: TLSConfig{MinVersion: "1.0"}, wantErr: `unsupported minimum TLS version "1.0"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Config{Host: "localhost", Port: 8443, User: "admin", TLS: tt.tls}
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNew_InvalidCAFile(t *testing.T) {
+	empty := filepath.Join(t.TempDir(), "empty.pem")
+	if err := os.WriteFile(empty, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		caFile  string
+		wantErr string
+	}{
+		{name: "missing", caFile: filepath.Join(t.TempDir(), "missing.pem"), wantErr: "failed to read CA file"},
+		{name: "no certificates", caFile: empty, wantErr: "no certificates found in CA file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.User = "admin"
+			cfg.TLS.CAFile = tt.caFile
+
+			_, err := New(cfg)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("New() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestApplyTLSEnv(t *testing.T) {
+	t.Setenv("TRINO_SSL_CA_FILE", "/etc/ssl/internal-ca.pem")
+	t.Setenv("TRINO_SSL_SERVER_NAME", "trino.internal")
+	t.Setenv("TRINO_SSL_MIN_VERSION", "1.3")
+
+	got := ApplyTLSEnv(TLSConfig{CertFile: "kept.crt"})
+	want := TLSConfig{
+		CAFile:     "/etc/ssl/internal-ca.pem",
+		CertFile:   "kept.crt",
+		ServerName: "trino.internal",
+		MinVersion: "1.3",
+	}
+	if got != want {
+		t.Errorf("ApplyTLSEnv() = %+v, want %+v", got, want)
+	}
+}
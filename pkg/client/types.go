@@ -0,0 +1,410 @@
+package client
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/txn2/mcp-trino/pkg/sqlparse"
+)
+
+// trinoType is a parsed Trino type such as decimal(10,2), timestamp(6) with
+// time zone, or map(varchar, array(bigint)). It drives the conversion of
+// result values, so each value is rendered the way its type needs rather
+// than however the driver happened to decode it.
+type trinoType struct {
+	name      string       // base type in lower case, e.g. "timestamp with time zone"
+	precision int          // fractional second digits of time and timestamp types
+	elems     []*trinoType // array element, map key and value, or row field types
+	fields    []string     // row field names; empty for anonymous fields
+}
+
+// defaultTimePrecision is the precision of time and timestamp types whose
+// type string does not state one.
+const defaultTimePrecision = 3
+
+// maxSafeInteger is the largest integer that a float64, and so a JSON
+// number as most clients read it, represents exactly.
+const maxSafeInteger = 1<<53 - 1
+
+// maxSafeDecimalDigits is the number of significant digits a decimal can
+// have and still survive a round trip through float64.
+const maxSafeDecimalDigits = 15
+
+// parseTrinoType parses a Trino type string as reported in a statement
+// response's columns, e.g. "row(id bigint, tags array(varchar))".
+func parseTrinoType(s string) *trinoType {
+	s = strings.TrimSpace(s)
+	open := strings.IndexByte(s, '(')
+	if open < 0 {
+		return &trinoType{name: strings.ToLower(s), precision: defaultTimePrecision}
+	}
+	end := matchingParen(s, open)
+	if end < 0 {
+		return &trinoType{name: strings.ToLower(s[:open]), precision: defaultTimePrecision}
+	}
+
+	// The parameters of time types come before "with time zone"
+	name := strings.ToLower(strings.TrimSpace(s[:open]))
+	if suffix := strings.TrimSpace(s[end+1:]); suffix != "" {
+		name += " " + strings.ToLower(suffix)
+	}
+	t := &trinoType{name: name, precision: defaultTimePrecision}
+
+	args := splitTypeArgs(s[open+1 : end])
+	switch name {
+	case "array", "map":
+		for _, arg := range args {
+			t.elems = append(t.elems, parseTrinoType(arg))
+		}
+	case "row":
+		for _, arg := range args {
+			field, typ := splitRowField(arg)
+			t.fields = append(t.fields, field)
+			t.elems = append(t.elems, parseTrinoType(typ))
+		}
+	case "time", "time with time zone", "timestamp", "timestamp with time zone":
+		if len(args) == 1 {
+			if p, err := strconv.Atoi(strings.TrimSpace(args[0])); err == nil {
+				t.precision = p
+			}
+		}
+	}
+	return t
+}
+
+// matchingParen returns the index of the parenthesis closing the one at
+// open, or -1. Parentheses inside quoted field names are ignored.
+func matchingParen(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			n := sqlparse.QuotedLen(s[i:])
+			if n < 0 {
+				return -1
+			}
+			i += n - 1
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// splitTypeArgs splits type parameters on top-level commas.
+func splitTypeArgs(s string) []string {
+	var args []string
+	depth, start := 0, 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			if n := sqlparse.QuotedLen(s[i:]); n > 0 {
+				i += n - 1
+			}
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				args = append(args, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(args, s[start:])
+}
+
+// splitRowField splits a row field into its name and type. Anonymous
+// fields, such as the fields of row(bigint, varchar), have no name.
+func splitRowField(s string) (name, typ string) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, `"`) {
+		if n := sqlparse.QuotedLen(s); n > 0 {
+			return strings.ReplaceAll(s[1:n-1], `""`, `"`), s[n:]
+		}
+	}
+
+	word, rest, ok := strings.Cut(s, " ")
+	rest = strings.TrimSpace(rest)
+	lowerRest := strings.ToLower(rest)
+	if !ok || strings.Contains(word, "(") || strings.EqualFold(word, "interval") ||
+		strings.HasPrefix(lowerRest, "with ") {
+		return "", s
+	}
+	return word, rest
+}
+
+// convert converts a value of type t, as returned by the driver or nested
+// inside an array, map, or row, to a JSON-friendly value:
+//   - integers beyond ±2^53 and decimals with more than 15 significant
+//     digits become strings, so no precision is lost; other decimals stay
+//     numbers with their exact digits
+//   - NaN and infinite doubles become "NaN", "Infinity", and "-Infinity"
+//   - varbinary becomes base64, and json values are decoded
+//   - dates, times, and timestamps use Trino's literal format with the
+//     type's precision and, where the type has one, the time zone
+//   - arrays, maps, and rows stay structured; rows become objects keyed
+//     by field name
+//
+// A nil type falls back to convertValue.
+func (t *trinoType) convert(v any) any {
+	if v == nil {
+		return nil
+	}
+	if t == nil {
+		return convertValue(v)
+	}
+
+	switch t.name {
+	case "tinyint", "smallint", "integer", "bigint":
+		return convertInteger(v)
+	case "real", "double":
+		return convertFloat(v)
+	case "decimal":
+		return convertDecimal(v)
+	case "varbinary":
+		if b, ok := v.([]byte); ok {
+			return base64.StdEncoding.EncodeToString(b)
+		}
+		return v // nested values arrive base64 encoded
+	case "json":
+		return convertJSON(v)
+	case "date", "time", "time with time zone", "timestamp", "timestamp with time zone":
+		if ts, ok := v.(time.Time); ok {
+			return t.formatTime(ts)
+		}
+		return v // nested values arrive in Trino's literal format
+	case "array":
+		return t.convertArray(v)
+	case "map":
+		return t.convertMap(v)
+	case "row":
+		return t.convertRow(v)
+	default:
+		if b, ok := v.([]byte); ok {
+			return string(b)
+		}
+		return v
+	}
+}
+
+// elem returns the i'th element type, or nil if the type string did not
+// name one.
+func (t *trinoType) elem(i int) *trinoType {
+	if i < len(t.elems) {
+		return t.elems[i]
+	}
+	return nil
+}
+
+func (t *trinoType) convertArray(v any) any {
+	items, ok := v.([]any)
+	if !ok {
+		return convertValue(v)
+	}
+	elem := t.elem(0)
+	out := make([]any, len(items))
+	for i, item := range items {
+		out[i] = elem.convert(item)
+	}
+	return out
+}
+
+func (t *trinoType) convertMap(v any) any {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return convertValue(v)
+	}
+	elem := t.elem(1)
+	out := make(map[string]any, len(m))
+	for k, item := range m {
+		out[k] = elem.convert(item)
+	}
+	return out
+}
+
+// convertRow converts a row to an object. Anonymous fields are named like
+// Trino's unnamed columns: _col0, _col1, and so on.
+func (t *trinoType) convertRow(v any) any {
+	items, ok := v.([]any)
+	if !ok {
+		return convertValue(v)
+	}
+	out := make(map[string]any, len(items))
+	for i, item := range items {
+		name := ""
+		if i < len(t.fields) {
+			name = t.fields[i]
+		}
+		if name == "" {
+			name = fmt.Sprintf("_col%d", i)
+		}
+		out[name] = t.elem(i).convert(item)
+	}
+	return out
+}
+
+// formatTime renders a date, time, or timestamp in Trino's literal format,
+// e.g. 2024-01-15 10:30:00.123 America/New_York.
+func (t *trinoType) formatTime(ts time.Time) string {
+	switch t.name {
+	case "date":
+		return ts.Format(time.DateOnly)
+	case "time":
+		return ts.Format(time.TimeOnly) + fraction(ts, t.precision)
+	case "time with time zone":
+		return ts.Format(time.TimeOnly) + fraction(ts, t.precision) + ts.Format("-07:00")
+	case "timestamp":
+		return ts.Format(time.DateTime) + fraction(ts, t.precision)
+	default:
+		return ts.Format(time.DateTime) + fraction(ts, t.precision) + " " + zoneName(ts)
+	}
+}
+
+// fraction returns the fractional seconds of ts to the given number of
+// digits, including the leading dot.
+func fraction(ts time.Time, precision int) string {
+	if precision <= 0 {
+		return ""
+	}
+	digits := fmt.Sprintf("%09d", ts.Nanosecond())
+	if precision <= len(digits) {
+		return "." + digits[:precision]
+	}
+	return "." + digits + strings.Repeat("0", precision-len(digits))
+}
+
+// zoneName returns the time zone of ts: its region name, such as
+// America/New_York, or its offset, such as +05:30, for fixed-offset zones.
+// The driver parses an offset that matches the local zone into time.Local,
+// whose name says nothing about the value, so that is an offset too.
+func zoneName(ts time.Time) string {
+	if loc := ts.Location(); loc != time.Local && loc.String() != "" {
+		return loc.String()
+	}
+	return ts.Format("-07:00")
+}
+
+func convertInteger(v any) any {
+	switch n := v.(type) {
+	case int64:
+		return safeInteger(n)
+	case json.Number:
+		if i, err := n.Int64(); err == nil {
+			return safeInteger(i)
+		}
+		return string(n)
+	}
+	return v
+}
+
+// safeInteger returns n, or n as a string if it is too large for a JSON
+// number to hold exactly.
+func safeInteger(n int64) any {
+	if n > maxSafeInteger || n < -maxSafeInteger {
+		return strconv.FormatInt(n, 10)
+	}
+	return n
+}
+
+func convertFloat(v any) any {
+	switch f := v.(type) {
+	case float64:
+		return finiteFloat(f)
+	case json.Number:
+		parsed, err := f.Float64()
+		if err != nil {
+			return string(f)
+		}
+		return finiteFloat(parsed)
+	}
+	return v // nested NaN and infinities arrive as strings
+}
+
+// finiteFloat returns f, or its name if JSON cannot represent it.
+func finiteFloat(f float64) any {
+	switch {
+	case math.IsNaN(f):
+		return "NaN"
+	case math.IsInf(f, 1):
+		return "Infinity"
+	case math.IsInf(f, -1):
+		return "-Infinity"
+	}
+	return f
+}
+
+// convertDecimal returns a decimal as a JSON number with its exact digits
+// when a float64 can hold it, and as a string otherwise.
+func convertDecimal(v any) any {
+	var s string
+	switch d := v.(type) {
+	case string:
+		s = d
+	case json.Number:
+		s = string(d)
+	default:
+		return v
+	}
+
+	digits, ok := decimalDigits(s)
+	if !ok || digits > maxSafeDecimalDigits {
+		return s
+	}
+	return json.Number(s)
+}
+
+// decimalDigits returns the number of significant digits in a plain
+// decimal literal such as -0.0125, and false if s is not one.
+func decimalDigits(s string) (int, bool) {
+	s = strings.TrimPrefix(s, "-")
+	intPart, fracPart, hasDot := strings.Cut(s, ".")
+	if intPart == "" || (hasDot && fracPart == "") {
+		return 0, false
+	}
+	all := intPart + fracPart
+	for i := 0; i < len(all); i++ {
+		if !isDigit(all[i]) {
+			return 0, false
+		}
+	}
+	return len(strings.TrimLeft(all, "0")), true
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// convertJSON decodes a json value so it is embedded as structured JSON
+// rather than as a string. Numbers keep their exact digits.
+func convertJSON(v any) any {
+	var raw []byte
+	switch s := v.(type) {
+	case string:
+		raw = []byte(s)
+	case []byte:
+		raw = s
+	default:
+		return v
+	}
+
+	d := json.NewDecoder(bytes.NewReader(raw))
+	d.UseNumber()
+	var decoded any
+	if err := d.Decode(&decoded); err != nil || d.More() {
+		return string(raw)
+	}
+	return decoded
+}
@@ -0,0 +1,175 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files")
+
+func TestParseTrinoType(t *testing.T) {
+	tests := []struct {
+		in        string
+		name      string
+		precision int
+		elems     []string
+		fields    []string
+	}{
+		{in: "bigint", name: "bigint", precision: 3},
+		{in: "decimal(38,10)", name: "decimal", precision: 3},
+		{in: "varchar(20)", name: "varchar", precision: 3},
+		{in: "timestamp(6)", name: "timestamp", precision: 6},
+		{in: "timestamp(9) with time zone", name: "timestamp with time zone", precision: 9},
+		{in: "time with time zone", name: "time with time zone", precision: 3},
+		{in: "interval day to second", name: "interval day to second", precision: 3},
+		{in: "array(decimal(10,2))", name: "array", precision: 3, elems: []string{"decimal"}},
+		{in: "map(varchar, array(bigint))", name: "map", precision: 3, elems: []string{"varchar", "array"}},
+		{
+			in: "row(id bigint, ts timestamp(6) with time zone, \"a (b)\" varchar)", name: "row", precision: 3,
+			elems: []string{"bigint", "timestamp with time zone", "varchar"}, fields: []string{"id", "ts", "a (b)"},
+		},
+		{
+			in: "row(bigint, interval year to month, timestamp(3) with time zone)", name: "row", precision: 3,
+			elems: []string{"bigint", "interval year to month", "timestamp with time zone"}, fields: []string{"", "", ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got := parseTrinoType(tt.in)
+			if got.name != tt.name || got.precision != tt.precision {
+				t.Errorf("parseTrinoType(%q) = %q precision %d, want %q precision %d",
+					tt.in, got.name, got.precision, tt.name, tt.precision)
+			}
+			var elems []string
+			for _, e := range got.elems {
+				elems = append(elems, e.name)
+			}
+			if !reflect.DeepEqual(elems, tt.elems) {
+				t.Errorf("elems = %q, want %q", elems, tt.elems)
+			}
+			if !reflect.DeepEqual(got.fields, tt.fields) {
+				t.Errorf("fields = %q, want %q", got.fields, tt.fields)
+			}
+		})
+	}
+}
+
+// typeCase is a column of testdata/types.json: a Trino type and a value as
+// the statement protocol encodes it.
+type typeCase struct {
+	Column string          `json:"column"`
+	Type   string          `json:"type"`
+	Value  json.RawMessage `json:"value"`
+}
+
+// TestClient_Query_TypeConversion runs a one-row result with a column of
+// every Trino type through the driver and compares the converted row with
+// testdata/types.golden.json. Run with -update to rewrite the golden file.
+func TestClient_Query_TypeConversion(t *testing.T) {
+	input, err := os.ReadFile(filepath.Join("testdata", "types.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cases []typeCase
+	if err := json.Unmarshal(input, &cases); err != nil {
+		t.Fatal(err)
+	}
+
+	columns := make([]map[string]any, len(cases))
+	row := make([]json.RawMessage, len(cases))
+	for i, tc := range cases {
+		columns[i] = map[string]any{
+			"name":          tc.Column,
+			"type":          tc.Type,
+			"typeSignature": typeSignatureOf(parseTrinoType(tc.Type)),
+		}
+		row[i] = tc.Value
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodPost:
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"id":      fakeQueryID,
+				"nextUri": "http://" + r.Host + "/v1/statement/executing/" + fakeQueryID + "/1",
+				"stats":   map[string]any{"state": "QUEUED"},
+			})
+			return
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"id":      fakeQueryID,
+			"columns": columns,
+			"data":    [][]json.RawMessage{row},
+			"stats":   map[string]any{"state": "FINISHED"},
+		})
+	}))
+	defer srv.Close()
+
+	c, err := New(testServerConfig(t, srv))
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	defer func() { _ = c.Close() }()
+
+	result, err := c.Query(context.Background(), "SELECT * FROM types", QueryOptions{DisableLimitPushdown: true})
+	if err != nil {
+		t.Fatalf("Query() error: %v", err)
+	}
+	if len(result.Rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(result.Rows))
+	}
+	got, err := json.MarshalIndent(result.Rows[0], "", "  ")
+	if err != nil {
+		t.Fatalf("marshaling row: %v", err)
+	}
+	got = append(got, '\n')
+
+	golden := filepath.Join("testdata", "types.golden.json")
+	if *update {
+		if err := os.WriteFile(golden, got, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("converted row does not match %s:\ngot:\n%s\nwant:\n%s", golden, got, want)
+	}
+}
+
+// typeSignatureOf builds the statement protocol's typeSignature for t,
+// with the arguments the driver reads: element types, row fields, and time
+// precision.
+func typeSignatureOf(t *trinoType) map[string]any {
+	args := []any{}
+	switch t.name {
+	case "array", "map":
+		for _, e := range t.elems {
+			args = append(args, map[string]any{"kind": "TYPE", "value": typeSignatureOf(e)})
+		}
+	case "row":
+		for i, e := range t.elems {
+			args = append(args, map[string]any{"kind": "NAMED_TYPE", "value": map[string]any{
+				"fieldName":     map[string]any{"name": t.fields[i]},
+				"typeSignature": typeSignatureOf(e),
+			}})
+		}
+	case "time", "time with time zone", "timestamp", "timestamp with time zone":
+		args = append(args, map[string]any{"kind": "LONG", "value": t.precision})
+	}
+	return map[string]any{"rawType": t.name, "arguments": args}
+}
@@ -0,0 +1,373 @@
+package extensions
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/txn2/mcp-trino/pkg/multiserver"
+	"github.com/txn2/mcp-trino/pkg/sqlparse"
+	"github.com/txn2/mcp-trino/pkg/tools"
+)
+
+// ErrAccessPolicy is returned when SQL or a schema tool reaches a catalog,
+// schema, or table that the access policy does not allow.
+var ErrAccessPolicy = errors.New("blocked by the access policy")
+
+// AccessRules allow and deny catalogs, schemas, and tables on a connection.
+//
+// A pattern names a catalog, catalog.schema, or catalog.schema.table, and
+// covers that object and everything in it. Each part may use the wildcards
+// of path.Match, e.g. "hive.pii_*". Matching ignores case.
+//
+// An object covered by a Deny pattern is denied. If there are Allow
+// patterns, only objects they cover are allowed, along with the catalogs
+// and schemas leading to them so those can still be browsed.
+type AccessRules struct {
+	Allow []string `json:"allow,omitempty" yaml:"allow,omitempty"`
+	Deny  []string `json:"deny,omitempty" yaml:"deny,omitempty"`
+}
+
+// AccessPolicy restricts the catalogs, schemas, and tables tools can reach,
+// with rules per connection. As a query interceptor it checks every table
+// the SQL references, and as an object filter it hides forbidden objects
+// from trino_browse and refuses them in trino_describe_table. Connections
+// without rules are not restricted.
+type AccessPolicy struct {
+	defaultConnection string
+	connections       map[string]*connectionAccess
+}
+
+// connectionAccess holds the compiled rules of a connection.
+type connectionAccess struct {
+	name string
+
+	// catalog and schema are the connection's defaults, which resolve
+	// unqualified names when the tool call sets none
+	catalog string
+	schema  string
+
+	allow []accessPattern
+	deny  []accessPattern
+}
+
+// accessPattern is a rule pattern split into its lower-case parts.
+type accessPattern struct {
+	text  string
+	parts []string
+}
+
+// NewAccessPolicy creates an access policy from rules keyed by connection
+// name. The connections' default catalogs and schemas in cfg resolve
+// unqualified table names in SQL. It returns an error for an unknown
+// connection or a malformed pattern.
+func NewAccessPolicy(cfg multiserver.Config, rules map[string]AccessRules) (*AccessPolicy, error) {
+	p := &AccessPolicy{
+		defaultConnection: cfg.Default,
+		connections:       make(map[string]*connectionAccess, len(rules)),
+	}
+	for name, r := range rules {
+		if name == "" {
+			name = cfg.Default
+		}
+		clientCfg, err := cfg.ClientConfig(name)
+		if err != nil {
+			return nil, fmt.Errorf("access rules: %w", err)
+		}
+		conn := &connectionAccess{
+			name:    name,
+			catalog: strings.ToLower(clientCfg.Catalog),
+			schema:  strings.ToLower(clientCfg.Schema),
+		}
+		if conn.allow, err = compileAccessPatterns(r.Allow); err != nil {
+			return nil, fmt.Errorf("access rules for %q: %w", name, err)
+		}
+		if conn.deny, err = compileAccessPatterns(r.Deny); err != nil {
+			return nil, fmt.Errorf("access rules for %q: %w", name, err)
+		}
+		p.connections[name] = conn
+	}
+	return p, nil
+}
+
+func compileAccessPatterns(patterns []string) ([]accessPattern, error) {
+	compiled := make([]accessPattern, 0, len(patterns))
+	for _, text := range patterns {
+		parts := strings.Split(strings.ToLower(strings.TrimSpace(text)), ".")
+		if len(parts) > 3 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid pattern %q: must be catalog, catalog.schema, or catalog.schema.table", text)
+		}
+		for _, part := range parts {
+			if _, err := path.Match(part, ""); part == "" || err != nil {
+				return nil, fmt.Errorf("invalid pattern %q", text)
+			}
+		}
+		compiled = append(compiled, accessPattern{text: text, parts: parts})
+	}
+	return compiled, nil
+}
+
+// Intercept checks every table the SQL references, and the catalogs and
+// schemas listed by SHOW SCHEMAS and SHOW TABLES or named by USE, against
+// the rules of the tool call's connection. Unqualified names resolve against the
+// catalog and schema of the tool call, or else the connection's defaults.
+//
+// A table function, which can read anything in its catalog, for instance
+// with a query it passes through, needs its whole catalog allowed.
+// Statements whose tables cannot be checked are rejected: EXECUTE,
+// EXECUTE IMMEDIATE, PREPARE, CALL, and statements of unknown kind.
+func (p *AccessPolicy) Intercept(ctx context.Context, sql string, _ tools.ToolName) (string, error) {
+	conn := p.connection(tools.GetConnection(ctx))
+	if conn == nil {
+		return sql, nil
+	}
+	catalog, schema := conn.defaults(ctx)
+
+	statements, _ := sqlparse.Classify(sql) // Trino rejects an unterminated quote, so the text before it is all that matters
+	for _, s := range statements {
+		if err := conn.checkResolvable(s); err != nil {
+			return "", err
+		}
+		for _, table := range s.Tables {
+			if err := conn.checkTable(table, catalog, schema); err != nil {
+				return "", err
+			}
+		}
+		for _, function := range s.TableFunctions {
+			if err := conn.checkTableFunction(function, catalog, schema); err != nil {
+				return "", err
+			}
+		}
+		if err := conn.checkNamespace(s.SQL, catalog, schema); err != nil {
+			return "", err
+		}
+	}
+	return sql, nil
+}
+
+// CheckObject implements tools.ObjectFilter. An empty catalog or schema,
+// as trino_describe_table accepts, is the tool call's or else the
+// connection's default.
+func (p *AccessPolicy) CheckObject(ctx context.Context, catalog, schema, table string) error {
+	conn := p.connection(tools.GetConnection(ctx))
+	if conn == nil {
+		return nil
+	}
+	defaultCatalog, defaultSchema := conn.defaults(ctx)
+	parts := []string{cmp.Or(strings.ToLower(catalog), defaultCatalog)}
+	if schema != "" || table != "" {
+		parts = append(parts, cmp.Or(strings.ToLower(schema), defaultSchema))
+	}
+	if table != "" {
+		parts = append(parts, strings.ToLower(table))
+	}
+	if slices.Contains(parts, "") {
+		name := slices.DeleteFunc([]string{catalog, schema, table}, func(s string) bool { return s == "" })
+		return fmt.Errorf("%w: %s %q is not fully qualified and connection %q has no default catalog and schema",
+			ErrAccessPolicy, objectKind(parts), strings.Join(name, "."), conn.name)
+	}
+	return conn.check(parts)
+}
+
+// connection returns the rules of the named connection, or nil if it has
+// none. An empty name is the default connection.
+func (p *AccessPolicy) connection(name string) *connectionAccess {
+	if name == "" {
+		name = p.defaultConnection
+	}
+	return p.connections[name]
+}
+
+// defaults returns the lower-case catalog and schema that unqualified
+// names resolve against: the tool call's, or else the connection's.
+func (c *connectionAccess) defaults(ctx context.Context) (catalog, schema string) {
+	catalog, schema = tools.GetDefaultSchema(ctx)
+	return cmp.Or(strings.ToLower(catalog), c.catalog), cmp.Or(strings.ToLower(schema), c.schema)
+}
+
+// checkNamespace checks the catalog or schema a SHOW SCHEMAS, SHOW TABLES,
+// or USE statement names, or lists by default.
+func (c *connectionAccess) checkNamespace(sql, catalog, schema string) error {
+	parts := namespaceRef(sql, catalog, schema)
+	if parts == nil {
+		return nil
+	}
+	if slices.Contains(parts, "") {
+		return fmt.Errorf("%w: %s in %q is not fully qualified and connection %q has no default catalog and schema",
+			ErrAccessPolicy, objectKind(parts), strings.TrimSpace(sql), c.name)
+	}
+	return c.check(parts)
+}
+
+// checkTable checks a table name as written in SQL, qualifying it with the
+// given default catalog and schema.
+func (c *connectionAccess) checkTable(name, catalog, schema string) error {
+	parts := qualifyTable(strings.Split(strings.ToLower(name), "."), catalog, schema)
+	if len(parts) < 3 {
+		return fmt.Errorf("%w: table %q is not fully qualified and connection %q has no default catalog and schema",
+			ErrAccessPolicy, name, c.name)
+	}
+	return c.check(parts)
+}
+
+// checkResolvable rejects statements whose tables cannot be checked:
+// prepared statements, which run later by name, procedures, which name the
+// objects they work on in arguments, and what Classify cannot resolve.
+func (c *connectionAccess) checkResolvable(s sqlparse.Statement) error {
+	kind := s.Kind
+	if kind == sqlparse.KindExplain {
+		kind = s.Explained
+	}
+	if s.Unresolved || kind == sqlparse.KindPrepare || kind == sqlparse.KindCall {
+		return fmt.Errorf("%w: %s statements cannot be checked against the rules of connection %q",
+			ErrAccessPolicy, kind, c.name)
+	}
+	return nil
+}
+
+// checkTableFunction checks a table function as written in SQL, qualifying
+// it like a table. It can read anything in its catalog, so no deny pattern
+// may apply to the catalog or anything in it, and an allow pattern must
+// cover the whole catalog.
+func (c *connectionAccess) checkTableFunction(name, catalog, schema string) error {
+	parts := qualifyTable(strings.Split(strings.ToLower(name), "."), catalog, schema)
+	if len(parts) < 3 {
+		return fmt.Errorf("%w: table function %q is not fully qualified and connection %q has no default catalog and schema",
+			ErrAccessPolicy, name, c.name)
+	}
+	function := strings.Join(parts, ".")
+	for _, p := range c.deny {
+		if p.matches(parts[:1]) {
+			return fmt.Errorf("%w: table function %q on connection %q can read all of catalog %q, and deny pattern %q applies to it",
+				ErrAccessPolicy, function, c.name, parts[0], p.text)
+		}
+	}
+	if len(c.allow) == 0 {
+		return nil
+	}
+	for _, p := range c.allow {
+		if p.covers(parts[:1]) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: table function %q on connection %q can read all of catalog %q, which no allow pattern covers",
+		ErrAccessPolicy, function, c.name, parts[0])
+}
+
+// qualifyTable prefixes a table name, given as [[catalog.]schema.]table,
+// with the default catalog and schema it is missing. It returns the parts
+// unchanged if a needed default is empty.
+func qualifyTable(parts []string, catalog, schema string) []string {
+	switch {
+	case len(parts) == 1 && catalog != "" && schema != "":
+		return []string{catalog, schema, parts[0]}
+	case len(parts) == 2 && catalog != "":
+		return []string{catalog, parts[0], parts[1]}
+	}
+	return parts
+}
+
+// check returns an error if the object, given as its lower-case catalog,
+// schema, and table parts, is not allowed.
+func (c *connectionAccess) check(parts []string) error {
+	for _, p := range c.deny {
+		if p.covers(parts) {
+			return fmt.Errorf("%w: %s %q on connection %q matches deny pattern %q",
+				ErrAccessPolicy, objectKind(parts), strings.Join(parts, "."), c.name, p.text)
+		}
+	}
+	if len(c.allow) == 0 {
+		return nil
+	}
+	for _, p := range c.allow {
+		if p.covers(parts) || p.leadsTo(parts) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s %q on connection %q matches no allow pattern",
+		ErrAccessPolicy, objectKind(parts), strings.Join(parts, "."), c.name)
+}
+
+// covers reports whether the pattern names the object or something that
+// contains it.
+func (p accessPattern) covers(parts []string) bool {
+	return len(p.parts) <= len(parts) && p.matches(parts)
+}
+
+// leadsTo reports whether the object is a catalog or schema containing
+// what the pattern names.
+func (p accessPattern) leadsTo(parts []string) bool {
+	return len(parts) < len(p.parts) && p.matches(parts)
+}
+
+// matches reports whether the parts the pattern and object share match.
+func (p accessPattern) matches(parts []string) bool {
+	for i := range min(len(p.parts), len(parts)) {
+		if ok, _ := path.Match(p.parts[i], parts[i]); !ok { // patterns are checked by NewAccessPolicy
+			return false
+		}
+	}
+	return true
+}
+
+func objectKind(parts []string) string {
+	switch len(parts) {
+	case 1:
+		return "catalog"
+	case 2:
+		return "schema"
+	}
+	return "table"
+}
+
+// namespaceRef returns the catalog listed by SHOW SCHEMAS [FROM catalog],
+// or the schema listed by SHOW TABLES [FROM [catalog.]schema] or set by
+// USE [catalog.]schema, as lower-case parts. Parts the statement leaves
+// out are the given catalog and schema, which Trino uses for the session,
+// and are empty if there is none. It returns nil for other statements.
+func namespaceRef(sql, catalog, schema string) []string {
+	tokens, _ := sqlparse.Scan(sql) //nolint:errcheck // Classify already split off the statement
+	var i int
+	switch {
+	case len(tokens) > 1 && tokens[0].Is("SHOW") && (tokens[1].Is("SCHEMAS") || tokens[1].Is("TABLES")):
+		if len(tokens) == 2 || (!tokens[2].Is("FROM") && !tokens[2].Is("IN")) {
+			if tokens[1].Is("SCHEMAS") {
+				return []string{catalog}
+			}
+			return []string{catalog, schema}
+		}
+		i = 3
+	case len(tokens) > 1 && tokens[0].Is("USE"):
+		i = 1
+	default:
+		return nil
+	}
+
+	var parts []string
+	for ; i < len(tokens) && (tokens[i].Kind == sqlparse.Word || tokens[i].Kind == sqlparse.Quoted); i += 2 {
+		parts = append(parts, strings.ToLower(sqlparse.Identifier(tokens[i].Text)))
+		if i+1 >= len(tokens) || !tokens[i+1].IsSymbol(".") {
+			break
+		}
+	}
+
+	switch {
+	case len(parts) == 0 || len(parts) > 2:
+		return nil
+	case tokens[1].Is("SCHEMAS"):
+		return parts[:1]
+	case len(parts) == 1:
+		return []string{catalog, parts[0]}
+	}
+	return parts
+}
+
+// Verify AccessPolicy implements QueryInterceptor and ObjectFilter.
+var (
+	_ tools.QueryInterceptor = (*AccessPolicy)(nil)
+	_ tools.ObjectFilter     = (*AccessPolicy)(nil)
+)
@@ -24,6 +24,21 @@ type Config struct {
 
 	// Output destination for logging middleware and query log
 	LogOutput io.Writer
+
+	// AccessPolicy restricts catalogs, schemas, and tables per connection.
+	// It is built from the config file (see ServerConfig.AccessPolicy);
+	// nil means no restrictions.
+	AccessPolicy *AccessPolicy
+
+	// SensitiveColumns configures a SensitiveColumnGuard; nil disables it.
+	// The guard needs the semantic provider and Trino clients, so
+	// BuildToolkitOptions does not add it; the server builds it with
+	// NewSensitiveColumnGuard.
+	SensitiveColumns *SensitiveColumnConfig
+
+	// Redaction configures a Redactor; nil disables it. Like the sensitive
+	// column guard, the server builds it, with NewRedactor.
+	Redaction *RedactionConfig
 }
 
 // DefaultConfig returns a Config with safe defaults.
@@ -86,6 +101,12 @@ func BuildToolkitOptions(cfg Config) []tools.ToolkitOption {
 	if cfg.EnableReadOnly {
 		opts = append(opts, tools.WithQueryInterceptor(NewReadOnlyInterceptor()))
 	}
+	if cfg.AccessPolicy != nil {
+		opts = append(opts,
+			tools.WithQueryInterceptor(cfg.AccessPolicy),
+			tools.WithObjectFilter(cfg.AccessPolicy),
+		)
+	}
 	if cfg.EnableQueryLog {
 		opts = append(opts, tools.WithQueryInterceptor(NewQueryLogInterceptor(logOutput)))
 	}
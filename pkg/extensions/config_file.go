@@ -5,11 +5,17 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
 
+	"github.com/txn2/mcp-trino/pkg/auth"
 	"github.com/txn2/mcp-trino/pkg/client"
+	"github.com/txn2/mcp-trino/pkg/multiserver"
+	"github.com/txn2/mcp-trino/pkg/semantic"
+	"github.com/txn2/mcp-trino/pkg/semantic/providers/datahub"
+	"github.com/txn2/mcp-trino/pkg/semantic/providers/static"
 	"github.com/txn2/mcp-trino/pkg/tools"
 )
 
@@ -18,12 +24,14 @@ import (
 const defaultLocalhost = "localhost"
 
 // ServerConfig is a unified configuration structure for file-based config.
-// It combines client, toolkit, and extensions configuration in a single file.
+// It combines client, connections, toolkit, extensions, semantic, and
+// transport configuration in a single file.
 // Suitable for Kubernetes ConfigMaps, Vault, or any file-based config source.
 //
 // Example YAML:
 //
 //	trino:
+//	  connection_name: production
 //	  host: trino.example.com
 //	  port: 443
 //	  user: service_user
@@ -32,9 +40,22 @@ const defaultLocalhost = "localhost"
 //	  schema: default
 //	  ssl: true
 //	  ssl_verify: true
+//	  tls:
+//	    ca_file: /etc/ssl/internal-ca.pem
+//	    min_version: "1.3"
 //	  timeout: 120s
 //	  source: my-mcp-server
 //
+//	connections:            # Additional servers, inheriting from trino
+//	  staging:
+//	    host: staging.example.com
+//	    catalog: iceberg
+//	    auth:               # Replaces the trino section's auth
+//	      method: oauth2
+//	      token_url: https://idp.example.com/oauth2/token
+//	      client_id: mcp-trino
+//	      client_secret: ${STAGING_CLIENT_SECRET}
+//
 //	toolkit:
 //	  default_limit: 1000
 //	  max_limit: 10000
@@ -48,19 +69,65 @@ const defaultLocalhost = "localhost"
 //	  querylog: false
 //	  metadata: false
 //	  errors: true
+//	  access:               # Per connection; unlisted connections are unrestricted
+//	    production:
+//	      deny: [system, pii_raw]
+//	    staging:
+//	      allow: [iceberg.analytics]
+//	  sensitive_columns:    # Needs a semantic provider
+//	    mode: acknowledge   # block (default) or acknowledge
+//	    min_level: confidential
+//	  redaction:
+//	    sensitive_columns: true
+//	    strategy: partial   # hash (default), partial, or null
+//	    detectors: [email, card]
+//
+//	semantic:
+//	  providers:            # Consulted in order
+//	    - type: static
+//	      file: /etc/mcp-trino/semantic.yaml
+//	      watch_interval: 30s
+//	    - type: datahub
+//	      endpoint: https://datahub.example.com/api/graphql
+//	      token: ${DATAHUB_TOKEN}
+//	  cache:
+//	    ttl: 5m
+//	    max_entries: 10000
+//
+//	transport:
+//	  mode: http
+//	  address: :8443
+//	  tls_cert: /etc/tls/tls.crt
+//	  tls_key: /etc/tls/tls.key
+//	  auth:
+//	    jwks_file: /etc/mcp-trino/jwks.json
+//	    audience: mcp-trino
 type ServerConfig struct {
 	// Trino client configuration
 	Trino TrinoConfig `json:"trino" yaml:"trino"`
 
+	// Connections declares additional named Trino servers. Unset fields
+	// inherit from the trino section.
+	Connections map[string]multiserver.ConnectionConfig `json:"connections,omitempty" yaml:"connections,omitempty"`
+
 	// Toolkit configuration
 	Toolkit ToolkitConfig `json:"toolkit" yaml:"toolkit"`
 
 	// Extensions configuration
 	Extensions ExtFileConfig `json:"extensions" yaml:"extensions"`
+
+	// Semantic metadata providers and caching
+	Semantic SemanticFileConfig `json:"semantic" yaml:"semantic"`
+
+	// Transport configuration for the mcp-trino server binary
+	Transport TransportFileConfig `json:"transport" yaml:"transport"`
 }
 
 // TrinoConfig maps to client.Config for file-based loading.
 type TrinoConfig struct {
+	// ConnectionName is the name of the primary connection. Default: "database".
+	ConnectionName string `json:"connection_name" yaml:"connection_name"`
+
 	Host      string   `json:"host" yaml:"host"`
 	Port      int      `json:"port" yaml:"port"`
 	User      string   `json:"user" yaml:"user"`
@@ -71,6 +138,27 @@ type TrinoConfig struct {
 	SSLVerify *bool    `json:"ssl_verify" yaml:"ssl_verify"` // Pointer to distinguish unset from false
 	Timeout   Duration `json:"timeout" yaml:"timeout"`       // Supports "120s", "2m", etc.
 	Source    string   `json:"source" yaml:"source"`
+
+	// TLS customizes HTTPS: CA bundle, client certificate, server name, and minimum version.
+	TLS client.TLSConfig `json:"tls" yaml:"tls"`
+
+	// Auth selects how to authenticate to Trino (jwt, oauth2, kerberos, certificate).
+	Auth client.AuthConfig `json:"auth" yaml:"auth"`
+
+	// Pool sizes the connection pool: max open and idle connections and
+	// connection lifetimes.
+	Pool client.PoolConfig `json:"pool" yaml:"pool"`
+
+	// Retry controls retries of read statements after transient failures.
+	Retry RetryFileConfig `json:"retry" yaml:"retry"`
+}
+
+// RetryFileConfig maps to client.RetryConfig for file-based loading.
+// Zero fields keep the client defaults.
+type RetryFileConfig struct {
+	MaxAttempts    int      `json:"max_attempts" yaml:"max_attempts"`
+	InitialBackoff Duration `json:"initial_backoff" yaml:"initial_backoff"`
+	MaxBackoff     Duration `json:"max_backoff" yaml:"max_backoff"`
 }
 
 // ToolkitConfig maps to tools.Config for file-based loading.
@@ -80,6 +168,9 @@ type ToolkitConfig struct {
 	DefaultTimeout Duration          `json:"default_timeout" yaml:"default_timeout"`
 	MaxTimeout     Duration          `json:"max_timeout" yaml:"max_timeout"`
 	Descriptions   map[string]string `json:"descriptions,omitempty" yaml:"descriptions,omitempty"`
+
+	// AllowedSessionProperties lists the session properties tool callers may set.
+	AllowedSessionProperties []string `json:"allowed_session_properties,omitempty" yaml:"allowed_session_properties,omitempty"`
 }
 
 // ExtFileConfig maps to Config for file-based loading.
@@ -90,6 +181,78 @@ type ExtFileConfig struct {
 	QueryLog *bool `json:"querylog" yaml:"querylog"`
 	Metadata *bool `json:"metadata" yaml:"metadata"`
 	Errors   *bool `json:"errors" yaml:"errors"`
+
+	// Access holds catalog, schema, and table rules by connection name.
+	Access map[string]AccessRules `json:"access,omitempty" yaml:"access,omitempty"`
+
+	// SensitiveColumns guards queries that read sensitive columns.
+	SensitiveColumns *SensitiveColumnConfig `json:"sensitive_columns,omitempty" yaml:"sensitive_columns,omitempty"`
+
+	// Redaction masks sensitive columns and detected values in query results.
+	Redaction *RedactionConfig `json:"redaction,omitempty" yaml:"redaction,omitempty"`
+}
+
+// SemanticFileConfig configures semantic metadata providers for file-based loading.
+type SemanticFileConfig struct {
+	// Providers are consulted in order; the first with metadata wins.
+	Providers []SemanticProviderConfig `json:"providers,omitempty" yaml:"providers,omitempty"`
+
+	// Cache configures caching of provider responses.
+	Cache SemanticCacheFileConfig `json:"cache" yaml:"cache"`
+}
+
+// SemanticProviderConfig configures a single semantic provider.
+// Type selects which of the remaining fields apply.
+type SemanticProviderConfig struct {
+	// Type is "static" or "datahub".
+	Type string `json:"type" yaml:"type"`
+
+	// Static provider settings
+	File          string   `json:"file,omitempty" yaml:"file,omitempty"`
+	WatchInterval Duration `json:"watch_interval,omitempty" yaml:"watch_interval,omitempty"`
+
+	// DataHub provider settings
+	Endpoint    string   `json:"endpoint,omitempty" yaml:"endpoint,omitempty"`
+	Token       string   `json:"token,omitempty" yaml:"token,omitempty"`
+	Platform    string   `json:"platform,omitempty" yaml:"platform,omitempty"`
+	Environment string   `json:"environment,omitempty" yaml:"environment,omitempty"`
+	Timeout     Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+}
+
+// SemanticCacheFileConfig maps to semantic.CacheConfig for file-based loading.
+// Zero values use the cache defaults.
+type SemanticCacheFileConfig struct {
+	TTL         Duration `json:"ttl" yaml:"ttl"`
+	MaxEntries  int      `json:"max_entries" yaml:"max_entries"`
+	CacheErrors bool     `json:"cache_errors" yaml:"cache_errors"`
+	ErrorTTL    Duration `json:"error_ttl" yaml:"error_ttl"`
+}
+
+// TransportFileConfig configures how the server binary is exposed.
+// Unset fields keep the transport defaults.
+type TransportFileConfig struct {
+	Mode           string         `json:"mode" yaml:"mode"`
+	Address        string         `json:"address" yaml:"address"`
+	Path           string         `json:"path" yaml:"path"`
+	TLSCert        string         `json:"tls_cert" yaml:"tls_cert"`
+	TLSKey         string         `json:"tls_key" yaml:"tls_key"`
+	Stateless      *bool          `json:"stateless" yaml:"stateless"`
+	SessionTimeout *Duration      `json:"session_timeout" yaml:"session_timeout"`
+	JSONResponse   *bool          `json:"json_response" yaml:"json_response"`
+	Auth           AuthFileConfig `json:"auth" yaml:"auth"`
+}
+
+// AuthFileConfig maps to auth.Config for file-based loading.
+type AuthFileConfig struct {
+	JWKSFile      string   `json:"jwks_file" yaml:"jwks_file"`
+	PublicKeyFile string   `json:"public_key_file" yaml:"public_key_file"`
+	HMACSecret    string   `json:"hmac_secret" yaml:"hmac_secret"`
+	Issuer        string   `json:"issuer" yaml:"issuer"`
+	Audience      string   `json:"audience" yaml:"audience"`
+	UserClaim     string   `json:"user_claim" yaml:"user_claim"`
+	Scopes        []string `json:"scopes" yaml:"scopes"`
+	Leeway        Duration `json:"leeway" yaml:"leeway"`
+	Impersonate   *bool    `json:"impersonate" yaml:"impersonate"`
 }
 
 // Duration is a wrapper for time.Duration that supports JSON/YAML unmarshaling
@@ -196,10 +359,31 @@ func FromBytes(data []byte, format string) (ServerConfig, error) {
 	// Expand environment variables in sensitive fields
 	cfg.Trino.Password = os.ExpandEnv(cfg.Trino.Password)
 	cfg.Trino.User = os.ExpandEnv(cfg.Trino.User)
+	cfg.Trino.Auth = expandAuthEnv(cfg.Trino.Auth)
+	for name, conn := range cfg.Connections {
+		conn.User = os.ExpandEnv(conn.User)
+		conn.Password = os.ExpandEnv(conn.Password)
+		if conn.Auth != nil {
+			expanded := expandAuthEnv(*conn.Auth)
+			conn.Auth = &expanded
+		}
+		cfg.Connections[name] = conn
+	}
+	for i := range cfg.Semantic.Providers {
+		cfg.Semantic.Providers[i].Token = os.ExpandEnv(cfg.Semantic.Providers[i].Token)
+	}
+	cfg.Transport.Auth.HMACSecret = os.ExpandEnv(cfg.Transport.Auth.HMACSecret)
 
 	return cfg, nil
 }
 
+// expandAuthEnv expands environment variables in the secret auth fields.
+func expandAuthEnv(a client.AuthConfig) client.AuthConfig {
+	a.Token = os.ExpandEnv(a.Token)
+	a.ClientSecret = os.ExpandEnv(a.ClientSecret)
+	return a
+}
+
 // ClientConfig converts the Trino section to a client.Config.
 func (c ServerConfig) ClientConfig() client.Config {
 	cfg := client.DefaultConfig()
@@ -239,10 +423,42 @@ func (c ServerConfig) ClientConfig() client.Config {
 	if c.Trino.Source != "" {
 		cfg.Source = c.Trino.Source
 	}
+	cfg.TLS = c.Trino.TLS
+	cfg.Auth = c.Trino.Auth
+	cfg.Pool = c.Trino.Pool
+	if c.Trino.Retry.MaxAttempts != 0 {
+		cfg.Retry.MaxAttempts = c.Trino.Retry.MaxAttempts
+	}
+	if c.Trino.Retry.InitialBackoff.Duration() > 0 {
+		cfg.Retry.InitialBackoff = c.Trino.Retry.InitialBackoff.Duration()
+	}
+	if c.Trino.Retry.MaxBackoff.Duration() > 0 {
+		cfg.Retry.MaxBackoff = c.Trino.Retry.MaxBackoff.Duration()
+	}
 
 	return cfg
 }
 
+// MultiServerConfig converts the trino and connections sections to a
+// multiserver.Config. The trino section is the primary connection.
+func (c ServerConfig) MultiServerConfig() multiserver.Config {
+	name := c.Trino.ConnectionName
+	if name == "" {
+		name = multiserver.DefaultConnectionName
+	}
+
+	connections := make(map[string]multiserver.ConnectionConfig, len(c.Connections))
+	for k, v := range c.Connections {
+		connections[k] = v
+	}
+
+	return multiserver.Config{
+		Default:     name,
+		Primary:     c.ClientConfig(),
+		Connections: connections,
+	}
+}
+
 // ToolsConfig converts the Toolkit section to a tools.Config.
 func (c ServerConfig) ToolsConfig() tools.Config {
 	cfg := tools.DefaultConfig()
@@ -259,6 +475,7 @@ func (c ServerConfig) ToolsConfig() tools.Config {
 	if c.Toolkit.MaxTimeout.Duration() > 0 {
 		cfg.MaxTimeout = c.Toolkit.MaxTimeout.Duration()
 	}
+	cfg.AllowedSessionProperties = c.Toolkit.AllowedSessionProperties
 
 	return cfg
 }
@@ -298,6 +515,100 @@ func (c ServerConfig) ExtConfig() Config {
 	if c.Extensions.Errors != nil {
 		cfg.EnableErrorHelp = *c.Extensions.Errors
 	}
+	cfg.SensitiveColumns = c.Extensions.SensitiveColumns
+	cfg.Redaction = c.Extensions.Redaction
+
+	return cfg
+}
+
+// AccessPolicy builds the access policy from the Extensions.Access rules,
+// resolving unqualified table names with the connections' default catalogs
+// and schemas. Returns nil if no rules are configured.
+func (c ServerConfig) AccessPolicy() (*AccessPolicy, error) {
+	if len(c.Extensions.Access) == 0 {
+		return nil, nil
+	}
+	return NewAccessPolicy(c.MultiServerConfig(), c.Extensions.Access)
+}
+
+// SemanticProvider builds the providers declared in the Semantic section.
+// Multiple providers are combined into a semantic.ProviderChain.
+// Returns nil if no providers are configured. Caching is not applied; use
+// SemanticCacheConfig with tools.WithSemanticCache.
+func (c ServerConfig) SemanticProvider() (semantic.Provider, error) {
+	providers := make([]semantic.Provider, 0, len(c.Semantic.Providers))
+	for i, pc := range c.Semantic.Providers {
+		p, err := pc.build()
+		if err != nil {
+			for _, created := range providers {
+				_ = created.Close()
+			}
+			return nil, fmt.Errorf("semantic provider %d (%s): %w", i, pc.Type, err)
+		}
+		providers = append(providers, p)
+	}
+
+	switch len(providers) {
+	case 0:
+		return nil, nil
+	case 1:
+		return providers[0], nil
+	default:
+		return semantic.NewProviderChain(providers...), nil
+	}
+}
+
+// build creates the provider described by the configuration.
+func (pc SemanticProviderConfig) build() (semantic.Provider, error) {
+	switch pc.Type {
+	case "static":
+		return static.New(static.Config{
+			FilePath:      pc.File,
+			WatchInterval: pc.WatchInterval.Duration(),
+		})
+	case "datahub":
+		return datahub.New(datahub.Config{
+			Endpoint:    pc.Endpoint,
+			Token:       pc.Token,
+			Platform:    pc.Platform,
+			Environment: pc.Environment,
+			Timeout:     pc.Timeout.Duration(),
+		})
+	default:
+		return nil, fmt.Errorf("unknown provider type %q (use \"static\" or \"datahub\")", pc.Type)
+	}
+}
+
+// SemanticCacheConfig converts the Semantic.Cache section to a semantic.CacheConfig.
+func (c ServerConfig) SemanticCacheConfig() semantic.CacheConfig {
+	return semantic.CacheConfig{
+		TTL:         c.Semantic.Cache.TTL.Duration(),
+		MaxEntries:  c.Semantic.Cache.MaxEntries,
+		CacheErrors: c.Semantic.Cache.CacheErrors,
+		ErrorTTL:    c.Semantic.Cache.ErrorTTL.Duration(),
+	}
+}
+
+// AuthConfig converts the Transport.Auth section to an auth.Config.
+func (c ServerConfig) AuthConfig() auth.Config {
+	cfg := auth.DefaultConfig()
+	a := c.Transport.Auth
+
+	cfg.JWKSFile = a.JWKSFile
+	cfg.PublicKeyFile = a.PublicKeyFile
+	cfg.HMACSecret = a.HMACSecret
+	cfg.Issuer = a.Issuer
+	cfg.Audience = a.Audience
+	cfg.RequiredScopes = a.Scopes
+	if a.UserClaim != "" {
+		cfg.UserClaim = a.UserClaim
+	}
+	if a.Leeway.Duration() > 0 {
+		cfg.Leeway = a.Leeway.Duration()
+	}
+	if a.Impersonate != nil {
+		cfg.Impersonate = *a.Impersonate
+	}
 
 	return cfg
 }
@@ -326,20 +637,104 @@ func LoadConfig(path string) (ServerConfig, error) {
 	}
 
 	// Override with environment variables
-	cfg = applyEnvOverrides(cfg)
-
-	return cfg, nil
+	return applyEnvOverrides(cfg)
 }
 
 // applyEnvOverrides applies environment variable overrides to a config.
 // Environment variables take precedence over file config.
-func applyEnvOverrides(cfg ServerConfig) ServerConfig {
+func applyEnvOverrides(cfg ServerConfig) (ServerConfig, error) {
 	cfg.Trino = applyTrinoEnvOverrides(cfg.Trino)
 	cfg.Extensions = applyExtensionsEnvOverrides(cfg.Extensions)
+	cfg.Transport = applyTransportEnvOverrides(cfg.Transport)
+
+	connections, err := applyConnectionsEnvOverrides(cfg.Connections)
+	if err != nil {
+		return ServerConfig{}, err
+	}
+	cfg.Connections = connections
+
+	return cfg, nil
+}
+
+// applyConnectionsEnvOverrides merges connections from TRINO_ADDITIONAL_SERVERS.
+// Connections defined in the environment replace file connections of the same name.
+func applyConnectionsEnvOverrides(conns map[string]multiserver.ConnectionConfig) (map[string]multiserver.ConnectionConfig, error) {
+	v := os.Getenv("TRINO_ADDITIONAL_SERVERS")
+	if v == "" {
+		return conns, nil
+	}
+
+	var additional map[string]multiserver.ConnectionConfig
+	if err := json.Unmarshal([]byte(v), &additional); err != nil {
+		return nil, fmt.Errorf("parsing TRINO_ADDITIONAL_SERVERS: %w", err)
+	}
+
+	merged := make(map[string]multiserver.ConnectionConfig, len(conns)+len(additional))
+	for name, conn := range conns {
+		merged[name] = conn
+	}
+	for name, conn := range additional {
+		merged[name] = conn
+	}
+	return merged, nil
+}
+
+// applyTransportEnvOverrides applies MCP_TRINO_TRANSPORT, MCP_TRINO_HTTP_*,
+// MCP_TRINO_TLS_*, and MCP_TRINO_AUTH_* environment variable overrides.
+func applyTransportEnvOverrides(cfg TransportFileConfig) TransportFileConfig {
+	setString := func(dst *string, key string) {
+		if v := os.Getenv(key); v != "" {
+			*dst = v
+		}
+	}
+	setBool := func(dst **bool, key string) {
+		if v := os.Getenv(key); v != "" {
+			b := parseBool(v)
+			*dst = &b
+		}
+	}
+	setDuration := func(dst *Duration, key string) {
+		if v := os.Getenv(key); v != "" {
+			var d Duration
+			if err := yaml.Unmarshal([]byte(v), &d); err == nil {
+				*dst = d
+			}
+		}
+	}
+
+	setString(&cfg.Mode, "MCP_TRINO_TRANSPORT")
+	setString(&cfg.Address, "MCP_TRINO_HTTP_ADDR")
+	setString(&cfg.Path, "MCP_TRINO_HTTP_PATH")
+	setString(&cfg.TLSCert, "MCP_TRINO_TLS_CERT")
+	setString(&cfg.TLSKey, "MCP_TRINO_TLS_KEY")
+	setBool(&cfg.Stateless, "MCP_TRINO_HTTP_STATELESS")
+	if v := os.Getenv("MCP_TRINO_SESSION_TIMEOUT"); v != "" {
+		var d Duration
+		if err := yaml.Unmarshal([]byte(v), &d); err == nil {
+			cfg.SessionTimeout = &d // an explicit 0 disables the timeout
+		}
+	}
+	setBool(&cfg.JSONResponse, "MCP_TRINO_HTTP_JSON_RESPONSE")
+
+	setString(&cfg.Auth.JWKSFile, "MCP_TRINO_AUTH_JWKS_FILE")
+	setString(&cfg.Auth.PublicKeyFile, "MCP_TRINO_AUTH_PUBLIC_KEY_FILE")
+	setString(&cfg.Auth.HMACSecret, "MCP_TRINO_AUTH_HMAC_SECRET")
+	setString(&cfg.Auth.Issuer, "MCP_TRINO_AUTH_ISSUER")
+	setString(&cfg.Auth.Audience, "MCP_TRINO_AUTH_AUDIENCE")
+	setString(&cfg.Auth.UserClaim, "MCP_TRINO_AUTH_USER_CLAIM")
+	if v := os.Getenv("MCP_TRINO_AUTH_SCOPES"); v != "" {
+		cfg.Auth.Scopes = strings.FieldsFunc(v, func(r rune) bool { return r == ',' || r == ' ' })
+	}
+	setDuration(&cfg.Auth.Leeway, "MCP_TRINO_AUTH_LEEWAY")
+	setBool(&cfg.Auth.Impersonate, "MCP_TRINO_AUTH_IMPERSONATE")
+
 	return cfg
 }
 
-// applyTrinoEnvOverrides applies TRINO_* environment variable overrides.
+// applyTrinoEnvOverrides applies TRINO_* environment variable overrides,
+// including the TLS, auth, pool, and retry variables read by
+// client.ApplyTLSEnv, client.ApplyAuthEnv, client.ApplyPoolEnv, and
+// client.ApplyRetryEnv.
 func applyTrinoEnvOverrides(cfg TrinoConfig) TrinoConfig {
 	if v := os.Getenv("TRINO_HOST"); v != "" {
 		cfg.Host = v
@@ -373,6 +768,23 @@ func applyTrinoEnvOverrides(cfg TrinoConfig) TrinoConfig {
 	if v := os.Getenv("TRINO_SOURCE"); v != "" {
 		cfg.Source = v
 	}
+	if v := os.Getenv("TRINO_CONNECTION_NAME"); v != "" {
+		cfg.ConnectionName = v
+	}
+	cfg.TLS = client.ApplyTLSEnv(cfg.TLS)
+	cfg.Auth = client.ApplyAuthEnv(cfg.Auth)
+	cfg.Pool = client.ApplyPoolEnv(cfg.Pool)
+
+	retry := client.ApplyRetryEnv(client.RetryConfig{
+		MaxAttempts:    cfg.Retry.MaxAttempts,
+		InitialBackoff: cfg.Retry.InitialBackoff.Duration(),
+		MaxBackoff:     cfg.Retry.MaxBackoff.Duration(),
+	})
+	cfg.Retry = RetryFileConfig{
+		MaxAttempts:    retry.MaxAttempts,
+		InitialBackoff: Duration(retry.InitialBackoff),
+		MaxBackoff:     Duration(retry.MaxBackoff),
+	}
 	return cfg
 }
 
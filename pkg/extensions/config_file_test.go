@@ -1,10 +1,16 @@
 package extensions
 
 import (
+	"context"
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
 	"time"
+
+	"github.com/txn2/mcp-trino/pkg/client"
+	"github.com/txn2/mcp-trino/pkg/semantic"
+	"github.com/txn2/mcp-trino/pkg/tools"
 )
 
 func TestFromBytes_JSON(t *testing.T) {
@@ -224,6 +230,8 @@ func TestServerConfig_ToolsConfig(t *testing.T) {
 			MaxLimit:       5000,
 			DefaultTimeout: Duration(30 * time.Second),
 			MaxTimeout:     Duration(120 * time.Second),
+
+			AllowedSessionProperties: []string{"query_max_execution_time"},
 		},
 	}
 
@@ -241,6 +249,9 @@ func TestServerConfig_ToolsConfig(t *testing.T) {
 	if toolsCfg.MaxTimeout != 120*time.Second {
 		t.Errorf("expected MaxTimeout 120s, got %v", toolsCfg.MaxTimeout)
 	}
+	if len(toolsCfg.AllowedSessionProperties) != 1 || toolsCfg.AllowedSessionProperties[0] != "query_max_execution_time" {
+		t.Errorf("unexpected AllowedSessionProperties: %v", toolsCfg.AllowedSessionProperties)
+	}
 }
 
 func TestServerConfig_ExtConfig(t *testing.T) {
@@ -496,3 +507,493 @@ trino:
 		t.Errorf("expected default timeout 120s, got %v", cfg.Trino.Timeout.Duration())
 	}
 }
+
+func TestFromBytes_Connections(t *testing.T) {
+	t.Setenv("TEST_STAGING_PASSWORD", "staging-secret")
+
+	yamlConfig := `
+trino:
+  connection_name: production
+  host: prod.example.com
+  user: service
+  catalog: hive
+connections:
+  staging:
+    host: staging.example.com
+    catalog: iceberg
+    password: ${TEST_STAGING_PASSWORD}
+  local:
+    host: localhost
+    ssl_verify: false
+`
+
+	cfg, err := FromBytes([]byte(yamlConfig), ".yaml")
+	if err != nil {
+		t.Fatalf("FromBytes failed: %v", err)
+	}
+
+	msCfg := cfg.MultiServerConfig()
+	if msCfg.Default != "production" {
+		t.Errorf("expected default connection 'production', got %q", msCfg.Default)
+	}
+	if msCfg.ConnectionCount() != 3 {
+		t.Errorf("expected 3 connections, got %d", msCfg.ConnectionCount())
+	}
+
+	staging, err := msCfg.ClientConfig("staging")
+	if err != nil {
+		t.Fatalf("ClientConfig(staging) failed: %v", err)
+	}
+	if staging.Catalog != "iceberg" {
+		t.Errorf("expected catalog 'iceberg', got %q", staging.Catalog)
+	}
+	if staging.User != "service" {
+		t.Errorf("expected user inherited from primary, got %q", staging.User)
+	}
+	if staging.Password != "staging-secret" {
+		t.Errorf("expected expanded password, got %q", staging.Password)
+	}
+
+	local, err := msCfg.ClientConfig("local")
+	if err != nil {
+		t.Fatalf("ClientConfig(local) failed: %v", err)
+	}
+	if local.SSLVerify {
+		t.Error("expected ssl_verify false for local connection")
+	}
+}
+
+func TestFromBytes_ConnectionTLSAndAuth(t *testing.T) {
+	t.Setenv("TEST_TRINO_TOKEN", "primary-jwt")
+	t.Setenv("TEST_STAGING_SECRET", "staging-secret")
+
+	yamlConfig := `
+trino:
+  host: prod.example.com
+  tls:
+    ca_file: /etc/ssl/internal-ca.pem
+    server_name: trino.internal
+  auth:
+    method: jwt
+    token: ${TEST_TRINO_TOKEN}
+connections:
+  staging:
+    host: staging.example.com
+    tls:
+      min_version: "1.3"
+    auth:
+      method: oauth2
+      token_url: https://idp.example.com/token
+      client_id: mcp-trino
+      client_secret: ${TEST_STAGING_SECRET}
+      scopes: [trino]
+  analytics:
+    host: analytics.example.com
+`
+
+	cfg, err := FromBytes([]byte(yamlConfig), ".yaml")
+	if err != nil {
+		t.Fatalf("FromBytes failed: %v", err)
+	}
+	msCfg := cfg.MultiServerConfig()
+
+	if msCfg.Primary.Auth.Method != client.AuthJWT || msCfg.Primary.Auth.Token != "primary-jwt" {
+		t.Errorf("expected primary jwt auth with expanded token, got %+v", msCfg.Primary.Auth)
+	}
+	if msCfg.Primary.TLS.CAFile != "/etc/ssl/internal-ca.pem" || msCfg.Primary.TLS.ServerName != "trino.internal" {
+		t.Errorf("unexpected primary TLS settings: %+v", msCfg.Primary.TLS)
+	}
+
+	staging, err := msCfg.ClientConfig("staging")
+	if err != nil {
+		t.Fatalf("ClientConfig(staging) failed: %v", err)
+	}
+	if staging.Auth.Method != client.AuthOAuth2 || staging.Auth.ClientSecret != "staging-secret" {
+		t.Errorf("expected staging oauth2 auth with expanded secret, got %+v", staging.Auth)
+	}
+	if staging.Auth.Token != "" {
+		t.Error("expected staging auth to replace, not merge with, the primary's")
+	}
+	if staging.TLS != (client.TLSConfig{MinVersion: "1.3"}) {
+		t.Errorf("expected staging TLS to replace the primary's, got %+v", staging.TLS)
+	}
+
+	analytics, err := msCfg.ClientConfig("analytics")
+	if err != nil {
+		t.Fatalf("ClientConfig(analytics) failed: %v", err)
+	}
+	if analytics.Auth.Token != "primary-jwt" {
+		t.Errorf("expected analytics to inherit primary auth, got %+v", analytics.Auth)
+	}
+}
+
+func TestLoadConfig_AuthEnvOverride(t *testing.T) {
+	t.Setenv("TRINO_AUTH_METHOD", "kerberos")
+	t.Setenv("TRINO_KERBEROS_PRINCIPAL", "mcp")
+	t.Setenv("TRINO_KERBEROS_REALM", "EXAMPLE.COM")
+	t.Setenv("TRINO_KERBEROS_KEYTAB", "/etc/mcp.keytab")
+
+	cfg, err := LoadConfig("")
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	a := cfg.ClientConfig().Auth
+	if a.Method != client.AuthKerberos || a.KerberosPrincipal != "mcp" ||
+		a.KerberosRealm != "EXAMPLE.COM" || a.KerberosKeytab != "/etc/mcp.keytab" {
+		t.Errorf("unexpected auth from env: %+v", a)
+	}
+}
+
+func TestLoadConfig_Retry(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	yamlConfig := `
+trino:
+  retry:
+    max_attempts: 5
+    initial_backoff: 200ms
+`
+	if err := os.WriteFile(configPath, []byte(yamlConfig), 0o600); err != nil {
+		t.Fatalf("failed to write temp file: %v", err)
+	}
+	t.Setenv("TRINO_RETRY_MAX_BACKOFF", "10s")
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	r := cfg.ClientConfig().Retry
+	if r.MaxAttempts != 5 || r.InitialBackoff != 200*time.Millisecond || r.MaxBackoff != 10*time.Second {
+		t.Errorf("unexpected retry config: %+v", r)
+	}
+
+	defaults, err := LoadConfig("")
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if got, want := defaults.ClientConfig().Retry.MaxAttempts, client.DefaultRetryConfig().MaxAttempts; got != want {
+		t.Errorf("expected default max attempts %d, got %d", want, got)
+	}
+}
+
+func TestLoadConfig_Pool(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	yamlConfig := `
+trino:
+  pool:
+    max_open_conns: 40
+    conn_max_lifetime: 30m
+connections:
+  adhoc:
+    host: adhoc.example.com
+    pool:
+      max_open_conns: 2
+`
+	if err := os.WriteFile(configPath, []byte(yamlConfig), 0o600); err != nil {
+		t.Fatalf("failed to write temp file: %v", err)
+	}
+	t.Setenv("TRINO_MAX_IDLE_CONNS", "8")
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	want := client.PoolConfig{MaxOpenConns: 40, MaxIdleConns: 8, ConnMaxLifetime: 30 * time.Minute}
+	if got := cfg.ClientConfig().Pool; got != want {
+		t.Errorf("primary pool = %+v, want %+v", got, want)
+	}
+	adhoc, err := cfg.MultiServerConfig().ClientConfig("adhoc")
+	if err != nil {
+		t.Fatalf("ClientConfig(adhoc) error: %v", err)
+	}
+	if adhoc.Pool != (client.PoolConfig{MaxOpenConns: 2}) {
+		t.Errorf("adhoc pool = %+v, want max_open_conns 2 only", adhoc.Pool)
+	}
+}
+
+func TestLoadConfig_ConnectionsEnvOverride(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	yamlConfig := `
+connections:
+  staging:
+    host: file-staging.example.com
+  analytics:
+    host: analytics.example.com
+`
+	if err := os.WriteFile(configPath, []byte(yamlConfig), 0o600); err != nil {
+		t.Fatalf("failed to write temp file: %v", err)
+	}
+
+	t.Setenv("TRINO_ADDITIONAL_SERVERS", `{"staging": {"host": "env-staging.example.com"}}`)
+	t.Setenv("TRINO_CONNECTION_NAME", "primary")
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if cfg.Connections["staging"].Host != "env-staging.example.com" {
+		t.Errorf("expected env to override staging host, got %q", cfg.Connections["staging"].Host)
+	}
+	if cfg.Connections["analytics"].Host != "analytics.example.com" {
+		t.Errorf("expected file connection to be kept, got %q", cfg.Connections["analytics"].Host)
+	}
+	if cfg.MultiServerConfig().Default != "primary" {
+		t.Errorf("expected connection name from env, got %q", cfg.MultiServerConfig().Default)
+	}
+
+	t.Setenv("TRINO_ADDITIONAL_SERVERS", "not json")
+	if _, err := LoadConfig(configPath); err == nil {
+		t.Error("expected error for invalid TRINO_ADDITIONAL_SERVERS")
+	}
+}
+
+func TestServerConfig_SemanticProvider(t *testing.T) {
+	dir := t.TempDir()
+	semanticPath := filepath.Join(dir, "semantic.yaml")
+	semanticYAML := `
+tables:
+  - catalog: hive
+    schema: sales
+    table: orders
+    description: Customer orders
+`
+	if err := os.WriteFile(semanticPath, []byte(semanticYAML), 0o600); err != nil {
+		t.Fatalf("failed to write semantic file: %v", err)
+	}
+
+	t.Run("none configured", func(t *testing.T) {
+		p, err := ServerConfig{}.SemanticProvider()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if p != nil {
+			t.Error("expected nil provider")
+		}
+	})
+
+	t.Run("static provider and cache", func(t *testing.T) {
+		cfg, err := FromBytes([]byte(`
+semantic:
+  providers:
+    - type: static
+      file: `+semanticPath+`
+  cache:
+    ttl: 10m
+    max_entries: 50
+`), ".yaml")
+		if err != nil {
+			t.Fatalf("FromBytes failed: %v", err)
+		}
+
+		p, err := cfg.SemanticProvider()
+		if err != nil {
+			t.Fatalf("SemanticProvider failed: %v", err)
+		}
+		defer func() { _ = p.Close() }()
+		if p.Name() != "static" {
+			t.Errorf("expected static provider, got %q", p.Name())
+		}
+
+		cacheCfg := cfg.SemanticCacheConfig()
+		if cacheCfg.TTL != 10*time.Minute || cacheCfg.MaxEntries != 50 {
+			t.Errorf("unexpected cache config: %+v", cacheCfg)
+		}
+	})
+
+	t.Run("multiple providers are chained", func(t *testing.T) {
+		cfg := ServerConfig{Semantic: SemanticFileConfig{Providers: []SemanticProviderConfig{
+			{Type: "static", File: semanticPath},
+			{Type: "datahub", Endpoint: "https://datahub.example.com/api/graphql", Token: "token"},
+		}}}
+
+		p, err := cfg.SemanticProvider()
+		if err != nil {
+			t.Fatalf("SemanticProvider failed: %v", err)
+		}
+		defer func() { _ = p.Close() }()
+		if _, ok := p.(*semantic.ProviderChain); !ok {
+			t.Errorf("expected *semantic.ProviderChain, got %T", p)
+		}
+	})
+
+	t.Run("invalid providers", func(t *testing.T) {
+		for _, pc := range []SemanticProviderConfig{
+			{Type: "unknown"},
+			{Type: "static"},
+			{Type: "datahub", Endpoint: "https://datahub.example.com"},
+		} {
+			cfg := ServerConfig{Semantic: SemanticFileConfig{Providers: []SemanticProviderConfig{pc}}}
+			if _, err := cfg.SemanticProvider(); err == nil {
+				t.Errorf("expected error for %+v", pc)
+			}
+		}
+	})
+}
+
+func TestServerConfig_AccessPolicy(t *testing.T) {
+	yamlConfig := `
+trino:
+  connection_name: production
+  host: prod.example.com
+  catalog: hive
+  schema: sales
+connections:
+  staging:
+    host: staging.example.com
+extensions:
+  access:
+    production:
+      deny: [system, hive.pii_raw]
+    staging:
+      allow: [iceberg.analytics]
+`
+	cfg, err := FromBytes([]byte(yamlConfig), ".yaml")
+	if err != nil {
+		t.Fatalf("FromBytes failed: %v", err)
+	}
+	if got := cfg.Extensions.Access["production"].Deny; len(got) != 2 {
+		t.Fatalf("expected 2 deny patterns for production, got %v", got)
+	}
+
+	policy, err := cfg.AccessPolicy()
+	if err != nil {
+		t.Fatalf("AccessPolicy failed: %v", err)
+	}
+	if policy == nil {
+		t.Fatal("expected an access policy")
+	}
+	_, err = policy.Intercept(context.Background(), "SELECT * FROM pii_raw.emails", tools.ToolQuery)
+	if !errors.Is(err, ErrAccessPolicy) {
+		t.Errorf("expected default schema table in denied schema to be rejected, got %v", err)
+	}
+
+	// No rules, no policy
+	policy, err = DefaultServerConfig().AccessPolicy()
+	if err != nil || policy != nil {
+		t.Errorf("expected nil policy without rules, got %v, %v", policy, err)
+	}
+
+	// Rules for an undeclared connection are an error
+	cfg.Extensions.Access["qa"] = AccessRules{Deny: []string{"system"}}
+	if _, err := cfg.AccessPolicy(); err == nil {
+		t.Error("expected error for rules of an unknown connection")
+	}
+}
+
+func TestFromBytes_SensitiveColumns(t *testing.T) {
+	yamlConfig := `
+extensions:
+  sensitive_columns:
+    mode: acknowledge
+    min_level: high
+    levels: [low, high]
+`
+	cfg, err := FromBytes([]byte(yamlConfig), ".yaml")
+	if err != nil {
+		t.Fatalf("FromBytes failed: %v", err)
+	}
+
+	sc := cfg.ExtConfig().SensitiveColumns
+	if sc == nil {
+		t.Fatal("expected sensitive column config")
+	}
+	if sc.Mode != SensitiveAcknowledge || sc.MinLevel != "high" || len(sc.Levels) != 2 {
+		t.Errorf("unexpected sensitive column config: %+v", sc)
+	}
+
+	if DefaultServerConfig().ExtConfig().SensitiveColumns != nil {
+		t.Error("expected no sensitive column config by default")
+	}
+}
+
+func TestFromBytes_Redaction(t *testing.T) {
+	yamlConfig := `
+extensions:
+  redaction:
+    sensitive_columns: true
+    strategy: partial
+    detectors: [email, card]
+    patterns:
+      - name: employee_id
+        regex: 'E\d{6}'
+        strategy: hash
+    hash_key: secret
+`
+	cfg, err := FromBytes([]byte(yamlConfig), ".yaml")
+	if err != nil {
+		t.Fatalf("FromBytes failed: %v", err)
+	}
+
+	rc := cfg.ExtConfig().Redaction
+	if rc == nil {
+		t.Fatal("expected redaction config")
+	}
+	if !rc.SensitiveColumns || rc.Strategy != MaskPartial || len(rc.Detectors) != 2 || rc.HashKey != "secret" {
+		t.Errorf("unexpected redaction config: %+v", rc)
+	}
+	if len(rc.Patterns) != 1 || rc.Patterns[0].Regex != `E\d{6}` || rc.Patterns[0].Strategy != MaskHash {
+		t.Errorf("unexpected redaction patterns: %+v", rc.Patterns)
+	}
+}
+
+func TestLoadConfig_TransportAndAuth(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	yamlConfig := `
+transport:
+  mode: http
+  address: :9000
+  stateless: true
+  session_timeout: 0
+  auth:
+    hmac_secret: ${TEST_AUTH_SECRET}
+    audience: mcp-trino
+    user_claim: email
+    scopes: [trino:query]
+    impersonate: false
+`
+	if err := os.WriteFile(configPath, []byte(yamlConfig), 0o600); err != nil {
+		t.Fatalf("failed to write temp file: %v", err)
+	}
+
+	t.Setenv("TEST_AUTH_SECRET", "file-secret")
+	t.Setenv("MCP_TRINO_HTTP_ADDR", ":9443")
+	t.Setenv("MCP_TRINO_AUTH_ISSUER", "https://idp.example.com")
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if cfg.Transport.Mode != "http" {
+		t.Errorf("expected mode http, got %q", cfg.Transport.Mode)
+	}
+	if cfg.Transport.Address != ":9443" {
+		t.Errorf("expected env to override address, got %q", cfg.Transport.Address)
+	}
+	if cfg.Transport.Stateless == nil || !*cfg.Transport.Stateless {
+		t.Error("expected stateless from file")
+	}
+	if cfg.Transport.SessionTimeout == nil || cfg.Transport.SessionTimeout.Duration() != 0 {
+		t.Errorf("expected an explicit zero session timeout, got %v", cfg.Transport.SessionTimeout)
+	}
+
+	authCfg := cfg.AuthConfig()
+	if authCfg.HMACSecret != "file-secret" {
+		t.Errorf("expected expanded HMAC secret, got %q", authCfg.HMACSecret)
+	}
+	if authCfg.Issuer != "https://idp.example.com" {
+		t.Errorf("expected issuer from env, got %q", authCfg.Issuer)
+	}
+	if authCfg.UserClaim != "email" {
+		t.Errorf("expected user claim email, got %q", authCfg.UserClaim)
+	}
+	if authCfg.Impersonate {
+		t.Error("expected impersonation disabled")
+	}
+	if len(authCfg.RequiredScopes) != 1 || authCfg.RequiredScopes[0] != "trino:query" {
+		t.Errorf("unexpected scopes %v", authCfg.RequiredScopes)
+	}
+}
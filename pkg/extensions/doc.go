@@ -16,8 +16,10 @@
 //
 // Interceptors transform or validate SQL before execution:
 //
-//   - [ReadOnlyInterceptor]: Blocks modification statements (INSERT, UPDATE, DELETE, etc.)
+//   - [ReadOnlyInterceptor]: Blocks modification and session-changing statements (INSERT, UPDATE, DELETE, SET SESSION, etc.)
 //   - [QueryLogInterceptor]: Logs all SQL queries for audit/debugging
+//   - [AccessPolicy]: Enforces per-connection catalog and schema allow/deny rules
+//   - [SensitiveColumnGuard]: Blocks, or asks callers to acknowledge, queries reading sensitive columns
 //
 // # Result Transformers
 //
@@ -26,6 +28,12 @@
 //   - [MetadataEnricher]: Adds execution metadata footer to results
 //   - [ErrorEnricher]: Adds helpful hints to error messages
 //
+// # Query Output Transformers
+//
+// Query output transformers modify result rows before they are formatted:
+//
+//   - [Redactor]: Masks sensitive columns and values matching PII detectors
+//
 // # Configuration
 //
 // Use [FromEnv] to load configuration from environment variables:
@@ -2,15 +2,21 @@ package extensions
 
 import (
 	"bytes"
+	"cmp"
 	"context"
 	"errors"
 	"os"
+	"reflect"
+	"slices"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 
+	"github.com/txn2/mcp-trino/pkg/client"
+	"github.com/txn2/mcp-trino/pkg/multiserver"
+	"github.com/txn2/mcp-trino/pkg/semantic"
 	"github.com/txn2/mcp-trino/pkg/tools"
 )
 
@@ -348,8 +354,13 @@ func TestReadOnlyInterceptor_AllowsSelect(t *testing.T) {
 		"select id from users",
 		"  SELECT COUNT(*) FROM orders",
 		"EXPLAIN SELECT * FROM table",
+		"EXPLAIN ANALYZE SELECT * FROM table",
 		"SHOW TABLES",
 		"DESCRIBE table",
+		"WITH recent AS (SELECT * FROM orders) SELECT * FROM recent",
+		"SELECT * FROM table WHERE note = 'DROP TABLE x'",
+		"SELECT 1 -- ; DELETE FROM table",
+		"SELECT 1;",
 	}
 
 	for _, sql := range tests {
@@ -379,6 +390,16 @@ func TestReadOnlyInterceptor_BlocksModifications(t *testing.T) {
 		"MERGE INTO target USING source ON ...",
 		"  INSERT INTO table VALUES (1)", // With leading whitespace
 		"insert into table values (1)",   // Lowercase
+		"EXPLAIN ANALYZE INSERT INTO table VALUES (1)",
+		"explain analyze verbose DELETE FROM table",
+		"WITH x AS (SELECT 1) INSERT INTO table SELECT * FROM x",
+		"SELECT 1; DROP TABLE table",
+		"/* comment */ DELETE FROM table",
+		"CALL system.sync_partition_metadata('s', 't', 'ADD')",
+		"EXECUTE stmt",
+		"ALTER TABLE table EXECUTE optimize",
+		"SET SESSION query_max_run_time = '1h'",
+		"USE hive.sales",
 	}
 
 	for _, sql := range tests {
@@ -430,6 +451,630 @@ func TestReadOnlyInterceptor_BlocksExecuteTool(t *testing.T) {
 	}
 }
 
+// ============================================================================
+// Access Policy Tests
+// ============================================================================
+
+func newTestAccessPolicy(t *testing.T) *AccessPolicy {
+	t.Helper()
+	cfg := multiserver.Config{
+		Default: "production",
+		Primary: client.Config{Catalog: "hive", Schema: "sales"},
+		Connections: map[string]multiserver.ConnectionConfig{
+			"staging": {Host: "staging.example.com", Catalog: "iceberg", Schema: "analytics"},
+			"dev":     {Host: "dev.example.com"},
+		},
+	}
+	policy, err := NewAccessPolicy(cfg, map[string]AccessRules{
+		"production": {Deny: []string{"system", "hive.pii_*", "hive.sales.salaries"}},
+		"staging":    {Allow: []string{"iceberg.analytics", "iceberg.shared.calendar"}},
+	})
+	if err != nil {
+		t.Fatalf("NewAccessPolicy() error: %v", err)
+	}
+	return policy
+}
+
+func TestAccessPolicy_Intercept(t *testing.T) {
+	policy := newTestAccessPolicy(t)
+
+	tests := []struct {
+		name       string
+		connection string
+		sql        string
+		wantErr    string
+	}{
+		{name: "allowed", sql: "SELECT * FROM hive.sales.orders"},
+		{name: "default connection by name", connection: "production", sql: "SELECT * FROM orders"},
+		{
+			name: "denied catalog", sql: "SELECT * FROM system.runtime.queries",
+			wantErr: `table "system.runtime.queries" on connection "production" matches deny pattern "system"`,
+		},
+		{
+			name: "denied schema wildcard", sql: "SELECT * FROM orders o JOIN pii_raw.customers c ON o.c = c.id",
+			wantErr: `table "hive.pii_raw.customers"`,
+		},
+		{name: "unqualified denied table", sql: "SELECT * FROM Salaries", wantErr: `table "hive.sales.salaries"`},
+		{name: "quoted name", sql: `SELECT * FROM "SYSTEM".runtime.nodes`, wantErr: `table "system.runtime.nodes"`},
+		{name: "subquery", sql: "SELECT * FROM orders WHERE id IN (SELECT id FROM system.runtime.tasks)", wantErr: "system"},
+		{name: "second statement", sql: "SELECT 1; SELECT * FROM system.runtime.nodes", wantErr: "system"},
+		{name: "cte name is not a table", sql: "WITH system AS (SELECT 1) SELECT * FROM system"},
+		{name: "write target", sql: "INSERT INTO hive.pii_raw.emails SELECT * FROM orders", wantErr: "pii_raw"},
+		{name: "show tables in denied schema", sql: "SHOW TABLES FROM pii_raw", wantErr: `schema "hive.pii_raw"`},
+		{name: "show schemas in denied catalog", sql: "SHOW SCHEMAS FROM system", wantErr: `catalog "system"`},
+		{name: "use denied schema", sql: "USE hive.pii_raw", wantErr: `schema "hive.pii_raw"`},
+		{name: "show tables in default schema", sql: "SHOW TABLES LIKE '%'"},
+		{name: "show schemas in default catalog", sql: "SHOW SCHEMAS"},
+		{name: "describe denied table", sql: "DESCRIBE hive.sales.salaries", wantErr: "salaries"},
+		{name: "show stats for query", sql: "SHOW STATS FOR (SELECT * FROM pii_raw.emails)", wantErr: `table "hive.pii_raw.emails"`},
+
+		// Table functions need their whole catalog allowed
+		{
+			name: "table function in restricted catalog", sql: "SELECT * FROM TABLE(hive.system.query(query => 'SELECT * FROM pii_raw.emails'))",
+			wantErr: `table function "hive.system.query" on connection "production" can read all of catalog "hive", and deny pattern "hive.pii_*"`,
+		},
+		{name: "unqualified table function", sql: "SELECT * FROM TABLE(system.query(query => 'SELECT 1'))", wantErr: `catalog "hive"`},
+		{name: "table function in denied catalog", sql: "SELECT * FROM TABLE(system.builtin.sequence(start => 1, stop => 3))", wantErr: "system"},
+		{name: "table function in unrestricted catalog", sql: "SELECT * FROM TABLE(iceberg.system.query(query => 'SELECT 1'))"},
+		{
+			name: "table function argument", sql: "SELECT * FROM TABLE(iceberg.system.fn(input => TABLE(hive.sales.salaries)))",
+			wantErr: `table "hive.sales.salaries"`,
+		},
+
+		// Statements whose tables cannot be checked
+		{name: "execute immediate", sql: "EXECUTE IMMEDIATE 'SELECT * FROM pii_raw.emails'", wantErr: "execute statements cannot be checked"},
+		{name: "execute", sql: "EXECUTE stmt", wantErr: "execute statements"},
+		{name: "prepare", sql: "PREPARE stmt FROM SELECT * FROM orders", wantErr: "prepare statements"},
+		{name: "explain execute", sql: "EXPLAIN EXECUTE stmt", wantErr: "execute statements"},
+		{name: "call", sql: "CALL system.flush_metadata_cache(schema_name => 'pii_raw')", wantErr: "call statements"},
+		{name: "unknown statement", sql: "VACUUM orders", wantErr: "unknown statements"},
+
+		{name: "allow list", connection: "staging", sql: "SELECT * FROM events JOIN shared.calendar USING (day)"},
+		{
+			name: "outside allow list", connection: "staging", sql: "SELECT * FROM iceberg.shared.holidays",
+			wantErr: `table "iceberg.shared.holidays" on connection "staging" matches no allow pattern`,
+		},
+		{name: "allow list other catalog", connection: "staging", sql: "SELECT * FROM hive.sales.orders", wantErr: "no allow pattern"},
+		{
+			name: "allow list table function", connection: "staging", sql: "SELECT * FROM TABLE(system.query(query => 'SELECT 1'))",
+			wantErr: `catalog "iceberg", which no allow pattern covers`,
+		},
+
+		{name: "connection without rules", connection: "dev", sql: "SELECT * FROM system.runtime.queries"},
+		{name: "execute without rules", connection: "dev", sql: "EXECUTE IMMEDIATE 'SELECT 1'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := tools.WithConnection(context.Background(), tt.connection)
+			got, err := policy.Intercept(ctx, tt.sql, tools.ToolQuery)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Intercept() error: %v", err)
+				}
+				if got != tt.sql {
+					t.Errorf("Intercept() = %q, want the SQL unchanged", got)
+				}
+				return
+			}
+			if !errors.Is(err, ErrAccessPolicy) {
+				t.Fatalf("Intercept() error = %v, want ErrAccessPolicy", err)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Intercept() error = %q, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAccessPolicy_CheckObject(t *testing.T) {
+	policy := newTestAccessPolicy(t)
+
+	tests := []struct {
+		connection             string
+		catalog, schema, table string
+		allowed                bool
+	}{
+		{"", "hive", "", "", true},
+		{"", "system", "", "", false},
+		{"", "SYSTEM", "", "", false},
+		{"", "hive", "pii_raw", "", false},
+		{"", "hive", "pii_raw", "emails", false},
+		{"", "hive", "sales", "", true},
+		{"", "hive", "sales", "salaries", false},
+		{"", "hive", "sales", "orders", true},
+
+		// Catalogs and schemas leading to allowed objects stay visible
+		{"staging", "iceberg", "", "", true},
+		{"staging", "iceberg", "analytics", "events", true},
+		{"staging", "iceberg", "shared", "", true},
+		{"staging", "iceberg", "shared", "calendar", true},
+		{"staging", "iceberg", "shared", "holidays", false},
+		{"staging", "iceberg", "raw", "", false},
+		{"staging", "hive", "", "", false},
+
+		{"dev", "system", "", "", true},
+
+		// An omitted catalog or schema is the connection's default, as in
+		// trino_describe_table
+		{"", "", "", "salaries", false},
+		{"", "", "", "orders", true},
+		{"", "", "pii_raw", "emails", false},
+		{"", "", "sales", "salaries", false},
+		{"", "system", "", "nodes", false},
+		{"", "hive", "", "salaries", false},
+		{"staging", "", "", "events", true},
+		{"staging", "", "", "holidays", true},
+		{"staging", "", "shared", "holidays", false},
+	}
+
+	for _, tt := range tests {
+		ctx := tools.WithConnection(context.Background(), tt.connection)
+		err := policy.CheckObject(ctx, tt.catalog, tt.schema, tt.table)
+		if got := err == nil; got != tt.allowed {
+			t.Errorf("CheckObject(%q, %q, %q, %q) error = %v, want allowed %v",
+				tt.connection, tt.catalog, tt.schema, tt.table, err, tt.allowed)
+		}
+	}
+}
+
+func TestAccessPolicy_UnqualifiedWithoutDefaults(t *testing.T) {
+	cfg := multiserver.Config{Default: "production"}
+	policy, err := NewAccessPolicy(cfg, map[string]AccessRules{"production": {Allow: []string{"hive"}}})
+	if err != nil {
+		t.Fatalf("NewAccessPolicy() error: %v", err)
+	}
+
+	for _, sql := range []string{"SELECT * FROM orders", "SHOW TABLES", "SHOW TABLES FROM sales", "SHOW SCHEMAS", "USE sales"} {
+		_, err = policy.Intercept(context.Background(), sql, tools.ToolQuery)
+		if !errors.Is(err, ErrAccessPolicy) || !strings.Contains(err.Error(), "not fully qualified") {
+			t.Errorf("expected %q to be rejected, got %v", sql, err)
+		}
+	}
+
+	err = policy.CheckObject(context.Background(), "hive", "", "orders")
+	if !errors.Is(err, ErrAccessPolicy) || !strings.Contains(err.Error(), `table "hive.orders" is not fully qualified`) {
+		t.Errorf("expected table without schema to be rejected, got %v", err)
+	}
+}
+
+func TestAccessPolicy_CallDefaultSchema(t *testing.T) {
+	policy := newTestAccessPolicy(t)
+
+	// The tool call's schema, not the connection's, resolves the name
+	ctx := tools.WithDefaultSchema(context.Background(), "", "pii_raw")
+	if _, err := policy.Intercept(ctx, "SELECT * FROM customers", tools.ToolQuery); !errors.Is(err, ErrAccessPolicy) {
+		t.Errorf("expected hive.pii_raw.customers to be rejected, got %v", err)
+	}
+	if _, err := policy.Intercept(ctx, "SHOW TABLES FROM sales", tools.ToolQuery); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// SHOW TABLES and SHOW SCHEMAS without FROM list the call's schema and catalog
+	for _, sql := range []string{"SHOW TABLES", "SHOW TABLES LIKE '%'"} {
+		_, err := policy.Intercept(ctx, sql, tools.ToolQuery)
+		if !errors.Is(err, ErrAccessPolicy) || !strings.Contains(err.Error(), `schema "hive.pii_raw"`) {
+			t.Errorf("Intercept(%q) error = %v, want hive.pii_raw rejected", sql, err)
+		}
+	}
+
+	ctx = tools.WithDefaultSchema(context.Background(), "System", "runtime")
+	if _, err := policy.Intercept(ctx, "SHOW SCHEMAS", tools.ToolQuery); err == nil || !strings.Contains(err.Error(), `catalog "system"`) {
+		t.Errorf("expected SHOW SCHEMAS in system to be rejected, got %v", err)
+	}
+	_, err := policy.Intercept(ctx, "SELECT * FROM nodes", tools.ToolQuery)
+	if err == nil || !strings.Contains(err.Error(), "system.runtime.nodes") {
+		t.Errorf("expected system.runtime.nodes to be rejected, got %v", err)
+	}
+	if err := policy.CheckObject(ctx, "", "", "nodes"); err == nil || !strings.Contains(err.Error(), "system.runtime.nodes") {
+		t.Errorf("expected CheckObject to reject system.runtime.nodes, got %v", err)
+	}
+}
+
+func TestNewAccessPolicy_Errors(t *testing.T) {
+	cfg := multiserver.Config{Default: "production"}
+
+	tests := map[string]map[string]AccessRules{
+		"unknown connection": {"staging": {Deny: []string{"system"}}},
+		"too many parts":     {"production": {Deny: []string{"a.b.c.d"}}},
+		"empty part":         {"production": {Allow: []string{"hive..orders"}}},
+		"bad wildcard":       {"production": {Deny: []string{"hive.[pii"}}},
+	}
+	for name, rules := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewAccessPolicy(cfg, rules); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestBuildToolkitOptions_AccessPolicy(t *testing.T) {
+	policy := newTestAccessPolicy(t)
+	cfg := Config{AccessPolicy: policy}
+
+	toolkit := tools.NewToolkit(nil, tools.DefaultConfig(), BuildToolkitOptions(cfg)...)
+	if !toolkit.HasInterceptors() || !toolkit.HasObjectFilters() {
+		t.Error("expected the access policy as interceptor and object filter")
+	}
+	if _, err := toolkit.InterceptSQL(context.Background(), "SELECT * FROM system.runtime.nodes", tools.ToolQuery); err == nil {
+		t.Error("expected the access policy to reject the query")
+	}
+}
+
+// ============================================================================
+// Sensitive Column Guard Tests
+// ============================================================================
+
+// sensitiveProvider marks columns of hive.hr.employees sensitive at
+// several levels, and fails for hive.hr.broken.
+func sensitiveProvider() semantic.Provider {
+	return semantic.ProviderFunc{
+		GetColumnsContextFn: func(_ context.Context, table semantic.TableIdentifier) (map[string]*semantic.ColumnContext, error) {
+			switch table.String() {
+			case "hive.hr.employees":
+				return map[string]*semantic.ColumnContext{
+					"name":   {},
+					"email":  {IsSensitive: true, SensitivityLevel: "internal"},
+					"salary": {IsSensitive: true, SensitivityLevel: "Confidential"},
+					"SSN":    {IsSensitive: true, SensitivityLevel: "restricted"},
+					"notes":  {IsSensitive: true},
+				}, nil
+			case "hive.hr.broken":
+				return nil, errors.New("provider unavailable")
+			}
+			return nil, nil
+		},
+	}
+}
+
+// sensitiveDescriber describes every table with the columns of
+// hive.hr.employees, resolving an empty catalog and schema to hive.hr, and
+// records the tables it describes.
+func sensitiveDescriber(described *[]string) TableDescriber {
+	return TableDescriberFunc(func(_ context.Context, _, catalog, schema, table string) (*client.TableInfo, error) {
+		*described = append(*described, table)
+		if table == "missing" {
+			return nil, errors.New("table not found")
+		}
+		cols := []client.ColumnDef{{Name: "id"}, {Name: "name"}, {Name: "email"}, {Name: "salary"}, {Name: "ssn"}, {Name: "notes"}}
+		return &client.TableInfo{
+			Catalog: cmp.Or(catalog, "hive"),
+			Schema:  cmp.Or(schema, "hr"),
+			Name:    table,
+			Columns: cols,
+		}, nil
+	})
+}
+
+func TestSensitiveColumnGuard_Intercept(t *testing.T) {
+	var described []string
+	guard, err := NewSensitiveColumnGuard(sensitiveProvider(), sensitiveDescriber(&described),
+		SensitiveColumnConfig{MinLevel: "confidential"})
+	if err != nil {
+		t.Fatalf("NewSensitiveColumnGuard() error: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		sql     string
+		wantErr string
+	}{
+		{name: "no sensitive columns", sql: "SELECT id, name, email FROM hive.hr.employees"},
+		{name: "sensitive column", sql: "SELECT name, ssn FROM hive.hr.employees", wantErr: "hive.hr.employees.ssn (restricted)"},
+		{name: "quoted column", sql: `SELECT "SSN" FROM hive.hr.employees`, wantErr: "hive.hr.employees.ssn"},
+		{name: "qualified column", sql: "SELECT e.salary FROM hive.hr.employees e", wantErr: "salary (Confidential)"},
+		{name: "filter column", sql: "SELECT id FROM hive.hr.employees WHERE salary > 100000", wantErr: "salary"},
+		{name: "unknown level counts as most sensitive", sql: "SELECT notes FROM hive.hr.employees", wantErr: "hive.hr.employees.notes"},
+		{name: "column name in string", sql: "SELECT id FROM hive.hr.employees WHERE name = 'ssn'"},
+		{
+			name:    "select star",
+			sql:     "SELECT * FROM hive.hr.employees",
+			wantErr: "hive.hr.employees.notes, hive.hr.employees.salary (Confidential), hive.hr.employees.ssn (restricted)",
+		},
+		{name: "table star", sql: "SELECT e.* FROM hive.hr.employees e", wantErr: "ssn"},
+		{name: "count star", sql: "SELECT count(*) FROM hive.hr.employees"},
+		{name: "table statement", sql: "TABLE hive.hr.employees", wantErr: "hive.hr.employees.ssn (restricted)"},
+		{name: "table subquery", sql: "SELECT count(*) FROM (TABLE hive.hr.employees)", wantErr: "ssn"},
+		{name: "drop table", sql: "DROP TABLE hive.hr.employees"},
+		{
+			name: "table function", sql: "SELECT * FROM TABLE(hive.system.query(query => 'SELECT ssn FROM hr.employees'))",
+			wantErr: "table function hive.system.query (columns unknown)",
+		},
+		{name: "execute immediate", sql: "EXECUTE IMMEDIATE 'SELECT ssn FROM hive.hr.employees'", wantErr: "EXECUTE statement (columns unknown)"},
+		{name: "unqualified table", sql: "SELECT ssn FROM employees", wantErr: "hive.hr.employees.ssn"},
+		{name: "second statement", sql: "SELECT 1; SELECT ssn FROM hive.hr.employees", wantErr: "ssn"},
+		{name: "copied by insert", sql: "INSERT INTO hive.tmp.t SELECT ssn FROM hive.hr.employees", wantErr: "ssn"},
+		{name: "explain", sql: "EXPLAIN SELECT ssn FROM hive.hr.employees"},
+		{name: "explain analyze", sql: "EXPLAIN ANALYZE SELECT ssn FROM hive.hr.employees", wantErr: "hive.hr.employees.ssn"},
+		{name: "other table", sql: "SELECT ssn FROM hive.sales.customers"},
+		{name: "provider error", sql: "SELECT id FROM hive.hr.broken", wantErr: "provider unavailable"},
+		{name: "describe error", sql: "SELECT * FROM hive.hr.missing", wantErr: "table not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := guard.Intercept(context.Background(), tt.sql, tools.ToolQuery)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Intercept() error: %v", err)
+				}
+				if got != tt.sql {
+					t.Errorf("Intercept() = %q, want the SQL unchanged", got)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Intercept() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	// trino_explain passes the explained statement, not EXPLAIN ...
+	sql := "SELECT ssn FROM hive.hr.employees"
+	if got, err := guard.Intercept(context.Background(), sql, tools.ToolExplain); err != nil || got != sql {
+		t.Errorf("Intercept(ToolExplain) = %q, %v; want the SQL unchanged", got, err)
+	}
+
+	// Only unqualified names and * need the table described
+	if want := []string{"employees", "employees", "employees", "employees", "employees", "missing"}; !slices.Equal(described, want) {
+		t.Errorf("described %v, want %v", described, want)
+	}
+}
+
+func TestSensitiveColumnGuard_CallDefaultSchema(t *testing.T) {
+	var described []string
+	guard, err := NewSensitiveColumnGuard(sensitiveProvider(), sensitiveDescriber(&described), SensitiveColumnConfig{})
+	if err != nil {
+		t.Fatalf("NewSensitiveColumnGuard() error: %v", err)
+	}
+
+	ctx := tools.WithDefaultSchema(context.Background(), "hive", "hr")
+	_, err = guard.Intercept(ctx, "SELECT email FROM employees", tools.ToolQuery)
+	if !errors.Is(err, ErrSensitiveColumns) || !strings.Contains(err.Error(), "hive.hr.employees.email (internal)") {
+		t.Errorf("expected email to be guarded without a minimum level, got %v", err)
+	}
+	if len(described) != 0 {
+		t.Errorf("expected no describe for a name qualified by the call, got %v", described)
+	}
+}
+
+func TestSensitiveColumnGuard_Acknowledge(t *testing.T) {
+	var described []string
+	guard, err := NewSensitiveColumnGuard(sensitiveProvider(), sensitiveDescriber(&described),
+		SensitiveColumnConfig{Mode: SensitiveAcknowledge, MinLevel: "restricted"})
+	if err != nil {
+		t.Fatalf("NewSensitiveColumnGuard() error: %v", err)
+	}
+	sql := "SELECT ssn, salary FROM hive.hr.employees"
+
+	_, err = guard.Intercept(context.Background(), sql, tools.ToolQuery)
+	if !errors.Is(err, ErrSensitiveColumns) || !strings.Contains(err.Error(), "acknowledge_sensitive") {
+		t.Fatalf("expected a request for acknowledgement, got %v", err)
+	}
+	if strings.Contains(err.Error(), "salary") {
+		t.Errorf("salary is below the minimum level: %v", err)
+	}
+
+	ctx := tools.WithSensitiveAcknowledged(context.Background(), true)
+	if got, err := guard.Intercept(ctx, sql, tools.ToolQuery); err != nil || got != sql {
+		t.Errorf("expected acknowledged query to pass, got %q, %v", got, err)
+	}
+
+	// Table functions need acknowledgement too
+	function := "SELECT * FROM TABLE(hive.system.query(query => 'SELECT ssn FROM hr.employees'))"
+	if _, err := guard.Intercept(context.Background(), function, tools.ToolQuery); !errors.Is(err, ErrSensitiveColumns) {
+		t.Errorf("expected a request for acknowledgement of a table function, got %v", err)
+	}
+	if got, err := guard.Intercept(ctx, function, tools.ToolQuery); err != nil || got != function {
+		t.Errorf("expected acknowledged table function to pass, got %q, %v", got, err)
+	}
+
+	// Acknowledgement does not lift a block
+	block, err := NewSensitiveColumnGuard(sensitiveProvider(), sensitiveDescriber(&described), SensitiveColumnConfig{})
+	if err != nil {
+		t.Fatalf("NewSensitiveColumnGuard() error: %v", err)
+	}
+	if _, err := block.Intercept(ctx, sql, tools.ToolQuery); !errors.Is(err, ErrSensitiveColumns) {
+		t.Errorf("expected blocked query, got %v", err)
+	}
+}
+
+func TestNewSensitiveColumnGuard_Errors(t *testing.T) {
+	var described []string
+	tables := sensitiveDescriber(&described)
+
+	tests := map[string]SensitiveColumnConfig{
+		"unknown mode":      {Mode: "warn"},
+		"unknown min level": {MinLevel: "secret"},
+		"min level not in custom levels": {
+			MinLevel: "confidential",
+			Levels:   []string{"low", "high"},
+		},
+	}
+	for name, cfg := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewSensitiveColumnGuard(sensitiveProvider(), tables, cfg); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+
+	if _, err := NewSensitiveColumnGuard(nil, tables, SensitiveColumnConfig{}); err == nil {
+		t.Error("expected error without a provider")
+	}
+	if _, err := NewSensitiveColumnGuard(sensitiveProvider(), tables, SensitiveColumnConfig{
+		MinLevel: "HIGH", Levels: []string{"low", "high"},
+	}); err != nil {
+		t.Errorf("unexpected error for custom levels: %v", err)
+	}
+}
+
+// ============================================================================
+// Redactor Tests
+// ============================================================================
+
+func employeeOutput() *tools.QueryOutput {
+	return &tools.QueryOutput{
+		Columns: []tools.QueryColumn{{Name: "id", Type: "bigint"}, {Name: "ssn", Type: "varchar"}, {Name: "salary", Type: "bigint"}},
+		Rows: []map[string]any{
+			{"id": int64(1), "ssn": "123-45-6789", "salary": int64(120000)},
+			{"id": int64(2), "ssn": nil, "salary": int64(95000)},
+		},
+	}
+}
+
+func TestRedactor_SensitiveColumns(t *testing.T) {
+	var described []string
+	tables := sensitiveDescriber(&described)
+	sql := "SELECT id, ssn, salary FROM hive.hr.employees"
+
+	tests := []struct {
+		strategy   string
+		wantSSN    any
+		wantSalary any
+		wantType   string
+	}{
+		{strategy: MaskPartial, wantSSN: "*******6789", wantSalary: "**0000", wantType: "varchar"},
+		{strategy: MaskNull, wantSSN: nil, wantSalary: nil, wantType: "bigint"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.strategy, func(t *testing.T) {
+			r, err := NewRedactor(sensitiveProvider(), tables, RedactionConfig{SensitiveColumns: true, Strategy: tt.strategy})
+			if err != nil {
+				t.Fatalf("NewRedactor() error: %v", err)
+			}
+			out := employeeOutput()
+			if err := r.TransformQueryOutput(context.Background(), tools.ToolQuery, sql, out); err != nil {
+				t.Fatalf("TransformQueryOutput() error: %v", err)
+			}
+			if got := out.Rows[0]["ssn"]; got != tt.wantSSN {
+				t.Errorf("ssn = %v, want %v", got, tt.wantSSN)
+			}
+			if got := out.Rows[0]["salary"]; got != tt.wantSalary {
+				t.Errorf("salary = %v, want %v", got, tt.wantSalary)
+			}
+			if out.Rows[0]["id"] != int64(1) || out.Rows[1]["ssn"] != nil {
+				t.Errorf("unexpected row values: %v", out.Rows)
+			}
+			if out.Columns[2].Type != tt.wantType || out.Columns[0].Type != "bigint" {
+				t.Errorf("unexpected column types: %+v", out.Columns)
+			}
+		})
+	}
+
+	// Hashes are stable for equal values and depend on the key
+	hash := func(key string) any {
+		t.Helper()
+		r, err := NewRedactor(sensitiveProvider(), tables, RedactionConfig{SensitiveColumns: true, HashKey: key})
+		if err != nil {
+			t.Fatalf("NewRedactor() error: %v", err)
+		}
+		out := employeeOutput()
+		if err := r.TransformQueryOutput(context.Background(), tools.ToolQuery, sql, out); err != nil {
+			t.Fatalf("TransformQueryOutput() error: %v", err)
+		}
+		return out.Rows[0]["ssn"]
+	}
+	first, again, keyed := hash(""), hash(""), hash("secret")
+	if s, ok := first.(string); !ok || len(s) != 16 || s == "123-45-6789" {
+		t.Errorf("unexpected hash %v", first)
+	}
+	if first != again || first == keyed {
+		t.Errorf("hashes %v, %v, %v: want equal without a key, different with one", first, again, keyed)
+	}
+
+	if len(described) != 0 {
+		t.Errorf("expected no describe for qualified names, got %v", described)
+	}
+}
+
+func TestRedactor_SensitiveColumnsUnqualified(t *testing.T) {
+	var described []string
+	r, err := NewRedactor(sensitiveProvider(), sensitiveDescriber(&described), RedactionConfig{
+		SensitiveColumns: true, Strategy: MaskNull,
+	})
+	if err != nil {
+		t.Fatalf("NewRedactor() error: %v", err)
+	}
+
+	out := employeeOutput()
+	if err := r.TransformQueryOutput(context.Background(), tools.ToolQuery, "SELECT * FROM employees", out); err != nil {
+		t.Fatalf("TransformQueryOutput() error: %v", err)
+	}
+	if out.Rows[0]["ssn"] != nil || !slices.Equal(described, []string{"employees"}) {
+		t.Errorf("expected ssn nulled after describing employees, got %v (described %v)", out.Rows[0], described)
+	}
+
+	err = r.TransformQueryOutput(context.Background(), tools.ToolQuery, "SELECT id FROM hive.hr.broken", employeeOutput())
+	if err == nil || !strings.Contains(err.Error(), "provider unavailable") {
+		t.Errorf("expected the provider error, got %v", err)
+	}
+}
+
+func TestRedactor_Detectors(t *testing.T) {
+	r, err := NewRedactor(nil, nil, RedactionConfig{
+		Strategy:  MaskPartial,
+		Detectors: []string{"card", "SSN", "email", "phone"},
+		Patterns: []RedactionPattern{
+			{Name: "employee", Regex: `E\d{6}`, Strategy: MaskNull},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewRedactor() error: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		value any
+		want  any
+	}{
+		{name: "email", value: "mail jo@example.com today", want: "mail **********.com today"},
+		{name: "card", value: "card 4111 1111 1111 1111", want: "card ***************1111"},
+		{name: "not a card", value: "order 1234 5678 9012 3456", want: "order 1234 5678 9012 3456"},
+		{name: "ssn", value: "123-45-6789", want: "*******6789"},
+		{name: "phone", value: "call (555) 123-4567", want: "call **********4567"},
+		{name: "custom null", value: "badge E123456", want: nil},
+		{name: "number", value: int64(4111111111111111), want: int64(4111111111111111)},
+		{
+			name:  "nested",
+			value: []any{"a@b.io", map[string]any{"ssn": "123-45-6789"}},
+			want:  []any{"**b.io", map[string]any{"ssn": "*******6789"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &tools.QueryOutput{
+				Columns: []tools.QueryColumn{{Name: "v", Type: "varchar"}},
+				Rows:    []map[string]any{{"v": tt.value}},
+			}
+			if err := r.TransformQueryOutput(context.Background(), tools.ToolQuery, "SELECT v FROM t", out); err != nil {
+				t.Fatalf("TransformQueryOutput() error: %v", err)
+			}
+			if got := out.Rows[0]["v"]; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("value = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewRedactor_Errors(t *testing.T) {
+	tests := map[string]RedactionConfig{
+		"unknown strategy":         {Strategy: "scramble"},
+		"unknown detector":         {Detectors: []string{"passport"}},
+		"bad pattern":              {Patterns: []RedactionPattern{{Name: "bad", Regex: "("}}},
+		"bad pattern strategy":     {Patterns: []RedactionPattern{{Name: "p", Regex: "x", Strategy: "drop"}}},
+		"columns without provider": {SensitiveColumns: true},
+	}
+	for name, cfg := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewRedactor(nil, nil, cfg); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
 // ============================================================================
 // QueryLog Interceptor Tests
 // ============================================================================
@@ -631,6 +1276,10 @@ func TestInterfaceCompliance(t *testing.T) {
 	var _ tools.ToolMiddleware = (*MetricsMiddleware)(nil)
 	var _ tools.QueryInterceptor = (*ReadOnlyInterceptor)(nil)
 	var _ tools.QueryInterceptor = (*QueryLogInterceptor)(nil)
+	var _ tools.QueryInterceptor = (*AccessPolicy)(nil)
+	var _ tools.ObjectFilter = (*AccessPolicy)(nil)
+	var _ tools.QueryInterceptor = (*SensitiveColumnGuard)(nil)
+	var _ tools.QueryOutputTransformer = (*Redactor)(nil)
 	var _ tools.ResultTransformer = (*MetadataEnricher)(nil)
 	var _ tools.ResultTransformer = (*ErrorEnricher)(nil)
 	var _ MetricsCollector = (*InMemoryCollector)(nil)
@@ -3,9 +3,8 @@ package extensions
 import (
 	"context"
 	"errors"
-	"regexp"
-	"strings"
 
+	"github.com/txn2/mcp-trino/pkg/sqlparse"
 	"github.com/txn2/mcp-trino/pkg/tools"
 )
 
@@ -13,37 +12,17 @@ import (
 var ErrModificationBlocked = errors.New("modification statements are not allowed in read-only mode")
 
 // ReadOnlyInterceptor blocks modification statements.
-// It rejects INSERT, UPDATE, DELETE, DROP, CREATE, ALTER, TRUNCATE, and GRANT statements.
-type ReadOnlyInterceptor struct {
-	// blockedPatterns matches SQL statements that modify data or schema
-	blockedPatterns []*regexp.Regexp
-}
+// It rejects SQL that sqlparse does not classify as read-only: writes such as
+// INSERT, UPDATE, DELETE, DROP, CREATE, ALTER, TRUNCATE, MERGE, GRANT, CALL,
+// and EXECUTE, statements that change session state such as SET SESSION and
+// USE, and statements it cannot classify. Every statement of multi-statement
+// SQL is checked, and EXPLAIN ANALYZE of a write is blocked too, since it
+// runs the statement it explains.
+type ReadOnlyInterceptor struct{}
 
 // NewReadOnlyInterceptor creates a new read-only interceptor.
 func NewReadOnlyInterceptor() *ReadOnlyInterceptor {
-	// Patterns to detect modification statements
-	// These match at the beginning of the statement (after optional whitespace)
-	patterns := []string{
-		`(?i)^\s*INSERT\s+`,
-		`(?i)^\s*UPDATE\s+`,
-		`(?i)^\s*DELETE\s+`,
-		`(?i)^\s*DROP\s+`,
-		`(?i)^\s*CREATE\s+`,
-		`(?i)^\s*ALTER\s+`,
-		`(?i)^\s*TRUNCATE\s+`,
-		`(?i)^\s*GRANT\s+`,
-		`(?i)^\s*REVOKE\s+`,
-		`(?i)^\s*MERGE\s+`,
-	}
-
-	compiled := make([]*regexp.Regexp, len(patterns))
-	for i, p := range patterns {
-		compiled[i] = regexp.MustCompile(p)
-	}
-
-	return &ReadOnlyInterceptor{
-		blockedPatterns: compiled,
-	}
+	return &ReadOnlyInterceptor{}
 }
 
 // Intercept checks if the SQL is a modification statement and blocks it.
@@ -53,12 +32,8 @@ func (ri *ReadOnlyInterceptor) Intercept(_ context.Context, sql string, toolName
 		return sql, nil
 	}
 
-	// Check for blocked patterns
-	trimmed := strings.TrimSpace(sql)
-	for _, pattern := range ri.blockedPatterns {
-		if pattern.MatchString(trimmed) {
-			return "", ErrModificationBlocked
-		}
+	if !sqlparse.IsReadOnly(sql) {
+		return "", ErrModificationBlocked
 	}
 
 	return sql, nil
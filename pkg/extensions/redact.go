@@ -0,0 +1,304 @@
+package extensions
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/txn2/mcp-trino/pkg/semantic"
+	"github.com/txn2/mcp-trino/pkg/sqlparse"
+	"github.com/txn2/mcp-trino/pkg/tools"
+)
+
+// Masking strategies for redacted values.
+const (
+	// MaskHash replaces a value with a hash, so equal values stay equal and
+	// can still be grouped or joined on.
+	MaskHash = "hash"
+
+	// MaskPartial replaces all but the last four characters with *.
+	MaskPartial = "partial"
+
+	// MaskNull replaces the whole value with null.
+	MaskNull = "null"
+)
+
+// redactionDetectors are the built-in value detectors by name.
+var redactionDetectors = map[string]struct {
+	pattern string
+	valid   func(string) bool
+}{
+	"email": {pattern: `[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`},
+	"phone": {pattern: `(?:\+\d{1,3}[ .-]?)?(?:\(\d{3}\)|\b\d{3})[ .-]?\d{3}[ .-]\d{4}\b`},
+	"card":  {pattern: `\b\d(?:[ -]?\d){12,18}\b`, valid: luhnValid},
+	"ssn":   {pattern: `\b\d{3}-\d{2}-\d{4}\b`},
+}
+
+// RedactionConfig configures a Redactor.
+type RedactionConfig struct {
+	// SensitiveColumns masks result columns that the semantic provider marks
+	// sensitive in the tables the query reads.
+	SensitiveColumns bool `json:"sensitive_columns,omitempty" yaml:"sensitive_columns,omitempty"`
+
+	// Strategy masks sensitive columns and detector matches without a
+	// strategy of their own: MaskHash (the default), MaskPartial, or MaskNull.
+	Strategy string `json:"strategy,omitempty" yaml:"strategy,omitempty"`
+
+	// Detectors names built-in value detectors: email, phone, card, ssn.
+	Detectors []string `json:"detectors,omitempty" yaml:"detectors,omitempty"`
+
+	// Patterns are custom value detectors.
+	Patterns []RedactionPattern `json:"patterns,omitempty" yaml:"patterns,omitempty"`
+
+	// HashKey keys MaskHash with HMAC-SHA256. Without a key, hashes of
+	// values with few possibilities, such as SSNs, can be reversed by
+	// hashing every candidate.
+	HashKey string `json:"hash_key,omitempty" yaml:"hash_key,omitempty"`
+}
+
+// RedactionPattern is a custom value detector.
+type RedactionPattern struct {
+	// Name identifies the pattern in errors.
+	Name string `json:"name" yaml:"name"`
+
+	// Regex matches the text to mask, in RE2 syntax.
+	Regex string `json:"regex" yaml:"regex"`
+
+	// Strategy overrides RedactionConfig.Strategy for matches.
+	Strategy string `json:"strategy,omitempty" yaml:"strategy,omitempty"`
+}
+
+// detector masks the matches of a pattern in string values.
+type detector struct {
+	re       *regexp.Regexp
+	valid    func(string) bool // nil accepts every match
+	strategy string
+}
+
+// Redactor is a query output transformer that masks values in the
+// structured result of trino_query and trino_execute before it is
+// formatted, so JSON, CSV, and markdown output and the structured output
+// are masked alike.
+//
+// It masks whole columns the semantic provider marks sensitive, matched by
+// result column name against the columns of the tables the query reads,
+// and the parts of string values, including strings nested in arrays, maps,
+// and rows, that detectors match. A column renamed with AS is not matched;
+// pair the redactor with a SensitiveColumnGuard to stop such queries.
+// Lookup failures fail the tool call rather than return unmasked rows.
+type Redactor struct {
+	provider  semantic.Provider
+	tables    TableDescriber
+	columns   bool
+	strategy  string
+	detectors []detector
+	hashKey   []byte
+}
+
+// NewRedactor creates a redactor. provider and tables are only needed, and
+// then required, for SensitiveColumns. It returns an error for an unknown
+// strategy or detector, or a pattern that does not compile.
+func NewRedactor(provider semantic.Provider, tables TableDescriber, cfg RedactionConfig) (*Redactor, error) {
+	if cfg.SensitiveColumns && (provider == nil || tables == nil) {
+		return nil, errors.New("redaction: sensitive_columns requires a semantic provider and table describer")
+	}
+
+	r := &Redactor{
+		provider: provider,
+		tables:   tables,
+		columns:  cfg.SensitiveColumns,
+		strategy: cfg.Strategy,
+		hashKey:  []byte(cfg.HashKey),
+	}
+	if r.strategy == "" {
+		r.strategy = MaskHash
+	}
+	if err := checkStrategy(r.strategy); err != nil {
+		return nil, fmt.Errorf("redaction: %w", err)
+	}
+
+	for _, name := range cfg.Detectors {
+		d, ok := redactionDetectors[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("redaction: unknown detector %q (must be email, phone, card, or ssn)", name)
+		}
+		r.detectors = append(r.detectors, detector{re: regexp.MustCompile(d.pattern), valid: d.valid, strategy: r.strategy})
+	}
+	for _, p := range cfg.Patterns {
+		re, err := regexp.Compile(p.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("redaction: pattern %q: %w", p.Name, err)
+		}
+		strategy := p.Strategy
+		if strategy == "" {
+			strategy = r.strategy
+		}
+		if err := checkStrategy(strategy); err != nil {
+			return nil, fmt.Errorf("redaction: pattern %q: %w", p.Name, err)
+		}
+		r.detectors = append(r.detectors, detector{re: re, strategy: strategy})
+	}
+	return r, nil
+}
+
+func checkStrategy(strategy string) error {
+	switch strategy {
+	case MaskHash, MaskPartial, MaskNull:
+		return nil
+	}
+	return fmt.Errorf("invalid strategy %q (must be %q, %q, or %q)", strategy, MaskHash, MaskPartial, MaskNull)
+}
+
+// TransformQueryOutput implements tools.QueryOutputTransformer.
+func (r *Redactor) TransformQueryOutput(ctx context.Context, _ tools.ToolName, sql string, output *tools.QueryOutput) error {
+	masked := make(map[string]bool)
+	if r.columns {
+		sensitive, err := r.sensitiveColumns(ctx, sql)
+		if err != nil {
+			return err
+		}
+		for i, c := range output.Columns {
+			if !sensitive[strings.ToLower(c.Name)] {
+				continue
+			}
+			masked[c.Name] = true
+			if r.strategy != MaskNull {
+				output.Columns[i].Type = "varchar"
+			}
+		}
+	}
+	if len(masked) == 0 && len(r.detectors) == 0 {
+		return nil
+	}
+
+	for _, row := range output.Rows {
+		for name, v := range row {
+			if masked[name] {
+				row[name] = r.maskValue(r.strategy, v)
+			} else {
+				row[name] = r.redact(v)
+			}
+		}
+	}
+	return nil
+}
+
+// sensitiveColumns returns the lower-case names of the sensitive columns of
+// the tables sql reads.
+func (r *Redactor) sensitiveColumns(ctx context.Context, sql string) (map[string]bool, error) {
+	statements, _ := sqlparse.Classify(sql) // the statement already ran, so it is complete
+	sensitive := make(map[string]bool)
+	for _, s := range statements {
+		for _, name := range s.Tables {
+			table, _, err := resolveTable(ctx, r.tables, name, false)
+			if err != nil {
+				return nil, err
+			}
+			columns, err := r.provider.GetColumnsContext(ctx, table)
+			if err != nil {
+				return nil, fmt.Errorf("cannot check sensitive columns of %s: %w", table, err)
+			}
+			for column, c := range columns {
+				if c != nil && c.IsSensitive {
+					sensitive[strings.ToLower(column)] = true
+				}
+			}
+		}
+	}
+	return sensitive, nil
+}
+
+// redact masks detector matches in v, descending into arrays and maps.
+func (r *Redactor) redact(v any) any {
+	switch val := v.(type) {
+	case string:
+		return r.redactString(val)
+	case []any:
+		for i := range val {
+			val[i] = r.redact(val[i])
+		}
+	case map[string]any:
+		for k := range val {
+			val[k] = r.redact(val[k])
+		}
+	}
+	return v
+}
+
+// redactString masks detector matches in s. A match masked with MaskNull
+// nulls the whole value.
+func (r *Redactor) redactString(s string) any {
+	for _, d := range r.detectors {
+		null := false
+		s = d.re.ReplaceAllStringFunc(s, func(match string) string {
+			if d.valid != nil && !d.valid(match) {
+				return match
+			}
+			if d.strategy == MaskNull {
+				null = true
+				return match
+			}
+			return r.mask(d.strategy, match)
+		})
+		if null {
+			return nil
+		}
+	}
+	return s
+}
+
+// maskValue masks a whole value; non-string values are masked as text.
+func (r *Redactor) maskValue(strategy string, v any) any {
+	if v == nil || strategy == MaskNull {
+		return nil
+	}
+	s, ok := v.(string)
+	if !ok {
+		s = fmt.Sprint(v)
+	}
+	return r.mask(strategy, s)
+}
+
+// mask masks s with MaskHash or MaskPartial.
+func (r *Redactor) mask(strategy, s string) string {
+	if strategy == MaskHash {
+		h := hmac.New(sha256.New, r.hashKey)
+		h.Write([]byte(s))
+		return hex.EncodeToString(h.Sum(nil))[:16]
+	}
+	runes := []rune(s)
+	if len(runes) <= 4 {
+		return strings.Repeat("*", len(runes))
+	}
+	return strings.Repeat("*", len(runes)-4) + string(runes[len(runes)-4:])
+}
+
+// luhnValid reports whether the digits of s pass the Luhn checksum that
+// card numbers carry.
+func luhnValid(s string) bool {
+	sum, n := 0, 0
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if n%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		n++
+	}
+	return n >= 13 && sum%10 == 0
+}
+
+// Verify Redactor implements QueryOutputTransformer.
+var _ tools.QueryOutputTransformer = (*Redactor)(nil)
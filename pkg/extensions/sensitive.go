@@ -0,0 +1,305 @@
+package extensions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/txn2/mcp-trino/pkg/client"
+	"github.com/txn2/mcp-trino/pkg/multiserver"
+	"github.com/txn2/mcp-trino/pkg/semantic"
+	"github.com/txn2/mcp-trino/pkg/sqlparse"
+	"github.com/txn2/mcp-trino/pkg/tools"
+)
+
+// ErrSensitiveColumns is returned when a query reads columns the semantic
+// provider marks sensitive at or above the guarded level.
+var ErrSensitiveColumns = errors.New("query reads sensitive columns")
+
+// Sensitive column guard modes.
+const (
+	// SensitiveBlock rejects queries that read guarded columns.
+	SensitiveBlock = "block"
+
+	// SensitiveAcknowledge runs queries that read guarded columns only when
+	// the caller sets acknowledge_sensitive.
+	SensitiveAcknowledge = "acknowledge"
+)
+
+// DefaultSensitivityLevels are the sensitivity levels used when none are
+// configured, from least to most sensitive.
+var DefaultSensitivityLevels = []string{"public", "internal", "confidential", "restricted"}
+
+// SensitiveColumnConfig configures a SensitiveColumnGuard.
+type SensitiveColumnConfig struct {
+	// Mode is SensitiveBlock (the default) or SensitiveAcknowledge.
+	Mode string `json:"mode,omitempty" yaml:"mode,omitempty"`
+
+	// MinLevel is the lowest sensitivity level guarded. Empty guards every
+	// column marked sensitive.
+	MinLevel string `json:"min_level,omitempty" yaml:"min_level,omitempty"`
+
+	// Levels orders the sensitivity levels from least to most sensitive,
+	// ignoring case. Default: DefaultSensitivityLevels. A sensitive column
+	// without a level, or with one not listed, counts as most sensitive.
+	Levels []string `json:"levels,omitempty" yaml:"levels,omitempty"`
+}
+
+// TableDescriber describes a table on a named connection, "" being the
+// default connection. An empty catalog or schema is the connection's
+// default.
+type TableDescriber interface {
+	DescribeTable(ctx context.Context, connection, catalog, schema, table string) (*client.TableInfo, error)
+}
+
+// TableDescriberFunc allows using a function as a TableDescriber.
+type TableDescriberFunc func(ctx context.Context, connection, catalog, schema, table string) (*client.TableInfo, error)
+
+// DescribeTable implements TableDescriber.
+func (f TableDescriberFunc) DescribeTable(
+	ctx context.Context, connection, catalog, schema, table string,
+) (*client.TableInfo, error) {
+	return f(ctx, connection, catalog, schema, table)
+}
+
+// ManagerDescriber returns a TableDescriber that uses the manager's
+// client for each connection.
+func ManagerDescriber(mgr *multiserver.Manager) TableDescriber {
+	return TableDescriberFunc(func(ctx context.Context, connection, catalog, schema, table string) (*client.TableInfo, error) {
+		c, err := mgr.Client(connection)
+		if err != nil {
+			return nil, err
+		}
+		return c.DescribeTable(ctx, catalog, schema, table)
+	})
+}
+
+// SensitiveColumnGuard is a query interceptor that stops queries reading
+// columns the semantic provider marks sensitive (ColumnContext.IsSensitive)
+// at or above a configured sensitivity level. Depending on its mode it
+// blocks such queries, or asks the caller to confirm with
+// acknowledge_sensitive.
+//
+// A column is read wherever a statement names it, not only in the select
+// list, since a filter or join on a column reveals its values too. SELECT *,
+// t.*, and TABLE t are expanded with DescribeTable. Table functions and
+// EXECUTE read columns the guard cannot see, so they are treated as
+// reading guarded columns. EXPLAIN and trino_explain return no rows and
+// are not guarded, but EXPLAIN ANALYZE runs its statement and is.
+// Lookup failures reject the query rather than let it run unchecked.
+type SensitiveColumnGuard struct {
+	provider    semantic.Provider
+	tables      TableDescriber
+	acknowledge bool
+	levels      map[string]int
+	minLevel    int
+}
+
+// NewSensitiveColumnGuard creates a guard that reads column sensitivity
+// from provider and expands SELECT * with tables. It returns an error for
+// an unknown mode or a MinLevel that is not one of the levels.
+func NewSensitiveColumnGuard(
+	provider semantic.Provider, tables TableDescriber, cfg SensitiveColumnConfig,
+) (*SensitiveColumnGuard, error) {
+	if provider == nil || tables == nil {
+		return nil, errors.New("sensitive columns: a semantic provider and table describer are required")
+	}
+
+	g := &SensitiveColumnGuard{provider: provider, tables: tables}
+	switch cfg.Mode {
+	case "", SensitiveBlock:
+	case SensitiveAcknowledge:
+		g.acknowledge = true
+	default:
+		return nil, fmt.Errorf("sensitive columns: invalid mode %q (must be %q or %q)",
+			cfg.Mode, SensitiveBlock, SensitiveAcknowledge)
+	}
+
+	levels := cfg.Levels
+	if len(levels) == 0 {
+		levels = DefaultSensitivityLevels
+	}
+	g.levels = make(map[string]int, len(levels))
+	for i, level := range levels {
+		g.levels[strings.ToLower(level)] = i
+	}
+	if cfg.MinLevel != "" {
+		rank, ok := g.levels[strings.ToLower(cfg.MinLevel)]
+		if !ok {
+			return nil, fmt.Errorf("sensitive columns: min_level %q is not one of %s",
+				cfg.MinLevel, strings.Join(levels, ", "))
+		}
+		g.minLevel = rank
+	}
+	return g, nil
+}
+
+// Intercept rejects SQL that reads guarded columns, unless the guard asks
+// for acknowledgement and the caller gave it.
+func (g *SensitiveColumnGuard) Intercept(ctx context.Context, sql string, toolName tools.ToolName) (string, error) {
+	if toolName == tools.ToolExplain {
+		return sql, nil
+	}
+	statements, _ := sqlparse.Classify(sql) // Trino rejects an unterminated quote, so the text before it is all that matters
+	var found []string
+	for _, s := range statements {
+		if s.Kind == sqlparse.KindExplain && !s.Analyze {
+			continue
+		}
+		found = append(found, uncheckedSources(s)...)
+		if len(s.Tables) == 0 {
+			continue
+		}
+		columns, err := g.sensitiveColumns(ctx, s)
+		if err != nil {
+			return "", err
+		}
+		found = append(found, columns...)
+	}
+	if len(found) == 0 {
+		return sql, nil
+	}
+
+	slices.Sort(found)
+	found = slices.Compact(found)
+	if !g.acknowledge {
+		return "", fmt.Errorf("%w: %s", ErrSensitiveColumns, strings.Join(found, ", "))
+	}
+	if !tools.GetSensitiveAcknowledged(ctx) {
+		return "", fmt.Errorf("%w: %s; set acknowledge_sensitive to true to run it anyway",
+			ErrSensitiveColumns, strings.Join(found, ", "))
+	}
+	return sql, nil
+}
+
+// sensitiveColumns returns the guarded columns a statement reads, each as
+// catalog.schema.table.column followed by its level.
+func (g *SensitiveColumnGuard) sensitiveColumns(ctx context.Context, s sqlparse.Statement) ([]string, error) {
+	names, star := columnRefs(s.SQL)
+	var found []string
+	for _, name := range s.Tables {
+		table, starColumns, err := resolveTable(ctx, g.tables, name, star)
+		if err != nil {
+			return nil, err
+		}
+		columns, err := g.provider.GetColumnsContext(ctx, table)
+		if err != nil {
+			return nil, fmt.Errorf("cannot check sensitive columns of %s: %w", table, err)
+		}
+		for column, c := range columns {
+			if c == nil || !c.IsSensitive || g.rank(c.SensitivityLevel) < g.minLevel {
+				continue
+			}
+			column = strings.ToLower(column)
+			if !names[column] && !starColumns[column] {
+				continue
+			}
+			ref := table.String() + "." + column
+			if c.SensitivityLevel != "" {
+				ref += " (" + c.SensitivityLevel + ")"
+			}
+			found = append(found, ref)
+		}
+	}
+	return found, nil
+}
+
+// uncheckedSources returns the sources of a statement whose columns
+// cannot be checked: the table functions it invokes, and the statement
+// itself if Classify cannot resolve its tables, as for EXECUTE.
+func uncheckedSources(s sqlparse.Statement) []string {
+	var sources []string
+	for _, function := range s.TableFunctions {
+		sources = append(sources, "table function "+function+" (columns unknown)")
+	}
+	if s.Unresolved {
+		keyword, _, _ := strings.Cut(s.SQL, " ")
+		sources = append(sources, strings.ToUpper(keyword)+" statement (columns unknown)")
+	}
+	return sources
+}
+
+// resolveTable qualifies a table name as written in SQL with the tool
+// call's catalog and schema. When the connection's defaults are needed, or
+// the statement selects *, it describes the table; for * it also returns
+// the table's lower-case column names.
+func resolveTable(
+	ctx context.Context, tables TableDescriber, name string, star bool,
+) (semantic.TableIdentifier, map[string]bool, error) {
+	parts := strings.Split(strings.ToLower(name), ".")
+	catalog, schema := tools.GetDefaultSchema(ctx)
+	catalog, schema = strings.ToLower(catalog), strings.ToLower(schema)
+	switch n := len(parts); {
+	case n >= 3:
+		catalog, schema = parts[n-3], parts[n-2]
+	case n == 2:
+		schema = parts[0]
+	}
+	table := semantic.TableIdentifier{
+		Connection: tools.GetConnection(ctx),
+		Catalog:    catalog,
+		Schema:     schema,
+		Table:      parts[len(parts)-1],
+	}
+	if catalog != "" && schema != "" && !star {
+		return table, nil, nil
+	}
+
+	info, err := tables.DescribeTable(ctx, table.Connection, catalog, schema, table.Table)
+	if err != nil {
+		return table, nil, fmt.Errorf("cannot check sensitive columns of %s: %w", name, err)
+	}
+	table.Catalog, table.Schema = info.Catalog, info.Schema
+	if !star {
+		return table, nil, nil
+	}
+	columns := make(map[string]bool, len(info.Columns))
+	for _, c := range info.Columns {
+		columns[strings.ToLower(c.Name)] = true
+	}
+	return table, columns, nil
+}
+
+// rank returns the position of a sensitivity level among the configured
+// levels. A missing or unknown level ranks above them all.
+func (g *SensitiveColumnGuard) rank(level string) int {
+	if rank, ok := g.levels[strings.ToLower(level)]; ok {
+		return rank
+	}
+	return len(g.levels)
+}
+
+// tableDefinitionKeywords precede TABLE where it starts a statement about
+// a table, such as DROP TABLE t, rather than a TABLE t query.
+var tableDefinitionKeywords = map[string]bool{
+	"CREATE": true, "REPLACE": true, "ALTER": true, "DROP": true, "TRUNCATE": true, "ON": true,
+}
+
+// columnRefs returns the lower-case identifiers in a statement, among them
+// the columns it names, and whether it selects * or t.*, or reads a whole
+// table with TABLE t.
+func columnRefs(sql string) (names map[string]bool, star bool) {
+	tokens, _ := sqlparse.Scan(sql) //nolint:errcheck // Classify already split off the statement
+	names = make(map[string]bool)
+	for i, t := range tokens {
+		switch {
+		case t.Is("TABLE") && i+1 < len(tokens) && (tokens[i+1].Kind == sqlparse.Word || tokens[i+1].Kind == sqlparse.Quoted):
+			if i == 0 || tokens[i-1].Kind != sqlparse.Word || !tableDefinitionKeywords[strings.ToUpper(tokens[i-1].Text)] {
+				star = true
+			}
+		case t.Kind == sqlparse.Word || t.Kind == sqlparse.Quoted:
+			names[strings.ToLower(sqlparse.Identifier(t.Text))] = true
+		case t.IsSymbol("*") && i > 0:
+			prev := tokens[i-1]
+			if prev.Is("SELECT") || prev.Is("DISTINCT") || prev.Is("ALL") || prev.IsSymbol(",") || prev.IsSymbol(".") {
+				star = true
+			}
+		}
+	}
+	return names, star
+}
+
+// Verify SensitiveColumnGuard implements QueryInterceptor.
+var _ tools.QueryInterceptor = (*SensitiveColumnGuard)(nil)
@@ -16,28 +16,41 @@ const DefaultConnectionName = "database"
 // Fields that are empty/zero inherit from the primary connection.
 type ConnectionConfig struct {
 	// Host is the Trino server hostname (required for additional servers).
-	Host string `json:"host"`
+	Host string `json:"host" yaml:"host"`
 
 	// Port is the Trino server port. Defaults to 443 (SSL) or 8080 (non-SSL).
-	Port int `json:"port,omitempty"`
+	Port int `json:"port,omitempty" yaml:"port,omitempty"`
 
 	// User is the Trino username. Inherits from primary if empty.
-	User string `json:"user,omitempty"`
+	User string `json:"user,omitempty" yaml:"user,omitempty"`
 
 	// Password is the Trino password. Inherits from primary if empty.
-	Password string `json:"password,omitempty"`
+	Password string `json:"password,omitempty" yaml:"password,omitempty"`
 
 	// Catalog is the default catalog. Inherits from primary if empty.
-	Catalog string `json:"catalog,omitempty"`
+	Catalog string `json:"catalog,omitempty" yaml:"catalog,omitempty"`
 
 	// Schema is the default schema. Inherits from primary if empty.
-	Schema string `json:"schema,omitempty"`
+	Schema string `json:"schema,omitempty" yaml:"schema,omitempty"`
 
 	// SSL enables HTTPS. Nil means auto-detect based on host.
-	SSL *bool `json:"ssl,omitempty"`
+	SSL *bool `json:"ssl,omitempty" yaml:"ssl,omitempty"`
 
 	// SSLVerify enables SSL certificate verification. Inherits from primary if nil.
-	SSLVerify *bool `json:"ssl_verify,omitempty"`
+	SSLVerify *bool `json:"ssl_verify,omitempty" yaml:"ssl_verify,omitempty"`
+
+	// TLS customizes HTTPS for this connection, e.g. a CA bundle for a
+	// cluster behind a different internal CA. When set it replaces the
+	// primary's TLS settings entirely; nil inherits them.
+	TLS *client.TLSConfig `json:"tls,omitempty" yaml:"tls,omitempty"`
+
+	// Auth configures how this connection authenticates. When set it replaces
+	// the primary's auth settings entirely; nil inherits them.
+	Auth *client.AuthConfig `json:"auth,omitempty" yaml:"auth,omitempty"`
+
+	// Pool sizes this connection's connection pool. When set it replaces the
+	// primary's pool settings entirely; nil inherits them.
+	Pool *client.PoolConfig `json:"pool,omitempty" yaml:"pool,omitempty"`
 }
 
 // Config holds configuration for multiple Trino connections.
@@ -146,6 +159,15 @@ func (c Config) ClientConfig(name string) (client.Config, error) {
 	if conn.SSLVerify != nil {
 		cfg.SSLVerify = *conn.SSLVerify
 	}
+	if conn.TLS != nil {
+		cfg.TLS = *conn.TLS
+	}
+	if conn.Auth != nil {
+		cfg.Auth = *conn.Auth
+	}
+	if conn.Pool != nil {
+		cfg.Pool = *conn.Pool
+	}
 
 	return cfg, nil
 }
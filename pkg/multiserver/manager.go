@@ -80,6 +80,23 @@ func (m *Manager) Client(name string) (*client.Client, error) {
 	return c, nil
 }
 
+// PoolStats returns the connection pool statistics of the named
+// connection. It reports false if the connection's client has not been
+// created yet; looking up stats does not create it.
+func (m *Manager) PoolStats(name string) (client.PoolStats, bool) {
+	if name == "" {
+		name = m.config.Default
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	c, ok := m.clients[name]
+	if !ok {
+		return client.PoolStats{}, false
+	}
+	return c.PoolStats(), true
+}
+
 // DefaultClient returns the default (primary) connection's client.
 func (m *Manager) DefaultClient() (*client.Client, error) {
 	return m.Client(m.config.Default)
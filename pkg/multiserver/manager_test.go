@@ -3,6 +3,7 @@ package multiserver
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/txn2/mcp-trino/pkg/client"
 )
@@ -204,6 +205,150 @@ func TestConfig_ClientConfig(t *testing.T) {
 	}
 }
 
+func TestConfig_ClientConfig_AuthAndTLS(t *testing.T) {
+	cfg := Config{
+		Default: "default",
+		Primary: client.Config{
+			Host:     "prod.example.com",
+			User:     "admin",
+			Password: "secret",
+			Auth:     client.AuthConfig{Token: "primary-jwt"},
+			TLS:      client.TLSConfig{MinVersion: "1.3"},
+		},
+		Connections: map[string]ConnectionConfig{
+			"staging": {Host: "staging.example.com"},
+			"kerberized": {
+				Host: "kdc-trino.example.com",
+				TLS:  &client.TLSConfig{CAFile: "/etc/ssl/corp-ca.pem"},
+				Auth: &client.AuthConfig{
+					KerberosPrincipal: "mcp",
+					KerberosRealm:     "EXAMPLE.COM",
+					KerberosKeytab:    "/etc/mcp.keytab",
+				},
+			},
+		},
+	}
+
+	staging, err := cfg.ClientConfig("staging")
+	if err != nil {
+		t.Fatalf("ClientConfig(staging) error: %v", err)
+	}
+	if staging.Auth.ResolvedMethod(staging.Password) != client.AuthJWT {
+		t.Errorf("expected staging to inherit jwt auth, got %+v", staging.Auth)
+	}
+	if staging.TLS.MinVersion != "1.3" {
+		t.Errorf("expected staging to inherit TLS settings, got %+v", staging.TLS)
+	}
+
+	kerberized, err := cfg.ClientConfig("kerberized")
+	if err != nil {
+		t.Fatalf("ClientConfig(kerberized) error: %v", err)
+	}
+	if got := kerberized.Auth.ResolvedMethod(kerberized.Password); got != client.AuthKerberos {
+		t.Errorf("expected kerberos auth, got %q", got)
+	}
+	if kerberized.Auth.Token != "" {
+		t.Error("expected connection auth to replace the primary's entirely")
+	}
+	if kerberized.TLS != (client.TLSConfig{CAFile: "/etc/ssl/corp-ca.pem"}) {
+		t.Errorf("expected connection TLS to replace the primary's entirely, got %+v", kerberized.TLS)
+	}
+}
+
+func TestConfig_ClientConfig_Pool(t *testing.T) {
+	cfg := Config{
+		Default: "default",
+		Primary: client.Config{
+			Host: "prod.example.com",
+			User: "admin",
+			Pool: client.PoolConfig{MaxOpenConns: 50, ConnMaxLifetime: time.Hour},
+		},
+		Connections: map[string]ConnectionConfig{
+			"staging": {Host: "staging.example.com"},
+			"adhoc":   {Host: "adhoc.example.com", Pool: &client.PoolConfig{MaxOpenConns: 2}},
+		},
+	}
+
+	staging, err := cfg.ClientConfig("staging")
+	if err != nil {
+		t.Fatalf("ClientConfig(staging) error: %v", err)
+	}
+	if staging.Pool != cfg.Primary.Pool {
+		t.Errorf("expected staging to inherit pool settings, got %+v", staging.Pool)
+	}
+
+	adhoc, err := cfg.ClientConfig("adhoc")
+	if err != nil {
+		t.Fatalf("ClientConfig(adhoc) error: %v", err)
+	}
+	if adhoc.Pool != (client.PoolConfig{MaxOpenConns: 2}) {
+		t.Errorf("expected connection pool to replace the primary's entirely, got %+v", adhoc.Pool)
+	}
+}
+
+func TestFromEnv_AdditionalServerPool(t *testing.T) {
+	t.Setenv("TRINO_USER", "admin")
+	t.Setenv("TRINO_ADDITIONAL_SERVERS",
+		`{"shared": {"host": "shared.example.com", "pool": {"max_open_conns": 100, "conn_max_idle_time": "2m"}}}`)
+
+	cfg, err := FromEnv()
+	if err != nil {
+		t.Fatalf("FromEnv() error: %v", err)
+	}
+	shared, err := cfg.ClientConfig("shared")
+	if err != nil {
+		t.Fatalf("ClientConfig(shared) error: %v", err)
+	}
+	if shared.Pool.MaxOpenConns != 100 || shared.Pool.ConnMaxIdleTime != 2*time.Minute {
+		t.Errorf("unexpected shared pool: %+v", shared.Pool)
+	}
+}
+
+func TestManager_PoolStats(t *testing.T) {
+	mgr := NewManager(Config{
+		Default: "default",
+		Primary: client.Config{
+			Host: "localhost",
+			Port: 8080,
+			User: "admin",
+			Pool: client.PoolConfig{MaxOpenConns: 7},
+		},
+	})
+	defer func() { _ = mgr.Close() }()
+
+	if _, ok := mgr.PoolStats(""); ok {
+		t.Error("expected no pool stats before the client is created")
+	}
+	if _, err := mgr.Client(""); err != nil {
+		t.Fatalf("Client() error: %v", err)
+	}
+	stats, ok := mgr.PoolStats("default")
+	if !ok {
+		t.Fatal("expected pool stats once the client exists")
+	}
+	if stats.MaxOpenConnections != 7 {
+		t.Errorf("MaxOpenConnections = %d, want 7", stats.MaxOpenConnections)
+	}
+}
+
+func TestFromEnv_AdditionalServerAuth(t *testing.T) {
+	t.Setenv("TRINO_USER", "admin")
+	t.Setenv("TRINO_ADDITIONAL_SERVERS",
+		`{"partner": {"host": "partner.example.com", "auth": {"method": "jwt", "token_file": "/run/secrets/partner-token"}}}`)
+
+	cfg, err := FromEnv()
+	if err != nil {
+		t.Fatalf("FromEnv() error: %v", err)
+	}
+	partner, err := cfg.ClientConfig("partner")
+	if err != nil {
+		t.Fatalf("ClientConfig(partner) error: %v", err)
+	}
+	if partner.Auth.Method != client.AuthJWT || partner.Auth.TokenFile != "/run/secrets/partner-token" {
+		t.Errorf("unexpected partner auth: %+v", partner.Auth)
+	}
+}
+
 func TestConfig_ConnectionNames(t *testing.T) {
 	cfg := Config{
 		Default: "default",
@@ -0,0 +1,517 @@
+package sqlparse
+
+import (
+	"slices"
+	"strings"
+)
+
+// Kind is the kind of a SQL statement, named after its leading keyword.
+type Kind string
+
+// Statement kinds.
+const (
+	KindQuery       Kind = "query"       // SELECT, WITH ... SELECT, VALUES, TABLE
+	KindShow        Kind = "show"        // SHOW and DESCRIBE
+	KindExplain     Kind = "explain"     // EXPLAIN, including EXPLAIN ANALYZE
+	KindInsert      Kind = "insert"      // INSERT
+	KindUpdate      Kind = "update"      // UPDATE
+	KindDelete      Kind = "delete"      // DELETE
+	KindMerge       Kind = "merge"       // MERGE
+	KindCreate      Kind = "create"      // CREATE
+	KindAlter       Kind = "alter"       // ALTER, including ALTER TABLE ... EXECUTE
+	KindDrop        Kind = "drop"        // DROP
+	KindTruncate    Kind = "truncate"    // TRUNCATE
+	KindComment     Kind = "comment"     // COMMENT ON
+	KindRefresh     Kind = "refresh"     // REFRESH MATERIALIZED VIEW
+	KindAnalyze     Kind = "analyze"     // ANALYZE, which collects table statistics
+	KindGrant       Kind = "grant"       // GRANT, REVOKE, and DENY
+	KindCall        Kind = "call"        // CALL of a procedure
+	KindExecute     Kind = "execute"     // EXECUTE and EXECUTE IMMEDIATE
+	KindPrepare     Kind = "prepare"     // PREPARE and DEALLOCATE PREPARE
+	KindSession     Kind = "session"     // SET and RESET, e.g. SET SESSION, and USE
+	KindTransaction Kind = "transaction" // START TRANSACTION, COMMIT, and ROLLBACK
+	KindUnknown     Kind = "unknown"     // anything else
+)
+
+// kindsByKeyword maps the leading keyword of a statement to its kind.
+var kindsByKeyword = map[string]Kind{
+	"SELECT":     KindQuery,
+	"VALUES":     KindQuery,
+	"TABLE":      KindQuery,
+	"SHOW":       KindShow,
+	"DESCRIBE":   KindShow,
+	"EXPLAIN":    KindExplain,
+	"INSERT":     KindInsert,
+	"UPDATE":     KindUpdate,
+	"DELETE":     KindDelete,
+	"MERGE":      KindMerge,
+	"CREATE":     KindCreate,
+	"ALTER":      KindAlter,
+	"DROP":       KindDrop,
+	"TRUNCATE":   KindTruncate,
+	"COMMENT":    KindComment,
+	"REFRESH":    KindRefresh,
+	"ANALYZE":    KindAnalyze,
+	"GRANT":      KindGrant,
+	"REVOKE":     KindGrant,
+	"DENY":       KindGrant,
+	"CALL":       KindCall,
+	"EXECUTE":    KindExecute,
+	"PREPARE":    KindPrepare,
+	"DEALLOCATE": KindPrepare,
+	"SET":        KindSession,
+	"RESET":      KindSession,
+	"USE":        KindSession,
+	"START":      KindTransaction,
+	"COMMIT":     KindTransaction,
+	"ROLLBACK":   KindTransaction,
+}
+
+// Statement is a classified SQL statement.
+type Statement struct {
+	Kind Kind `json:"kind"`
+
+	// SQL is the text of the statement, without a terminating semicolon.
+	SQL string `json:"sql"`
+
+	// Tables lists the tables and views the statement reads or writes, as
+	// written but with identifiers resolved as by Identifier, e.g.
+	// hive.sales.orders. Names defined by WITH are not tables.
+	Tables []string `json:"tables,omitempty"`
+
+	// TableFunctions lists the table functions the statement invokes with
+	// TABLE(...), named like Tables, e.g. hive.system.query. What they read
+	// depends on their arguments.
+	TableFunctions []string `json:"table_functions,omitempty"`
+
+	// Unresolved reports whether the statement runs SQL that Tables cannot
+	// name: EXECUTE and EXECUTE IMMEDIATE, which run a prepared statement or
+	// a string, and statements of unknown kind. Checks on the objects SQL
+	// reaches should fail closed on it.
+	Unresolved bool `json:"unresolved,omitempty"`
+
+	// Mutates reports whether running the statement can change data,
+	// schema, privileges, or statistics. Statements of unknown kind and
+	// EXECUTE, whose effect depends on a prepared statement, count as
+	// mutating, so read-only checks fail closed.
+	Mutates bool `json:"mutates"`
+
+	// ChangesSession reports whether the statement changes session state:
+	// session properties, the current catalog and schema, roles, prepared
+	// statements, or transactions.
+	ChangesSession bool `json:"changes_session"`
+
+	// Explained is the kind of the statement an EXPLAIN explains, and
+	// Analyze is set for EXPLAIN ANALYZE, which runs it.
+	Explained Kind `json:"explained,omitempty"`
+	Analyze   bool `json:"analyze,omitempty"`
+}
+
+// ReadOnly reports whether the statement neither mutates anything nor
+// changes session state.
+func (s Statement) ReadOnly() bool {
+	return !s.Mutates && !s.ChangesSession
+}
+
+// Classify splits SQL text into statements on top-level semicolons and
+// classifies each. On an unterminated string, quoted identifier, or
+// comment it classifies the text before it and returns the error too.
+func Classify(sql string) ([]Statement, error) {
+	tokens, err := Scan(sql)
+	var statements []Statement
+	start := 0
+	for i := 0; i <= len(tokens); i++ {
+		if i < len(tokens) && !tokens[i].IsSymbol(";") {
+			continue
+		}
+		if i > start {
+			statements = append(statements, classify(sql, tokens[start:i]))
+		}
+		start = i + 1
+	}
+	return statements, err
+}
+
+// IsReadOnly reports whether every statement in sql is read-only. Empty
+// SQL is read-only.
+func IsReadOnly(sql string) bool {
+	statements, _ := Classify(sql) // what precedes an unterminated quote is all Trino could run
+	for _, s := range statements {
+		if !s.ReadOnly() {
+			return false
+		}
+	}
+	return true
+}
+
+// classify classifies the tokens of a single statement of sql.
+func classify(sql string, tokens []Token) Statement {
+	s := Statement{SQL: sql[tokens[0].Pos:tokens[len(tokens)-1].End()], Kind: statementKind(tokens)}
+	switch s.Kind {
+	case KindQuery, KindShow:
+	case KindExplain:
+		explained := Statement{Kind: KindUnknown, Mutates: true, Unresolved: true}
+		if inner := explainedStatement(tokens); len(inner) > 0 {
+			explained = classify(sql, inner)
+		}
+		s.Explained, s.Tables, s.TableFunctions = explained.Kind, explained.Tables, explained.TableFunctions
+		s.Unresolved = explained.Unresolved
+		s.Analyze = len(tokens) > 1 && tokens[1].Is("ANALYZE")
+		if s.Analyze {
+			s.Mutates, s.ChangesSession = explained.Mutates, explained.ChangesSession
+		}
+		return s
+	case KindSession, KindPrepare, KindTransaction:
+		s.ChangesSession = true
+	default:
+		s.Mutates = true
+		s.Unresolved = s.Kind == KindExecute || s.Kind == KindUnknown
+	}
+	s.Tables, s.TableFunctions = referencedTables(tokens, s.Kind)
+	return s
+}
+
+// statementKind returns the kind of a statement from its leading keyword,
+// looking past a WITH clause to the statement it prefixes.
+func statementKind(tokens []Token) Kind {
+	first := firstKeyword(tokens)
+	if first < 0 {
+		return KindUnknown
+	}
+	if tokens[first].IsSymbol("(") {
+		return KindQuery
+	}
+	if tokens[first].Kind != Word {
+		return KindUnknown
+	}
+	if kind, ok := kindsByKeyword[strings.ToUpper(tokens[first].Text)]; ok {
+		return kind
+	}
+	return KindUnknown
+}
+
+// firstKeyword returns the index of the token that determines a
+// statement's kind: its first token, or the first after a leading WITH
+// clause. It returns -1 for an incomplete WITH clause.
+func firstKeyword(tokens []Token) int {
+	if len(tokens) == 0 {
+		return -1
+	}
+	if !tokens[0].Is("WITH") {
+		return 0
+	}
+	if _, end := withClause(tokens, 0); end < len(tokens) {
+		return end
+	}
+	return -1
+}
+
+// withClause reads the WITH clause at tokens[i],
+//
+//	WITH [RECURSIVE] name [(columns)] AS (query) [, ...]
+//
+// and returns the names it defines and the index of the token after it.
+func withClause(tokens []Token, i int) (names []string, end int) {
+	j := i + 1
+	if j < len(tokens) && tokens[j].Is("RECURSIVE") {
+		j++
+	}
+	for j < len(tokens) && (tokens[j].Kind == Word || tokens[j].Kind == Quoted) {
+		names = append(names, Identifier(tokens[j].Text))
+		j++
+		if j < len(tokens) && tokens[j].IsSymbol("(") {
+			j = closingParen(tokens, j) + 1 // column list
+		}
+		if j < len(tokens) && tokens[j].Is("AS") {
+			j++
+		}
+		if j >= len(tokens) || !tokens[j].IsSymbol("(") {
+			return names, len(tokens)
+		}
+		j = closingParen(tokens, j) + 1
+		if j >= len(tokens) || !tokens[j].IsSymbol(",") {
+			return names, j
+		}
+		j++
+	}
+	return names, j
+}
+
+// explainedStatement returns the tokens of the statement an EXPLAIN
+// explains, after ANALYZE, VERBOSE, and the option list.
+func explainedStatement(tokens []Token) []Token {
+	i := 1
+	for i < len(tokens) && (tokens[i].Is("ANALYZE") || tokens[i].Is("VERBOSE")) {
+		i++
+	}
+	if i < len(tokens) && tokens[i].IsSymbol("(") {
+		// The option list, unless the statement is a parenthesized query
+		j := closingParen(tokens, i)
+		if i+1 < len(tokens) && !isQueryStart(tokens[i+1]) {
+			i = j + 1
+		}
+	}
+	if i >= len(tokens) {
+		return nil
+	}
+	return tokens[i:]
+}
+
+// isQueryStart reports whether a query can start with t.
+func isQueryStart(t Token) bool {
+	return t.Is("SELECT") || t.Is("WITH") || t.Is("VALUES") || t.Is("TABLE") || t.IsSymbol("(")
+}
+
+// closingParen returns the index of the parenthesis closing the one at
+// open, or the last index if it is not closed.
+func closingParen(tokens []Token, open int) int {
+	for j := open + 1; j < len(tokens); j++ {
+		if tokens[j].IsSymbol(")") && tokens[j].Depth == tokens[open].Depth {
+			return j
+		}
+	}
+	return len(tokens) - 1
+}
+
+// tableKeywords are the keywords that a table name follows, such as FROM
+// in queries or INTO in INSERT and MERGE.
+var tableKeywords = map[string]bool{
+	"FROM": true, "JOIN": true, "INTO": true, "UPDATE": true, "USING": true,
+	"TABLE": true, "VIEW": true,
+}
+
+// clauseKeywords end a FROM list: after them, a comma no longer separates
+// tables.
+var clauseKeywords = map[string]bool{
+	"WHERE": true, "GROUP": true, "HAVING": true, "ORDER": true, "LIMIT": true,
+	"OFFSET": true, "FETCH": true, "UNION": true, "EXCEPT": true, "INTERSECT": true,
+	"WINDOW": true, "ON": true, "SET": true, "WHEN": true, "SELECT": true,
+	"JOIN": true, "CROSS": true, "INNER": true, "LEFT": true, "RIGHT": true,
+	"FULL": true, "NATURAL": true,
+}
+
+// referencedTables returns the tables and the table functions a statement
+// names, in order of first appearance.
+func referencedTables(tokens []Token, kind Kind) (tables, functions []string) {
+	switch {
+	case kind == KindShow && !showStatsQuery(tokens):
+		return showTables(tokens), nil
+	case kind == KindGrant:
+		return grantTables(tokens), nil
+	}
+
+	ctes := cteNames(tokens)
+	// add adds the name at i. Where a table function may stand instead of
+	// a table, as after FROM, a name followed by ( is a function, and a
+	// parenthesized join starts with a table.
+	add := func(i int, functionsAllowed bool) {
+		for functionsAllowed && i+1 < len(tokens) && tokens[i].IsSymbol("(") && !isQueryStart(tokens[i+1]) {
+			i++
+		}
+		name, next, ok := qualifiedName(tokens, i)
+		if !ok || slices.Contains(tables, name) || ctes[name] {
+			return
+		}
+		if functionsAllowed && next < len(tokens) && tokens[next].IsSymbol("(") {
+			return
+		}
+		tables = append(tables, name)
+	}
+
+	if kind == KindAnalyze {
+		add(1, false)
+	}
+
+	inQuery := queryScopes(tokens)
+	var fromLists []int // depths of the FROM lists being read, innermost last
+	for i, t := range tokens {
+		if !inQuery[i] {
+			continue
+		}
+		for len(fromLists) > 0 && t.Depth < fromLists[len(fromLists)-1] {
+			fromLists = fromLists[:len(fromLists)-1]
+		}
+		if n := len(fromLists); n > 0 && t.Depth == fromLists[n-1] {
+			switch {
+			case t.IsSymbol(","):
+				add(i+1, true)
+			case t.Kind == Word && clauseKeywords[strings.ToUpper(t.Text)]:
+				fromLists = fromLists[:n-1]
+			}
+		}
+
+		if t.Kind != Word || !tableKeywords[strings.ToUpper(t.Text)] {
+			continue
+		}
+		if t.Is("TABLE") && i+1 < len(tokens) && tokens[i+1].IsSymbol("(") {
+			// TABLE(fn(...)) invokes a table function; TABLE(t) is a table
+			// argument of one
+			name, next, ok := qualifiedName(tokens, i+2)
+			if ok && next < len(tokens) && tokens[next].IsSymbol("(") {
+				if !slices.Contains(functions, name) {
+					functions = append(functions, name)
+				}
+			} else {
+				add(i+2, false)
+			}
+			continue
+		}
+		if t.Is("FROM") && i > 0 && tokens[i-1].Is("DISTINCT") {
+			continue // IS DISTINCT FROM
+		}
+		if t.Is("FROM") {
+			fromLists = append(fromLists, t.Depth)
+		}
+		add(skipIfExists(tokens, i+1), t.Is("FROM") || t.Is("JOIN"))
+	}
+	return tables, functions
+}
+
+// showStatsQuery reports whether a SHOW statement is SHOW STATS FOR
+// (query), whose query names tables like any other.
+func showStatsQuery(tokens []Token) bool {
+	return len(tokens) > 3 && tokens[1].Is("STATS") && tokens[2].Is("FOR") && tokens[3].IsSymbol("(")
+}
+
+// showTables returns the table a SHOW or DESCRIBE statement is about, for
+// SHOW COLUMNS, SHOW CREATE, SHOW STATS, and DESCRIBE.
+func showTables(tokens []Token) []string {
+	name := func(i int) []string {
+		if n, _, ok := qualifiedName(tokens, i); ok {
+			return []string{n}
+		}
+		return nil
+	}
+	switch {
+	case len(tokens) < 2:
+		return nil
+	case tokens[0].Is("DESCRIBE"):
+		if tokens[1].Is("INPUT") || tokens[1].Is("OUTPUT") {
+			return nil
+		}
+		return name(1)
+	case tokens[1].Is("COLUMNS") && len(tokens) > 2:
+		return name(3)
+	case tokens[1].Is("CREATE") && len(tokens) > 2:
+		if tokens[2].Is("MATERIALIZED") {
+			return name(4)
+		}
+		if tokens[2].Is("TABLE") || tokens[2].Is("VIEW") {
+			return name(3)
+		}
+	case tokens[1].Is("STATS") && len(tokens) > 2 && tokens[2].Is("FOR"):
+		return name(3)
+	}
+	return nil
+}
+
+// grantTables returns the table a GRANT, REVOKE, or DENY statement is
+// about. Privileges on a schema name no table.
+func grantTables(tokens []Token) []string {
+	i := slices.IndexFunc(tokens, func(t Token) bool { return t.Is("ON") })
+	if i < 0 || i+1 >= len(tokens) || tokens[i+1].Is("SCHEMA") {
+		return nil
+	}
+	i++
+	if tokens[i].Is("TABLE") {
+		i++
+	}
+	if name, _, ok := qualifiedName(tokens, i); ok {
+		return []string{name}
+	}
+	return nil
+}
+
+// queryScopes reports for each token whether it is outside parentheses or
+// inside a parenthesized query, where FROM and JOIN name tables, rather
+// than inside a function call such as EXTRACT(YEAR FROM ts).
+func queryScopes(tokens []Token) []bool {
+	in := make([]bool, len(tokens))
+	scopes := []bool{true} // one per depth
+	for i, t := range tokens {
+		if t.Depth+1 < len(scopes) {
+			scopes = scopes[:t.Depth+1]
+		}
+		in[i] = scopes[len(scopes)-1]
+		if t.IsSymbol("(") {
+			// A subquery, a parenthesized join such as FROM (a JOIN b ON ...),
+			// or a table function call and its arguments, which may be tables
+			query := i+1 < len(tokens) && isQueryStart(tokens[i+1]) ||
+				i > 0 && (tokens[i-1].Is("FROM") || tokens[i-1].Is("JOIN") || tokens[i-1].Is("TABLE")) ||
+				tableFunctionArgs(tokens, i)
+			scopes = append(scopes, query)
+		}
+	}
+	return in
+}
+
+// tableFunctionArgs reports whether the ( at open starts the arguments of
+// a table function, as in TABLE(catalog.schema.fn(...)).
+func tableFunctionArgs(tokens []Token, open int) bool {
+	i := open - 1
+	for i >= 2 && tokens[i-1].IsSymbol(".") {
+		i -= 2
+	}
+	return i >= 2 && (tokens[i].Kind == Word || tokens[i].Kind == Quoted) &&
+		tokens[i-1].IsSymbol("(") && tokens[i-2].Is("TABLE")
+}
+
+// cteNames returns the names defined by the WITH clauses of a statement.
+// WITH followed by ( starts table properties, not a WITH clause.
+func cteNames(tokens []Token) map[string]bool {
+	names := make(map[string]bool)
+	for i, t := range tokens {
+		if t.Is("WITH") && i+1 < len(tokens) && !tokens[i+1].IsSymbol("(") {
+			defined, _ := withClause(tokens, i)
+			for _, name := range defined {
+				names[name] = true
+			}
+		}
+	}
+	return names
+}
+
+// skipIfExists returns the index after IF EXISTS or IF NOT EXISTS at i,
+// or i.
+func skipIfExists(tokens []Token, i int) int {
+	if i < len(tokens) && tokens[i].Is("IF") {
+		i++
+		if i < len(tokens) && tokens[i].Is("NOT") {
+			i++
+		}
+		if i < len(tokens) && tokens[i].Is("EXISTS") {
+			i++
+		}
+	}
+	return i
+}
+
+// qualifiedName reads a possibly qualified name, such as
+// catalog.schema.table, at i. It returns the name with its identifiers
+// resolved, the index after it, and false if there is none.
+func qualifiedName(tokens []Token, i int) (name string, next int, ok bool) {
+	var parts []string
+	for i < len(tokens) && (tokens[i].Kind == Word || tokens[i].Kind == Quoted) {
+		if tokens[i].Kind == Word && nonNames[strings.ToUpper(tokens[i].Text)] {
+			return "", i, false
+		}
+		parts = append(parts, Identifier(tokens[i].Text))
+		i++
+		if i >= len(tokens) || !tokens[i].IsSymbol(".") {
+			break
+		}
+		i++
+	}
+	if len(parts) == 0 {
+		return "", i, false
+	}
+	return strings.Join(parts, "."), i, true
+}
+
+// nonNames are keywords that can follow a table keyword without a table
+// name, e.g. LATERAL in FROM LATERAL (...) or SET in MERGE's THEN UPDATE
+// SET.
+var nonNames = map[string]bool{
+	"SELECT": true, "VALUES": true, "WITH": true, "LATERAL": true, "UNNEST": true,
+	"SET": true,
+}
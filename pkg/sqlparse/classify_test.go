@@ -0,0 +1,228 @@
+package sqlparse
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name       string
+		sql        string
+		kind       Kind
+		tables     []string
+		functions  []string
+		unresolved bool
+		mutates    bool
+		session    bool
+		readOnly   bool
+	}{
+		// Queries
+		{name: "select", sql: "SELECT * FROM orders", kind: KindQuery, tables: []string{"orders"}, readOnly: true},
+		{name: "decimal before keyword", sql: "SELECT 1.0from t", kind: KindQuery, tables: []string{"t"}, readOnly: true},
+		{name: "fraction before keyword", sql: "SELECT .5from t", kind: KindQuery, tables: []string{"t"}, readOnly: true},
+		{name: "exponent before keyword", sql: "SELECT 1e5from t", kind: KindQuery, tables: []string{"t"}, readOnly: true},
+		{
+			name: "qualified and quoted", sql: `SELECT * FROM Hive.Sales."Order Items" o JOIN hive.sales.customer c ON o.c = c.id`,
+			kind: KindQuery, tables: []string{`hive.sales.Order Items`, "hive.sales.customer"}, readOnly: true,
+		},
+		{
+			name: "comma list and subquery", sql: "SELECT * FROM a, (SELECT * FROM b) x, c WHERE a.id IN (SELECT id FROM d)",
+			kind: KindQuery, tables: []string{"a", "b", "c", "d"}, readOnly: true,
+		},
+		{
+			name: "cte names are not tables",
+			sql:  "WITH recent AS (SELECT * FROM orders), top (id) AS (SELECT 1) SELECT * FROM recent JOIN top USING (id)",
+			kind: KindQuery, tables: []string{"orders"}, readOnly: true,
+		},
+		{
+			name: "functions are not tables",
+			sql:  "SELECT extract(YEAR FROM ts), trim(BOTH FROM s) FROM t CROSS JOIN UNNEST(tags) AS u(tag) WHERE a IS DISTINCT FROM b",
+			kind: KindQuery, tables: []string{"t"}, readOnly: true,
+		},
+		{
+			name: "table function", sql: "SELECT * FROM TABLE(system.sequence(start => 1, stop => 5))",
+			kind: KindQuery, functions: []string{"system.sequence"}, readOnly: true,
+		},
+		{
+			name: "table function with query string", sql: "SELECT * FROM TABLE(pii.system.query(query => 'SELECT * FROM hr.employees'))",
+			kind: KindQuery, functions: []string{"pii.system.query"}, readOnly: true,
+		},
+		{
+			name: "table function with table arguments",
+			sql: "SELECT * FROM a JOIN TABLE(exclude_columns(input => TABLE(b), columns => DESCRIPTOR(x))) f ON true, " +
+				"TABLE(fn(input => TABLE(SELECT * FROM c)))",
+			kind: KindQuery, tables: []string{"a", "b", "c"}, functions: []string{"exclude_columns", "fn"}, readOnly: true,
+		},
+		{
+			name: "match_recognize in comma list",
+			sql:  "SELECT * FROM a MATCH_RECOGNIZE (PARTITION BY x, y ORDER BY z PATTERN (A B*) DEFINE B AS B.v > A.v) m, b",
+			kind: KindQuery, tables: []string{"a", "b"}, readOnly: true,
+		},
+		{name: "parenthesized join", sql: "SELECT * FROM (a JOIN b ON a.id = b.id)", kind: KindQuery, tables: []string{"a", "b"}, readOnly: true},
+		{name: "parenthesized query", sql: "(SELECT 1)", kind: KindQuery, readOnly: true},
+		{name: "values", sql: "VALUES (1, 'a')", kind: KindQuery, readOnly: true},
+		{name: "table", sql: "TABLE orders", kind: KindQuery, tables: []string{"orders"}, readOnly: true},
+		{
+			name: "keyword in string", sql: "SELECT * FROM t WHERE s = 'DELETE FROM x; DROP TABLE y'",
+			kind: KindQuery, tables: []string{"t"}, readOnly: true,
+		},
+		{name: "comments", sql: "-- DROP TABLE x\n/* INSERT */ SELECT 1", kind: KindQuery, readOnly: true},
+
+		// Metadata
+		{name: "show tables", sql: "SHOW TABLES FROM hive.sales", kind: KindShow, readOnly: true},
+		{name: "show columns", sql: "SHOW COLUMNS FROM hive.sales.orders", kind: KindShow, tables: []string{"hive.sales.orders"}, readOnly: true},
+		{name: "show create mv", sql: "SHOW CREATE MATERIALIZED VIEW mv", kind: KindShow, tables: []string{"mv"}, readOnly: true},
+		{name: "describe", sql: "DESCRIBE hive.sales.orders", kind: KindShow, tables: []string{"hive.sales.orders"}, readOnly: true},
+		{name: "describe input", sql: "DESCRIBE INPUT stmt", kind: KindShow, readOnly: true},
+		{name: "show stats", sql: "SHOW STATS FOR hive.sales.orders", kind: KindShow, tables: []string{"hive.sales.orders"}, readOnly: true},
+		{
+			name: "show stats for query", sql: "SHOW STATS FOR (SELECT * FROM pii.hr.employees WHERE id IN (SELECT id FROM t))",
+			kind: KindShow, tables: []string{"pii.hr.employees", "t"}, readOnly: true,
+		},
+
+		// EXPLAIN
+		{name: "explain", sql: "EXPLAIN (TYPE DISTRIBUTED) SELECT * FROM t", kind: KindExplain, tables: []string{"t"}, readOnly: true},
+		{name: "explain write", sql: "EXPLAIN DELETE FROM t", kind: KindExplain, tables: []string{"t"}, readOnly: true},
+		{name: "explain analyze", sql: "EXPLAIN ANALYZE SELECT * FROM t", kind: KindExplain, tables: []string{"t"}, readOnly: true},
+		{name: "explain analyze delete", sql: "EXPLAIN ANALYZE DELETE FROM t", kind: KindExplain, tables: []string{"t"}, mutates: true},
+		{
+			name: "explain analyze verbose insert", sql: "explain analyze verbose insert into t values (1)",
+			kind: KindExplain, tables: []string{"t"}, mutates: true,
+		},
+		{name: "explain nothing", sql: "EXPLAIN ANALYZE", kind: KindExplain, unresolved: true, mutates: true},
+		{name: "explain execute", sql: "EXPLAIN EXECUTE stmt", kind: KindExplain, unresolved: true, readOnly: true},
+
+		// Writes
+		{name: "insert", sql: "INSERT INTO t (a, b) SELECT a, b FROM s", kind: KindInsert, tables: []string{"t", "s"}, mutates: true},
+		{name: "with insert", sql: "WITH x AS (SELECT 1) INSERT INTO t SELECT * FROM x", kind: KindInsert, tables: []string{"t"}, mutates: true},
+		{name: "update", sql: "UPDATE t SET a = 1 WHERE b IN (SELECT b FROM s)", kind: KindUpdate, tables: []string{"t", "s"}, mutates: true},
+		{name: "delete", sql: "DELETE FROM t WHERE a = 1", kind: KindDelete, tables: []string{"t"}, mutates: true},
+		{
+			name: "merge",
+			sql:  "MERGE INTO t USING s ON t.id = s.id WHEN MATCHED THEN UPDATE SET a = s.a WHEN NOT MATCHED THEN INSERT (id) VALUES (s.id)",
+			kind: KindMerge, tables: []string{"t", "s"}, mutates: true,
+		},
+		{
+			name: "create table as", sql: "CREATE TABLE IF NOT EXISTS t WITH (format = 'ORC') AS SELECT * FROM s",
+			kind: KindCreate, tables: []string{"t", "s"}, mutates: true,
+		},
+		{name: "create view", sql: "CREATE OR REPLACE VIEW v AS SELECT * FROM s", kind: KindCreate, tables: []string{"v", "s"}, mutates: true},
+		{name: "drop", sql: "DROP TABLE IF EXISTS hive.sales.t", kind: KindDrop, tables: []string{"hive.sales.t"}, mutates: true},
+		{name: "alter execute", sql: "ALTER TABLE t EXECUTE optimize", kind: KindAlter, tables: []string{"t"}, mutates: true},
+		{name: "truncate", sql: "TRUNCATE TABLE t", kind: KindTruncate, tables: []string{"t"}, mutates: true},
+		{name: "comment", sql: "COMMENT ON TABLE t IS 'orders'", kind: KindComment, tables: []string{"t"}, mutates: true},
+		{name: "refresh", sql: "REFRESH MATERIALIZED VIEW mv", kind: KindRefresh, tables: []string{"mv"}, mutates: true},
+		{name: "analyze", sql: "ANALYZE hive.sales.t", kind: KindAnalyze, tables: []string{"hive.sales.t"}, mutates: true},
+		{name: "grant", sql: "GRANT SELECT ON TABLE t TO ROLE analyst", kind: KindGrant, tables: []string{"t"}, mutates: true},
+		{name: "revoke", sql: "REVOKE SELECT ON t FROM analyst", kind: KindGrant, tables: []string{"t"}, mutates: true},
+		{name: "grant schema", sql: "GRANT SELECT ON SCHEMA s TO analyst", kind: KindGrant, mutates: true},
+		{name: "call", sql: "CALL system.sync_partition_metadata('s', 't', 'ADD')", kind: KindCall, mutates: true},
+		{name: "execute", sql: "EXECUTE stmt USING 1", kind: KindExecute, unresolved: true, mutates: true},
+		{name: "execute immediate", sql: "EXECUTE IMMEDIATE 'SELECT 1'", kind: KindExecute, unresolved: true, mutates: true},
+		{name: "unknown", sql: "VACUUM t", kind: KindUnknown, unresolved: true, mutates: true},
+
+		// Session state
+		{name: "set session", sql: "SET SESSION query_max_run_time = '1h'", kind: KindSession, session: true},
+		{name: "reset session", sql: "RESET SESSION query_max_run_time", kind: KindSession, session: true},
+		{name: "use", sql: "USE hive.sales", kind: KindSession, session: true},
+		{name: "set role", sql: "SET ROLE admin", kind: KindSession, session: true},
+		{name: "prepare", sql: "PREPARE stmt FROM SELECT * FROM t", kind: KindPrepare, tables: []string{"t"}, session: true},
+		{name: "deallocate", sql: "DEALLOCATE PREPARE stmt", kind: KindPrepare, session: true},
+		{name: "transaction", sql: "START TRANSACTION", kind: KindTransaction, session: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statements, err := Classify(tt.sql)
+			if err != nil {
+				t.Fatalf("Classify() error: %v", err)
+			}
+			if len(statements) != 1 {
+				t.Fatalf("got %d statements, want 1: %+v", len(statements), statements)
+			}
+			s := statements[0]
+			if s.Kind != tt.kind || s.Mutates != tt.mutates || s.ChangesSession != tt.session {
+				t.Errorf("got kind %s, mutates %v, changes session %v; want %s, %v, %v",
+					s.Kind, s.Mutates, s.ChangesSession, tt.kind, tt.mutates, tt.session)
+			}
+			if !reflect.DeepEqual(s.Tables, tt.tables) {
+				t.Errorf("tables = %q, want %q", s.Tables, tt.tables)
+			}
+			if !reflect.DeepEqual(s.TableFunctions, tt.functions) || s.Unresolved != tt.unresolved {
+				t.Errorf("table functions = %q, unresolved %v; want %q, %v", s.TableFunctions, s.Unresolved, tt.functions, tt.unresolved)
+			}
+			if got := IsReadOnly(tt.sql); got != tt.readOnly {
+				t.Errorf("IsReadOnly() = %v, want %v", got, tt.readOnly)
+			}
+		})
+	}
+}
+
+func TestClassify_MultipleStatements(t *testing.T) {
+	sql := "SELECT * FROM t; DROP TABLE t;\n -- done\n"
+	statements, err := Classify(sql)
+	if err != nil {
+		t.Fatalf("Classify() error: %v", err)
+	}
+	if len(statements) != 2 {
+		t.Fatalf("got %d statements, want 2: %+v", len(statements), statements)
+	}
+	if statements[0].SQL != "SELECT * FROM t" || statements[1].SQL != "DROP TABLE t" {
+		t.Errorf("statement SQL = %q, %q", statements[0].SQL, statements[1].SQL)
+	}
+	if statements[1].Kind != KindDrop || IsReadOnly(sql) {
+		t.Error("a write after a query should make the SQL not read-only")
+	}
+
+	if statements, _ := Classify("; ;"); len(statements) != 0 {
+		t.Errorf("expected no statements, got %+v", statements)
+	}
+	if !IsReadOnly("") {
+		t.Error("empty SQL should be read-only")
+	}
+}
+
+func TestClassify_Unterminated(t *testing.T) {
+	statements, err := Classify("DELETE FROM t WHERE s = 'open")
+	if err == nil {
+		t.Error("expected error for unterminated string")
+	}
+	if len(statements) != 1 || statements[0].Kind != KindDelete {
+		t.Errorf("expected the statement before the error to be classified, got %+v", statements)
+	}
+}
+
+// FuzzClassify checks that Classify never panics, that statement text
+// comes from the input, and that appending a write to SQL that scans
+// cleanly is never read-only.
+func FuzzClassify(f *testing.F) {
+	for _, seed := range []string{
+		"SELECT * FROM a, (SELECT * FROM b) x WHERE a.id IN (SELECT id FROM d)",
+		"WITH x AS (SELECT 1) INSERT INTO t SELECT * FROM x",
+		"EXPLAIN ANALYZE VERBOSE DELETE FROM t",
+		"EXPLAIN (TYPE DISTRIBUTED",
+		"WITH x (a AS (",
+		"SELECT 1; SET SESSION a = 'b'",
+		"((((",
+		"))) FROM",
+		"MERGE INTO t USING s ON t.id = s.id WHEN MATCHED THEN UPDATE SET a = 1",
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, sql string) {
+		statements, err := Classify(sql)
+		for _, s := range statements {
+			if s.SQL == "" || !strings.Contains(sql, s.SQL) {
+				t.Fatalf("statement %q is not part of the input", s.SQL)
+			}
+			if s.Kind == "" {
+				t.Fatalf("statement %q has no kind", s.SQL)
+			}
+		}
+		if err == nil && IsReadOnly(sql+"\n;DROP TABLE t") {
+			t.Fatalf("%q followed by DROP TABLE classified as read-only", sql)
+		}
+	})
+}
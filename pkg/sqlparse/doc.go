@@ -0,0 +1,29 @@
+// Package sqlparse lexes and classifies Trino SQL.
+//
+// It is not a full parser: it reads just enough of the token stream to tell
+// what a statement does. The read-only checks of trino_query and
+// trino_explain, the read-only extension, and the client's retry policy share
+// it, so they agree on which SQL can change anything.
+//
+// # Lexing
+//
+// Scan splits SQL into tokens, skipping comments and whitespace and keeping
+// string literals and quoted identifiers whole, so keywords inside them are
+// never mistaken for statements:
+//
+//	tokens, err := sqlparse.Scan(`SELECT * FROM "Order Items" -- DROP TABLE x`)
+//
+// # Classifying
+//
+// Classify splits SQL on top-level semicolons and reports, for each
+// statement, its kind, the tables it references, and whether it mutates data
+// or changes session state:
+//
+//	statements, err := sqlparse.Classify("WITH x AS (SELECT 1) INSERT INTO t SELECT * FROM x")
+//	// statements[0].Kind == sqlparse.KindInsert, statements[0].Mutates == true
+//
+// IsReadOnly reports whether every statement is read-only. Statements of
+// unknown kind count as mutating, so checks built on it fail closed.
+// Likewise, checks on the tables SQL reaches should reject statements marked
+// Unresolved, such as EXECUTE, whose tables Classify cannot see.
+package sqlparse
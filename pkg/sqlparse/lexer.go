@@ -0,0 +1,175 @@
+package sqlparse
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TokenKind classifies a token produced by Scan.
+type TokenKind int
+
+// Token kinds.
+const (
+	Word   TokenKind = iota // keyword or unquoted identifier
+	Quoted                  // "quoted identifier"
+	String                  // 'string literal'
+	Number                  // numeric literal
+	Symbol                  // any other single character, e.g. ( ) , ; ?
+)
+
+// Token is a lexical token of a SQL statement. Comments and whitespace
+// are not tokens.
+type Token struct {
+	Kind  TokenKind
+	Text  string
+	Pos   int // byte offset of the token in the statement
+	Depth int // parenthesis nesting depth; ( and ) have the outer depth
+}
+
+// End returns the byte offset just past the token.
+func (t Token) End() int {
+	return t.Pos + len(t.Text)
+}
+
+// Is reports whether the token is the given keyword, case-insensitively.
+func (t Token) Is(keyword string) bool {
+	return t.Kind == Word && strings.EqualFold(t.Text, keyword)
+}
+
+// IsSymbol reports whether the token is the given symbol, e.g. ";".
+func (t Token) IsSymbol(symbol string) bool {
+	return t.Kind == Symbol && t.Text == symbol
+}
+
+// Scan splits SQL text into tokens. On an unterminated string, quoted
+// identifier, or comment it returns the tokens before it and an error.
+func Scan(s string) ([]Token, error) {
+	var tokens []Token
+	depth := 0
+	for i := 0; i < len(s); {
+		c := s[i]
+		rest := s[i:]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f':
+			i++
+		case strings.HasPrefix(rest, "--"):
+			j := strings.IndexByte(rest, '\n')
+			if j < 0 {
+				return tokens, nil
+			}
+			i += j + 1
+		case strings.HasPrefix(rest, "/*"):
+			j := strings.Index(rest[2:], "*/")
+			if j < 0 {
+				return tokens, fmt.Errorf("unterminated comment at offset %d", i)
+			}
+			i += j + 4
+		case c == '\'' || c == '"':
+			n := QuotedLen(rest)
+			if n < 0 {
+				return tokens, fmt.Errorf("unterminated quote at offset %d", i)
+			}
+			kind := String
+			if c == '"' {
+				kind = Quoted
+			}
+			tokens = append(tokens, Token{Kind: kind, Text: rest[:n], Pos: i, Depth: depth})
+			i += n
+		case isDigit(c) || (c == '.' && len(rest) > 1 && isDigit(rest[1])):
+			n := numberLen(rest)
+			tokens = append(tokens, Token{Kind: Number, Text: rest[:n], Pos: i, Depth: depth})
+			i += n
+		case isWordByte(c):
+			n := 1
+			for n < len(rest) && (isWordByte(rest[n]) || isDigit(rest[n])) {
+				n++
+			}
+			tokens = append(tokens, Token{Kind: Word, Text: rest[:n], Pos: i, Depth: depth})
+			i += n
+		default:
+			if c == ')' && depth > 0 {
+				depth--
+			}
+			tokens = append(tokens, Token{Kind: Symbol, Text: rest[:1], Pos: i, Depth: depth})
+			if c == '(' {
+				depth++
+			}
+			i++
+		}
+	}
+	return tokens, nil
+}
+
+// QuotedLen returns the length of the quoted section at the start of s,
+// including both quotes, or -1 if it is unterminated. A doubled quote
+// escapes the quote character.
+func QuotedLen(s string) int {
+	q := s[0]
+	for i := 1; i < len(s); i++ {
+		if s[i] != q {
+			continue
+		}
+		if i+1 < len(s) && s[i+1] == q {
+			i++
+			continue
+		}
+		return i + 1
+	}
+	return -1
+}
+
+// Identifier returns an identifier token as Trino resolves it: quoted
+// names without their quotes, unquoted names in lower case.
+func Identifier(s string) string {
+	if len(s) >= 2 && s[0] == '"' {
+		return strings.ReplaceAll(s[1:len(s)-1], `""`, `"`)
+	}
+	return strings.ToLower(s)
+}
+
+// numberLen returns the length of the numeric literal at the start of s,
+// e.g. 42, 1_000, 1.5, .5, 1e-3. Like Trino it stops at any letter other
+// than an exponent, so 1.0from is the number 1.0 followed by FROM.
+func numberLen(s string) int {
+	n := digitsLen(s)
+	if n < len(s) && s[n] == '.' {
+		n += 1 + digitsLen(s[n+1:])
+	}
+	if n < len(s) && (s[n] == 'e' || s[n] == 'E') {
+		exp := n + 1
+		if exp < len(s) && (s[exp] == '+' || s[exp] == '-') {
+			exp++
+		}
+		if d := digitsLen(s[exp:]); d > 0 {
+			n = exp + d
+		}
+	}
+	return n
+}
+
+// digitsLen returns the length of the run of digits at the start of s,
+// allowing a single _ between two digits.
+func digitsLen(s string) int {
+	n := 0
+	for n < len(s) {
+		switch {
+		case isDigit(s[n]):
+			n++
+		case s[n] == '_' && n > 0 && n+1 < len(s) && isDigit(s[n+1]):
+			n += 2
+		default:
+			return n
+		}
+	}
+	return n
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// isWordByte reports whether c can start an unquoted identifier. Bytes of
+// multi-byte UTF-8 characters are treated as letters.
+func isWordByte(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
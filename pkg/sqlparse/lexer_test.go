@@ -0,0 +1,111 @@
+package sqlparse
+
+import "testing"
+
+func TestScan(t *testing.T) {
+	tokens, err := Scan(`SELECT "a(b", 'it''s', 1e-3 FROM (t) /* ( */ -- )`)
+	if err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+
+	want := []Token{
+		{Kind: Word, Text: "SELECT"},
+		{Kind: Quoted, Text: `"a(b"`},
+		{Kind: Symbol, Text: ","},
+		{Kind: String, Text: `'it''s'`},
+		{Kind: Symbol, Text: ","},
+		{Kind: Number, Text: "1e-3"},
+		{Kind: Word, Text: "FROM"},
+		{Kind: Symbol, Text: "("},
+		{Kind: Word, Text: "t", Depth: 1},
+		{Kind: Symbol, Text: ")"},
+	}
+	if len(tokens) != len(want) {
+		t.Fatalf("got %d tokens, want %d: %+v", len(tokens), len(want), tokens)
+	}
+	for i, w := range want {
+		if got := tokens[i]; got.Kind != w.Kind || got.Text != w.Text || got.Depth != w.Depth {
+			t.Errorf("token %d = %+v, want %+v", i, got, w)
+		}
+	}
+
+	if _, err := Scan("SELECT /* open"); err == nil {
+		t.Error("expected error for unterminated comment")
+	}
+	if _, err := Scan("SELECT 'open"); err == nil {
+		t.Error("expected error for unterminated string")
+	}
+}
+
+func TestScan_Numbers(t *testing.T) {
+	tests := map[string][]string{
+		"42":        {"42"},
+		"1_000":     {"1_000"},
+		"1.5":       {"1.5"},
+		".5":        {".5"},
+		"1e-3":      {"1e-3"},
+		"1.0from":   {"1.0", "from"},
+		".5from":    {".5", "from"},
+		"1e5from":   {"1e5", "from"},
+		"1efrom":    {"1", "efrom"},
+		"1_from":    {"1", "_from"},
+		"2E+10else": {"2E+10", "else"},
+	}
+	for in, want := range tests {
+		tokens, err := Scan(in)
+		if err != nil {
+			t.Fatalf("Scan(%q) error: %v", in, err)
+		}
+		if len(tokens) != len(want) || tokens[0].Kind != Number {
+			t.Errorf("Scan(%q) = %+v, want number then %v", in, tokens, want)
+			continue
+		}
+		for i, w := range want {
+			if tokens[i].Text != w {
+				t.Errorf("Scan(%q) token %d = %q, want %q", in, i, tokens[i].Text, w)
+			}
+		}
+	}
+}
+
+func TestIdentifier(t *testing.T) {
+	tests := map[string]string{
+		"Orders":       "orders",
+		`"Orders"`:     "Orders",
+		`"say ""hi"""`: `say "hi"`,
+		"sales_2024":   "sales_2024",
+		`"with.dot"`:   "with.dot",
+	}
+	for in, want := range tests {
+		if got := Identifier(in); got != want {
+			t.Errorf("Identifier(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+// FuzzScan checks that Scan never panics and that every token is the
+// text at its position.
+func FuzzScan(f *testing.F) {
+	for _, seed := range []string{
+		`SELECT "a(b", 'it''s', 1e-3 FROM (t) /* ( */ -- )`,
+		"SELECT 1; DROP TABLE t",
+		"SELECT 1.0from t",
+		")))(((",
+		"'unterminated",
+		"/* open",
+		"é.ü \"ß\"",
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		tokens, _ := Scan(s)
+		for _, tok := range tokens {
+			if tok.Pos < 0 || tok.End() > len(s) || s[tok.Pos:tok.End()] != tok.Text {
+				t.Fatalf("token %+v does not match the input at its position", tok)
+			}
+			if tok.Depth < 0 {
+				t.Fatalf("token %+v has a negative depth", tok)
+			}
+		}
+	})
+}
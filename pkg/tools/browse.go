@@ -6,6 +6,8 @@ import (
 	"strings"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/txn2/mcp-trino/pkg/client"
 )
 
 // BrowseInput defines the input for the trino_browse tool.
@@ -23,6 +25,10 @@ type BrowseInput struct {
 	// Pattern is an optional LIKE pattern to filter tables (only when listing tables).
 	Pattern string `json:"pattern,omitempty" jsonschema_description:"LIKE pattern to filter tables (only when listing tables)"`
 
+	// Type is an optional object type filter: table, view, or
+	// materialized_view (only when listing tables).
+	Type string `json:"type,omitempty" jsonschema_description:"Object type filter: table, view, or materialized_view (only when listing tables)"` //nolint:lll // struct tag
+
 	// Connection is the named connection to use. Empty uses the default connection.
 	// Use trino_list_connections to see available connections.
 	Connection string `json:"connection,omitempty" jsonschema_description:"Named connection to use (see trino_list_connections)"`
@@ -69,13 +75,22 @@ func (t *Toolkit) handleBrowse(
 		return ErrorResult(fmt.Sprintf("Connection error: %v", err)), nil, nil
 	}
 
+	// Browsing inside a catalog or schema the object filters reject fails
+	ctx = WithConnection(ctx, input.Connection)
+	if input.Catalog != "" {
+		if err := t.checkObject(ctx, input.Catalog, input.Schema, ""); err != nil {
+			return ErrorResult(err.Error()), nil, nil
+		}
+	}
+
 	switch {
 	case input.Catalog == "":
 		return t.browseCatalogs(ctx, trinoClient)
 	case input.Schema == "":
 		return t.browseSchemas(ctx, trinoClient, input.Catalog)
 	default:
-		return t.browseTables(ctx, trinoClient, input.Catalog, input.Schema, input.Pattern)
+		tableType, _ := browseTableType(input.Type) // checked by validateBrowseInput
+		return t.browseTables(ctx, trinoClient, input.Catalog, input.Schema, input.Pattern, tableType)
 	}
 }
 
@@ -86,9 +101,41 @@ func validateBrowseInput(input BrowseInput) error {
 	if input.Pattern != "" && (input.Catalog == "" || input.Schema == "") {
 		return fmt.Errorf("pattern requires both catalog and schema")
 	}
+	if input.Type != "" && (input.Catalog == "" || input.Schema == "") {
+		return fmt.Errorf("type requires both catalog and schema")
+	}
+	if _, err := browseTableType(input.Type); err != nil {
+		return err
+	}
 	return nil
 }
 
+// browseTableType maps a type filter to the client.TableInfo type it
+// selects. An empty filter selects every type.
+func browseTableType(filter string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(filter)) {
+	case "":
+		return "", nil
+	case "table":
+		return client.TableTypeTable, nil
+	case "view":
+		return client.TableTypeView, nil
+	case "materialized_view", "materialized view":
+		return client.TableTypeMaterializedView, nil
+	default:
+		return "", fmt.Errorf("invalid type %q: must be table, view, or materialized_view", filter)
+	}
+}
+
+// tableTypeLabel returns the lower-case plural of a client.TableInfo type
+// for headings, e.g. "materialized views".
+func tableTypeLabel(tableType string) string {
+	if tableType == "" {
+		return "tables"
+	}
+	return strings.ToLower(tableType) + "s"
+}
+
 func (t *Toolkit) browseCatalogs(
 	ctx context.Context, trinoClient TrinoClient,
 ) (*mcp.CallToolResult, any, error) {
@@ -96,6 +143,7 @@ func (t *Toolkit) browseCatalogs(
 	if err != nil {
 		return ErrorResult(fmt.Sprintf("Failed to list catalogs: %v", err)), nil, nil
 	}
+	catalogs = t.filterNames(ctx, "", "", catalogs)
 
 	output := "## Available Catalogs\n\n"
 	for _, catalog := range catalogs {
@@ -123,6 +171,7 @@ func (t *Toolkit) browseSchemas(
 	if err != nil {
 		return ErrorResult(fmt.Sprintf("Failed to list schemas: %v", err)), nil, nil
 	}
+	schemas = t.filterNames(ctx, catalog, "", schemas)
 
 	output := fmt.Sprintf("## Schemas in `%s`\n\n", catalog)
 	for _, schema := range schemas {
@@ -145,45 +194,69 @@ func (t *Toolkit) browseSchemas(
 }
 
 func (t *Toolkit) browseTables(
-	ctx context.Context, trinoClient TrinoClient, catalog, schema, pattern string,
+	ctx context.Context, trinoClient TrinoClient, catalog, schema, pattern, tableType string,
 ) (*mcp.CallToolResult, any, error) {
 	tables, err := trinoClient.ListTables(ctx, catalog, schema)
 	if err != nil {
 		return ErrorResult(fmt.Sprintf("Failed to list tables: %v", err)), nil, nil
 	}
 
-	var tableNames []string
-	var output string
+	p := strings.ReplaceAll(strings.ToLower(pattern), "%", "")
+	var matched []client.TableInfo
+	for _, tbl := range tables {
+		if tableType != "" && tbl.Type != tableType {
+			continue
+		}
+		if p != "" && !strings.Contains(strings.ToLower(tbl.Name), p) {
+			continue
+		}
+		if t.checkObject(ctx, catalog, schema, tbl.Name) != nil {
+			continue
+		}
+		matched = append(matched, tbl)
+	}
 
+	label := tableTypeLabel(tableType)
+	heading := strings.ToUpper(label[:1]) + label[1:]
+	var output string
 	if pattern != "" {
-		p := strings.ToLower(pattern)
-		p = strings.ReplaceAll(p, "%", "")
-		for _, tbl := range tables {
-			if strings.Contains(strings.ToLower(tbl.Name), p) {
-				tableNames = append(tableNames, tbl.Name)
-			}
-		}
-		output = fmt.Sprintf("## Tables in `%s.%s` matching '%s'\n\n", catalog, schema, pattern)
+		output = fmt.Sprintf("## %s in `%s.%s` matching '%s'\n\n", heading, catalog, schema, pattern)
 	} else {
-		tableNames = make([]string, len(tables))
-		for i, tbl := range tables {
-			tableNames[i] = tbl.Name
-		}
-		output = fmt.Sprintf("## Tables in `%s.%s`\n\n", catalog, schema)
+		output = fmt.Sprintf("## %s in `%s.%s`\n\n", heading, catalog, schema)
 	}
 
-	for _, name := range tableNames {
-		output += fmt.Sprintf("- `%s`\n", name)
+	tableNames := make([]string, len(matched))
+	items := make([]BrowseTable, len(matched))
+	hasViews := false
+	for i, tbl := range matched {
+		tableNames[i] = tbl.Name
+		items[i] = BrowseTable{Name: tbl.Name, Type: tbl.Type, Comment: tbl.Comment}
+
+		line := fmt.Sprintf("- `%s`", tbl.Name)
+		if tbl.Type != "" && tbl.Type != client.TableTypeTable {
+			line += fmt.Sprintf(" (%s)", strings.ToLower(tbl.Type))
+		}
+		if tbl.Comment != "" {
+			line += " — " + tbl.Comment
+		}
+		output += line + "\n"
+		hasViews = hasViews || tbl.Type == client.TableTypeView
+	}
+	output += fmt.Sprintf("\n*%d %s found*", len(matched), label)
+	if hasViews {
+		output += "\n\n*Views run their defining query every time they are read, " +
+			"so querying one can be as expensive as the joins behind it.*"
 	}
-	output += fmt.Sprintf("\n*%d tables found*", len(tableNames))
 
 	browseOutput := BrowseOutput{
 		Level:   "tables",
 		Catalog: catalog,
 		Schema:  schema,
 		Items:   tableNames,
+		Tables:  items,
 		Count:   len(tableNames),
 		Pattern: pattern,
+		Type:    tableType,
 	}
 
 	return &mcp.CallToolResult{
@@ -46,6 +46,26 @@ func TestBrowseInput_Validation(t *testing.T) {
 			input:   BrowseInput{Catalog: "hive", Pattern: "%user%"},
 			wantErr: "pattern requires both catalog and schema",
 		},
+		{
+			name:    "list views",
+			input:   BrowseInput{Catalog: "hive", Schema: "default", Type: "view"},
+			wantErr: "",
+		},
+		{
+			name:    "list materialized views",
+			input:   BrowseInput{Catalog: "hive", Schema: "default", Type: "MATERIALIZED_VIEW"},
+			wantErr: "",
+		},
+		{
+			name:    "type without schema",
+			input:   BrowseInput{Catalog: "hive", Type: "view"},
+			wantErr: "type requires both catalog and schema",
+		},
+		{
+			name:    "invalid type",
+			input:   BrowseInput{Catalog: "hive", Schema: "default", Type: "index"},
+			wantErr: "must be table, view, or materialized_view",
+		},
 	}
 
 	for _, tt := range tests {
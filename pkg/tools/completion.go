@@ -0,0 +1,196 @@
+package tools
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// DefaultCompletionCacheTTL is how long catalog, schema, and table names are
+// cached for argument completion unless changed with WithCompletionCacheTTL.
+const DefaultCompletionCacheTTL = time.Minute
+
+// maxCompletionValues is the most values a completion result may carry (MCP spec).
+const maxCompletionValues = 100
+
+// Complete answers MCP completion requests for arguments named connection,
+// catalog, schema, or table, on any prompt or resource template. Catalog,
+// schema, and table names come from Trino, using the connection, catalog,
+// and schema already chosen in the request context, and are cached per
+// caller. Values are filtered by case-insensitive prefix.
+//
+// Install it when creating the server:
+//
+//	server := mcp.NewServer(impl, &mcp.ServerOptions{
+//	    CompletionHandler: toolkit.Complete,
+//	})
+func (t *Toolkit) Complete(ctx context.Context, req *mcp.CompleteRequest) (*mcp.CompleteResult, error) {
+	if req == nil || req.Params == nil {
+		return &mcp.CompleteResult{Completion: mcp.CompletionResultDetails{Values: []string{}}}, nil
+	}
+
+	ctx = t.callerContext(ctx, req.Extra)
+	var resolved map[string]string
+	if req.Params.Context != nil {
+		resolved = req.Params.Context.Arguments
+	}
+
+	arg := req.Params.Argument
+	return completionResult(t.completionCandidates(ctx, arg.Name, resolved), arg.Value), nil
+}
+
+// completionCandidates returns all known values for the named argument.
+// Names that cannot be listed yet (e.g. a schema before its catalog is
+// chosen) or that fail to load yield no candidates.
+func (t *Toolkit) completionCandidates(ctx context.Context, name string, resolved map[string]string) []string {
+	connection := resolved["connection"]
+	catalog := resolved["catalog"]
+	schema := resolved["schema"]
+	ctx = WithConnection(ctx, connection)
+
+	switch name {
+	case "connection":
+		return t.connectionNames()
+	case "catalog":
+		catalogs := t.completions.lookup(ctx, []string{connection}, func(ctx context.Context) ([]string, error) {
+			trinoClient, err := t.getClient(connection)
+			if err != nil {
+				return nil, err
+			}
+			return trinoClient.ListCatalogs(ctx)
+		})
+		return t.filterNames(ctx, "", "", catalogs)
+	case "schema":
+		if catalog == "" {
+			return nil
+		}
+		schemas := t.completions.lookup(ctx, []string{connection, catalog}, func(ctx context.Context) ([]string, error) {
+			trinoClient, err := t.getClient(connection)
+			if err != nil {
+				return nil, err
+			}
+			return trinoClient.ListSchemas(ctx, catalog)
+		})
+		return t.filterNames(ctx, catalog, "", schemas)
+	case "table":
+		if catalog == "" || schema == "" {
+			return nil
+		}
+		tables := t.completions.lookup(ctx, []string{connection, catalog, schema}, func(ctx context.Context) ([]string, error) {
+			trinoClient, err := t.getClient(connection)
+			if err != nil {
+				return nil, err
+			}
+			tables, err := trinoClient.ListTables(ctx, catalog, schema)
+			if err != nil {
+				return nil, err
+			}
+			names := make([]string, len(tables))
+			for i, tbl := range tables {
+				names[i] = tbl.Name
+			}
+			return names, nil
+		})
+		return t.filterNames(ctx, catalog, schema, tables)
+	default:
+		return nil
+	}
+}
+
+// connectionNames returns the names of all configured connections.
+func (t *Toolkit) connectionNames() []string {
+	if t.manager != nil {
+		return t.manager.Connections()
+	}
+	infos := t.ConnectionInfos()
+	names := make([]string, len(infos))
+	for i, info := range infos {
+		names[i] = info.Name
+	}
+	return names
+}
+
+// completionResult filters candidates by prefix and caps the result size.
+func completionResult(candidates []string, prefix string) *mcp.CompleteResult {
+	p := strings.ToLower(prefix)
+	values := []string{}
+	for _, c := range candidates {
+		if strings.HasPrefix(strings.ToLower(c), p) {
+			values = append(values, c)
+		}
+	}
+	slices.Sort(values)
+
+	total := len(values)
+	if total > maxCompletionValues {
+		values = values[:maxCompletionValues]
+	}
+	return &mcp.CompleteResult{
+		Completion: mcp.CompletionResultDetails{
+			Values:  values,
+			Total:   total,
+			HasMore: total > maxCompletionValues,
+		},
+	}
+}
+
+// completionIndex caches catalog, schema, and table names for completion.
+// Entries are keyed by the calling principal as well as the path, since
+// impersonated users may see different objects.
+type completionIndex struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]completionEntry
+}
+
+type completionEntry struct {
+	values  []string
+	expires time.Time
+}
+
+func newCompletionIndex(ttl time.Duration) *completionIndex {
+	return &completionIndex{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]completionEntry),
+	}
+}
+
+// lookup returns the cached names under path, calling load on a miss.
+// Load failures are not cached and yield no names.
+func (c *completionIndex) lookup(
+	ctx context.Context, path []string, load func(ctx context.Context) ([]string, error),
+) []string {
+	key := GetPrincipal(ctx) + "\x00" + strings.Join(path, "\x00")
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && c.now().Before(entry.expires) {
+		return entry.values
+	}
+
+	values, err := load(ctx)
+	if err != nil {
+		return nil
+	}
+
+	if c.ttl > 0 {
+		now := c.now()
+		c.mu.Lock()
+		for k, e := range c.entries {
+			if !now.Before(e.expires) {
+				delete(c.entries, k)
+			}
+		}
+		c.entries[key] = completionEntry{values: values, expires: now.Add(c.ttl)}
+		c.mu.Unlock()
+	}
+	return values
+}
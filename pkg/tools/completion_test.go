@@ -0,0 +1,190 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/txn2/mcp-trino/pkg/client"
+	"github.com/txn2/mcp-trino/pkg/multiserver"
+)
+
+// connectCompletion returns a client session for a server whose completion
+// handler, prompts, and resources come from toolkit.
+func connectCompletion(t *testing.T, toolkit *Toolkit) *mcp.ClientSession {
+	t.Helper()
+	opts := &mcp.ServerOptions{CompletionHandler: toolkit.Complete}
+	return connectTestSession(t, opts, func(server *mcp.Server) {
+		toolkit.RegisterPrompts(server)
+		toolkit.RegisterResources(server)
+	})
+}
+
+func completionMock() *MockTrinoClient {
+	mock := NewMockTrinoClient()
+	mock.ListCatalogsFunc = func(_ context.Context) ([]string, error) {
+		return []string{"system", "hive", "Hudi", "iceberg"}, nil
+	}
+	mock.ListSchemasFunc = func(_ context.Context, catalog string) ([]string, error) {
+		if catalog != "hive" {
+			return nil, errors.New("catalog not found")
+		}
+		return []string{"sales", "staging"}, nil
+	}
+	mock.ListTablesFunc = func(_ context.Context, _, _ string) ([]client.TableInfo, error) {
+		return []client.TableInfo{{Name: "orders"}, {Name: "order_items"}, {Name: "customers"}}, nil
+	}
+	return mock
+}
+
+func TestComplete(t *testing.T) {
+	session := connectCompletion(t, NewToolkit(completionMock(), DefaultConfig()))
+	promptRef := &mcp.CompleteReference{Type: "ref/prompt", Name: PromptReportQuery}
+	resourceRef := &mcp.CompleteReference{Type: "ref/resource", URI: ResourceTableTemplate}
+
+	tests := []struct {
+		name     string
+		ref      *mcp.CompleteReference
+		arg      string
+		value    string
+		resolved map[string]string
+		want     []string
+	}{
+		{name: "connection", ref: resourceRef, arg: "connection", want: []string{"default"}},
+		{name: "catalog prefix", ref: promptRef, arg: "catalog", value: "h", want: []string{"Hudi", "hive"}},
+		{name: "catalog all", ref: resourceRef, arg: "catalog", want: []string{"Hudi", "hive", "iceberg", "system"}},
+		{name: "schema needs catalog", ref: promptRef, arg: "schema", want: []string{}},
+		{
+			name: "schema", ref: resourceRef, arg: "schema", value: "st",
+			resolved: map[string]string{"connection": "default", "catalog": "hive"},
+			want:     []string{"staging"},
+		},
+		{
+			name: "schema load error", ref: promptRef, arg: "schema",
+			resolved: map[string]string{"catalog": "missing"},
+			want:     []string{},
+		},
+		{
+			name: "table", ref: promptRef, arg: "table", value: "ORD",
+			resolved: map[string]string{"catalog": "hive", "schema": "sales"},
+			want:     []string{"order_items", "orders"},
+		},
+		{name: "table needs schema", ref: promptRef, arg: "table", resolved: map[string]string{"catalog": "hive"}, want: []string{}},
+		{name: "unknown argument", ref: promptRef, arg: "goal", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := &mcp.CompleteParams{
+				Ref:      tt.ref,
+				Argument: mcp.CompleteParamsArgument{Name: tt.arg, Value: tt.value},
+			}
+			if tt.resolved != nil {
+				params.Context = &mcp.CompleteContext{Arguments: tt.resolved}
+			}
+			result, err := session.Complete(context.Background(), params)
+			if err != nil {
+				t.Fatalf("Complete error: %v", err)
+			}
+			if !reflect.DeepEqual(result.Completion.Values, tt.want) {
+				t.Errorf("got %v, want %v", result.Completion.Values, tt.want)
+			}
+		})
+	}
+}
+
+func TestComplete_Cache(t *testing.T) {
+	calls := 0
+	mock := completionMock()
+	mock.ListCatalogsFunc = func(_ context.Context) ([]string, error) {
+		calls++
+		return []string{"hive"}, nil
+	}
+	toolkit := NewToolkit(mock, DefaultConfig())
+	now := time.Now()
+	toolkit.completions.now = func() time.Time { return now }
+
+	complete := func(ctx context.Context) {
+		t.Helper()
+		_, err := toolkit.Complete(ctx, &mcp.CompleteRequest{Params: &mcp.CompleteParams{
+			Argument: mcp.CompleteParamsArgument{Name: "catalog"},
+		}})
+		if err != nil {
+			t.Fatalf("Complete error: %v", err)
+		}
+	}
+
+	ctx := context.Background()
+	complete(ctx)
+	complete(ctx)
+	if calls != 1 {
+		t.Errorf("expected cached catalogs, got %d loads", calls)
+	}
+
+	complete(WithPrincipal(ctx, "alice"))
+	if calls != 2 {
+		t.Errorf("expected a separate cache entry per principal, got %d loads", calls)
+	}
+
+	now = now.Add(DefaultCompletionCacheTTL)
+	complete(ctx)
+	if calls != 3 {
+		t.Errorf("expected reload after TTL, got %d loads", calls)
+	}
+	if len(toolkit.completions.entries) != 1 {
+		t.Errorf("expected expired entries to be pruned, got %d", len(toolkit.completions.entries))
+	}
+
+	uncached := NewToolkit(mock, DefaultConfig(), WithCompletionCacheTTL(0))
+	calls = 0
+	for range 2 {
+		_, _ = uncached.Complete(ctx, &mcp.CompleteRequest{Params: &mcp.CompleteParams{
+			Argument: mcp.CompleteParamsArgument{Name: "catalog"},
+		}})
+	}
+	if calls != 2 {
+		t.Errorf("expected no caching with zero TTL, got %d loads", calls)
+	}
+}
+
+func TestComplete_Connections(t *testing.T) {
+	mgr := multiserver.NewManager(multiserver.Config{
+		Default: "prod",
+		Primary: client.Config{Host: "localhost", User: "test"},
+		Connections: map[string]multiserver.ConnectionConfig{
+			"staging": {Host: "staging.example.com"},
+			"dev":     {Host: "localhost"},
+		},
+	})
+	toolkit := NewToolkitWithManager(mgr, DefaultConfig())
+
+	result, err := toolkit.Complete(context.Background(), &mcp.CompleteRequest{Params: &mcp.CompleteParams{
+		Argument: mcp.CompleteParamsArgument{Name: "connection", Value: "p"},
+	}})
+	if err != nil {
+		t.Fatalf("Complete error: %v", err)
+	}
+	if !reflect.DeepEqual(result.Completion.Values, []string{"prod"}) {
+		t.Errorf("unexpected values: %v", result.Completion.Values)
+	}
+}
+
+func TestCompletionResult_Limit(t *testing.T) {
+	candidates := make([]string, 150)
+	for i := range candidates {
+		candidates[i] = fmt.Sprintf("table_%03d", i)
+	}
+
+	result := completionResult(candidates, "TABLE_")
+	if len(result.Completion.Values) != maxCompletionValues {
+		t.Errorf("expected %d values, got %d", maxCompletionValues, len(result.Completion.Values))
+	}
+	if result.Completion.Total != 150 || !result.Completion.HasMore {
+		t.Errorf("expected total 150 with more, got %+v", result.Completion)
+	}
+}
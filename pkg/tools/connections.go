@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/txn2/mcp-trino/pkg/client"
 )
 
 // ListConnectionsInput defines the input for the trino_list_connections tool.
@@ -26,6 +28,67 @@ type ConnectionInfoOutput struct {
 	Schema    string `json:"schema,omitempty"`
 	SSL       bool   `json:"ssl"`
 	IsDefault bool   `json:"is_default"`
+
+	// Pool is omitted for connections that have not been used yet.
+	Pool *ConnectionPoolOutput `json:"pool,omitempty"`
+}
+
+// ConnectionPoolOutput reports a connection's pool usage. A growing
+// wait_count means queries are waiting for a free connection.
+type ConnectionPoolOutput struct {
+	MaxOpen        int   `json:"max_open"`
+	Open           int   `json:"open"`
+	InUse          int   `json:"in_use"`
+	Idle           int   `json:"idle"`
+	WaitCount      int64 `json:"wait_count"`
+	WaitDurationMs int64 `json:"wait_duration_ms"`
+}
+
+// connectionKey is the context key for the connection a tool call uses.
+type connectionKey struct{}
+
+// WithConnection returns a new context carrying the name of the connection
+// a tool call uses, as given by the caller ("" for the default connection).
+// The toolkit sets it before running query interceptors and object filters,
+// so they can apply per-connection rules.
+func WithConnection(ctx context.Context, connection string) context.Context {
+	return context.WithValue(ctx, connectionKey{}, connection)
+}
+
+// GetConnection retrieves the connection name from the context.
+// Returns "" for the default connection or if none is set.
+func GetConnection(ctx context.Context) string {
+	c, _ := ctx.Value(connectionKey{}).(string) //nolint:errcheck // type assertion ok is unused by design
+	return c
+}
+
+// defaultSchemaKey is the context key for the default catalog and schema
+// of a tool call's SQL.
+type defaultSchemaKey struct{}
+
+// defaultSchema is the value stored under defaultSchemaKey.
+type defaultSchema struct {
+	catalog, schema string
+}
+
+// WithDefaultSchema returns a new context carrying the catalog and schema a
+// tool call's caller set for unqualified table names. Empty values mean the
+// connection's defaults. The toolkit sets it before running query
+// interceptors, so they resolve table names as Trino will.
+func WithDefaultSchema(ctx context.Context, catalog, schema string) context.Context {
+	return context.WithValue(ctx, defaultSchemaKey{}, defaultSchema{catalog: catalog, schema: schema})
+}
+
+// GetDefaultSchema retrieves the catalog and schema set by WithDefaultSchema.
+func GetDefaultSchema(ctx context.Context) (catalog, schema string) {
+	d, _ := ctx.Value(defaultSchemaKey{}).(defaultSchema) //nolint:errcheck // type assertion ok is unused by design
+	return d.catalog, d.schema
+}
+
+// poolStatser is implemented by clients that report pool statistics,
+// such as *client.Client.
+type poolStatser interface {
+	PoolStats() client.PoolStats
 }
 
 // registerListConnectionsTool adds the trino_list_connections tool to the server.
@@ -57,6 +120,23 @@ func (t *Toolkit) registerListConnectionsTool(server *mcp.Server, cfg *toolConfi
 }
 
 func (t *Toolkit) handleListConnections(_ context.Context, _ *mcp.CallToolRequest) (*mcp.CallToolResult, any, error) {
+	output := t.listConnections()
+
+	data, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		return ErrorResult("Failed to marshal connection info"), nil, nil
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: string(data)},
+		},
+	}, &output, nil
+}
+
+// listConnections builds the connection listing shared by the
+// trino_list_connections tool and the trino://connections resource.
+func (t *Toolkit) listConnections() ListConnectionsOutput {
 	infos := t.ConnectionInfos()
 
 	output := ListConnectionsOutput{
@@ -74,16 +154,29 @@ func (t *Toolkit) handleListConnections(_ context.Context, _ *mcp.CallToolReques
 			SSL:       info.SSL,
 			IsDefault: info.IsDefault,
 		}
+		if stats, ok := t.poolStats(info.Name); ok {
+			output.Connections[i].Pool = &ConnectionPoolOutput{
+				MaxOpen:        stats.MaxOpenConnections,
+				Open:           stats.OpenConnections,
+				InUse:          stats.InUse,
+				Idle:           stats.Idle,
+				WaitCount:      stats.WaitCount,
+				WaitDurationMs: stats.WaitDurationMs,
+			}
+		}
 	}
 
-	data, err := json.MarshalIndent(output, "", "  ")
-	if err != nil {
-		return ErrorResult("Failed to marshal connection info"), nil, nil
-	}
+	return output
+}
 
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{Text: string(data)},
-		},
-	}, &output, nil
+// poolStats returns the pool statistics of the named connection, if its
+// client exists and reports them.
+func (t *Toolkit) poolStats(name string) (client.PoolStats, bool) {
+	if t.manager != nil {
+		return t.manager.PoolStats(name)
+	}
+	if c, ok := t.client.(poolStatser); ok {
+		return c.PoolStats(), true
+	}
+	return client.PoolStats{}, false
 }
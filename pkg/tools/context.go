@@ -18,6 +18,9 @@ type ToolContext struct {
 	// StartTime is when execution started.
 	StartTime time.Time
 
+	// Principal is the authenticated caller, or "" if unauthenticated.
+	Principal string
+
 	// metadata stores values passed between middleware hooks.
 	metadata map[string]any
 	mu       sync.RWMutex
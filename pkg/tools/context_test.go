@@ -1,6 +1,7 @@
 package tools
 
 import (
+	"reflect"
 	"testing"
 	"time"
 )
@@ -12,7 +13,7 @@ func TestNewToolContext(t *testing.T) {
 	if tc.Name != ToolQuery {
 		t.Errorf("expected ToolQuery, got %v", tc.Name)
 	}
-	if tc.Input != input {
+	if !reflect.DeepEqual(tc.Input, input) {
 		t.Error("input not set correctly")
 	}
 	if tc.StartTime.IsZero() {
@@ -14,6 +14,7 @@ var defaultDescriptions = map[ToolName]string{
 		"Set unwrap_json=true to automatically parse single-row, single-string-column " +
 		"results containing a JSON object or array — the column type changes to JSON " +
 		"and the value becomes the parsed object (common with table functions like raw_query). " +
+		"Pass literal values through params with ? placeholders instead of inlining them in sql. " +
 		"For write operations (INSERT, CREATE, etc.), use trino_execute instead.",
 
 	ToolExecute: "Execute a SQL statement against Trino, including write operations " +
@@ -24,21 +25,29 @@ var defaultDescriptions = map[ToolName]string{
 		"(more token-efficient for large result sets) or format=markdown for a pipe-table. " +
 		"Set unwrap_json=true to automatically parse single-row, single-string-column " +
 		"results containing a JSON object or array — the column type changes to JSON " +
-		"and the value becomes the parsed object (common with table functions like raw_query).",
+		"and the value becomes the parsed object (common with table functions like raw_query). " +
+		"Pass literal values through params with ? placeholders instead of inlining them in sql.",
 
 	ToolExplain: "Get the execution plan for a SQL query to understand performance characteristics " +
 		"before running expensive queries. Use this when querying large tables (millions of " +
 		"rows) to verify the query plan uses appropriate filters. Also useful for debugging " +
-		"slow queries or understanding join strategies.",
+		"slow queries or understanding join strategies. Logical and distributed plans include an " +
+		"analysis with estimated rows and cost per operator and flags full table scans, cross " +
+		"joins, missing partition pruning, and large broadcast joins. Type analyze runs the query " +
+		"(read statements only) and ranks operators by actual CPU time.",
 
 	ToolBrowse: "Browse the Trino catalog hierarchy. " +
 		"Omit all parameters to list catalogs. " +
 		"Provide catalog to list schemas. " +
-		"Provide catalog and schema to list tables (with optional pattern filter).",
+		"Provide catalog and schema to list tables, views, and materialized views " +
+		"(with optional pattern and type filters). Views are marked because querying one " +
+		"runs its defining query, which can be an expensive join.",
 
 	ToolDescribeTable: "Get detailed table information with columns, types, and optional sample data. " +
 		"Set include_sample=true to see actual data values, which helps understand column " +
-		"meaning and data formats. This is the richest single-call way to understand a " +
+		"meaning and data formats. Set include_properties=true to see partition columns, " +
+		"file format, and bucketing before querying a large table, and include_ddl=true " +
+		"for the CREATE statement. This is the richest single-call way to understand a " +
 		"table's structure. Requires catalog, schema, and table name — use trino_browse " +
 		"to discover available tables if needed.",
 
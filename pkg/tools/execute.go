@@ -3,7 +3,6 @@ package tools
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 
@@ -18,6 +17,10 @@ type ExecuteInput struct {
 	// Limit is the maximum number of rows to return. Default: 1000, Max: 10000.
 	Limit int `json:"limit,omitempty" jsonschema_description:"Maximum rows to return (default: 1000, max: 10000)"`
 
+	// DisableLimitPushdown runs the SQL unchanged instead of adding the limit
+	// to it; the limit is then only applied while reading rows.
+	DisableLimitPushdown bool `json:"disable_limit_pushdown,omitempty" jsonschema_description:"When true, do not add the row limit to the SQL; Trino runs the full query and rows beyond the limit are discarded"` //nolint:lll // jsonschema_description must be a single tag value
+
 	// TimeoutSeconds is the query timeout in seconds. Default: 120, Max: 300.
 	TimeoutSeconds int `json:"timeout_seconds,omitempty" jsonschema_description:"Query timeout in seconds (default: 120, max: 300)"`
 
@@ -32,6 +35,21 @@ type ExecuteInput struct {
 	// Connection is the named connection to use. Empty uses the default connection.
 	// Use trino_list_connections to see available connections.
 	Connection string `json:"connection,omitempty" jsonschema_description:"Named connection to use (see trino_list_connections)"`
+
+	// Catalog and Schema set the default catalog and schema for unqualified table names.
+	Catalog string `json:"catalog,omitempty" jsonschema_description:"Default catalog for unqualified table names"`
+	Schema  string `json:"schema,omitempty" jsonschema_description:"Default schema for unqualified table names"`
+
+	// SessionProperties sets Trino session properties for this statement.
+	// Only properties allowed by Config.AllowedSessionProperties are accepted.
+	SessionProperties map[string]string `json:"session_properties,omitempty" jsonschema_description:"Trino session properties for this statement (only server-allowed properties are accepted)"` //nolint:lll // jsonschema_description must be a single tag value
+
+	// Params are bound, in order, to ? placeholders in SQL.
+	Params []QueryParam `json:"params,omitempty" jsonschema_description:"Values for ? placeholders in sql, in order. Use instead of inlining literals"`
+
+	// AcknowledgeSensitive confirms the caller means to read columns marked
+	// sensitive, when the server requires acknowledgement for them.
+	AcknowledgeSensitive bool `json:"acknowledge_sensitive,omitempty" jsonschema_description:"Set to true to confirm reading columns marked sensitive, when the server asks for acknowledgement"` //nolint:lll // jsonschema_description must be a single tag value
 }
 
 // registerExecuteTool adds the trino_execute tool to the server.
@@ -78,6 +96,8 @@ func (t *Toolkit) handleExecute(ctx context.Context, _ *mcp.CallToolRequest, inp
 	}
 
 	// Apply query interceptors (no read-only enforcement — that's the point of trino_execute)
+	ctx = WithDefaultSchema(WithConnection(ctx, input.Connection), input.Catalog, input.Schema)
+	ctx = WithSensitiveAcknowledged(ctx, input.AcknowledgeSensitive)
 	sql, err := t.InterceptSQL(ctx, input.SQL, ToolExecute)
 	if err != nil {
 		return ErrorResult(fmt.Sprintf("Query rejected: %v", err)), nil, nil
@@ -93,13 +113,7 @@ func (t *Toolkit) handleExecute(ctx context.Context, _ *mcp.CallToolRequest, inp
 	}
 
 	// Apply timeout
-	timeout := time.Duration(input.TimeoutSeconds) * time.Second
-	if timeout <= 0 {
-		timeout = t.config.DefaultTimeout
-	}
-	if timeout > t.config.MaxTimeout {
-		timeout = t.config.MaxTimeout
-	}
+	timeout := t.queryTimeout(input.TimeoutSeconds)
 
 	// Get client for the specified connection
 	trinoClient, err := t.getClient(input.Connection)
@@ -113,8 +127,15 @@ func (t *Toolkit) handleExecute(ctx context.Context, _ *mcp.CallToolRequest, inp
 
 	// Execute query
 	opts := client.QueryOptions{
-		Limit:   limit,
-		Timeout: timeout,
+		Limit:                limit,
+		Timeout:              timeout,
+		DisableLimitPushdown: input.DisableLimitPushdown,
+	}
+	if err := t.applySessionInput(&opts, input.Catalog, input.Schema, input.SessionProperties); err != nil {
+		return ErrorResult(err.Error()), nil, nil
+	}
+	if opts.Args, err = paramArgs(input.Params); err != nil {
+		return ErrorResult(fmt.Sprintf("Invalid params: %v", err)), nil, nil
 	}
 
 	result, err := trinoClient.Query(ctx, sql, opts)
@@ -129,6 +150,11 @@ func (t *Toolkit) handleExecute(ctx context.Context, _ *mcp.CallToolRequest, inp
 	// Build structured output (reuse QueryOutput — same result shape)
 	queryOutput := buildQueryOutput(result)
 
+	// Transform it, e.g. redact values, before any formatting
+	if err := t.transformQueryOutput(ctx, ToolExecute, sql, &queryOutput); err != nil {
+		return ErrorResult(fmt.Sprintf("Result transform failed: %v", err)), nil, nil
+	}
+
 	// Attempt JSON unwrap if requested — mutates queryOutput in place,
 	// changing columns[0].type to "JSON" and replacing the row value.
 	if input.UnwrapJSON {
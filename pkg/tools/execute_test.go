@@ -34,18 +34,39 @@ func TestIsWriteSQL(t *testing.T) {
 		{"line comment", "-- comment\nINSERT INTO users VALUES (1)", true},
 		{"block comment", "/* comment */INSERT INTO users VALUES (1)", true},
 
+		// EXPLAIN ANALYZE runs the statement
+		{"EXPLAIN ANALYZE INSERT", "EXPLAIN ANALYZE INSERT INTO users VALUES (1)", true},
+		{"EXPLAIN ANALYZE VERBOSE DELETE", "explain analyze verbose DELETE FROM users", true},
+
+		// Writes a leading keyword does not reveal
+		{"WITH INSERT", "WITH x AS (SELECT 1) INSERT INTO users SELECT * FROM x", true},
+		{"second statement", "SELECT 1; DROP TABLE users", true},
+		{"ALTER EXECUTE", "ALTER TABLE users EXECUTE optimize", true},
+		{"unknown statement", "VACUUM users", true},
+
+		// Session state changes
+		{"SET SESSION", "SET SESSION query_max_run_time = '1h'", true},
+		{"RESET SESSION", "RESET SESSION query_max_run_time", true},
+		{"USE", "USE hive.sales", true},
+		{"PREPARE", "PREPARE stmt FROM SELECT 1", true},
+
 		// Read operations
 		{"SELECT", "SELECT * FROM users", false},
 		{"select lowercase", "select id from users", false},
 		{"SHOW", "SHOW TABLES", false},
 		{"DESCRIBE", "DESCRIBE users", false},
 		{"EXPLAIN", "EXPLAIN SELECT * FROM users", false},
+		{"EXPLAIN INSERT", "EXPLAIN INSERT INTO users VALUES (1)", false},
+		{"EXPLAIN ANALYZE", "EXPLAIN ANALYZE SELECT * FROM users", false},
 		{"WITH CTE", "WITH cte AS (SELECT 1) SELECT * FROM cte", false},
 
 		// Edge cases
 		{"empty", "", false},
 		{"whitespace only", "   ", false},
 		{"SELECT with INSERT in value", "SELECT * FROM users WHERE name = 'INSERT'", false},
+		{"statement in comment", "SELECT 1 -- ; DROP TABLE users", false},
+		{"trailing semicolon", "SELECT 1;", false},
+		{"parenthesized query", "(SELECT 1) UNION (SELECT 2)", false},
 	}
 
 	for _, tt := range tests {
@@ -1,8 +1,11 @@
 package tools
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 
@@ -14,14 +17,39 @@ type ExplainInput struct {
 	// SQL is the SQL query to explain.
 	SQL string `json:"sql" jsonschema_description:"The SQL query to explain"`
 
-	// Type is the explain type: logical, distributed, io, or validate.
-	Type string `json:"type,omitempty" jsonschema_description:"Explain type: logical (default), distributed, io, or validate"`
+	// Type is the explain type: logical, distributed, io, validate,
+	// analyze, or analyze_verbose. The analyze types run the query.
+	Type string `json:"type,omitempty" jsonschema_description:"Explain type: logical (default), distributed, io, validate, analyze, or analyze_verbose. analyze runs the query and reports actual CPU, time, and rows per operator"` //nolint:lll // jsonschema_description must be a single tag value
+
+	// TimeoutSeconds is the timeout of the analyze types, which run the
+	// query, in seconds. Default: 120, Max: 300.
+	TimeoutSeconds int `json:"timeout_seconds,omitempty" jsonschema_description:"Timeout in seconds for analyze and analyze_verbose, which run the query (default: 120, max: 300)"` //nolint:lll // jsonschema_description must be a single tag value
 
 	// Connection is the named connection to use. Empty uses the default connection.
 	// Use trino_list_connections to see available connections.
 	Connection string `json:"connection,omitempty" jsonschema_description:"Named connection to use (see trino_list_connections)"`
 }
 
+// planExplainer is implemented by clients that return a plan as a tree,
+// such as *client.Client.
+type planExplainer interface {
+	ExplainPlan(ctx context.Context, sql string, explainType client.ExplainType) (*client.QueryPlan, error)
+}
+
+// explainAnalyzer is implemented by clients that run EXPLAIN ANALYZE,
+// such as *client.Client.
+type explainAnalyzer interface {
+	ExplainAnalyze(ctx context.Context, sql string, verbose bool, opts client.QueryOptions) (*client.ExplainAnalyzeResult, error)
+}
+
+// maxHotOperators is the number of operators, most CPU time first, that
+// EXPLAIN ANALYZE reports.
+const maxHotOperators = 10
+
+// maxCostlyOperators is the number of operators, most expensive first,
+// listed in the plan analysis text.
+const maxCostlyOperators = 5
+
 // registerExplainTool adds the trino_explain tool to the server.
 //
 //nolint:dupl // Each tool registration requires distinct types for type-safe handlers.
@@ -64,13 +92,21 @@ func (t *Toolkit) handleExplain(ctx context.Context, _ *mcp.CallToolRequest, inp
 		return ErrorResult(err.Error()), nil, nil
 	}
 
+	// EXPLAIN ANALYZE runs the statement, so it is read-only like trino_query
+	analyze := input.Type == "analyze" || input.Type == "analyze_verbose"
+	if analyze && IsWriteSQL(input.SQL) {
+		return ErrorResult("EXPLAIN ANALYZE runs the statement, so it is limited to read operations — " +
+			"write operations (INSERT, UPDATE, DELETE, CREATE, DROP, etc.) and session changes (SET SESSION, USE) are not allowed."), nil, nil
+	}
+
 	// Apply query interceptors
+	ctx = WithConnection(ctx, input.Connection)
 	sql, err := t.InterceptSQL(ctx, input.SQL, ToolExplain)
 	if err != nil {
 		return ErrorResult(fmt.Sprintf("Query rejected: %v", err)), nil, nil
 	}
 
-	// Map type string to ExplainType
+	// Map type string to ExplainType; the analyze types are handled apart
 	var explainType client.ExplainType
 	switch input.Type {
 	case "distributed":
@@ -89,6 +125,11 @@ func (t *Toolkit) handleExplain(ctx context.Context, _ *mcp.CallToolRequest, inp
 		return ErrorResult(fmt.Sprintf("Connection error: %v", err)), nil, nil
 	}
 
+	if analyze {
+		opts := client.QueryOptions{Timeout: t.queryTimeout(input.TimeoutSeconds)}
+		return explainAnalyze(ctx, trinoClient, sql, input.Type == "analyze_verbose", opts)
+	}
+
 	result, err := trinoClient.Explain(ctx, sql, explainType)
 	if err != nil {
 		return ErrorResult(fmt.Sprintf("Explain failed: %v", err)), nil, nil
@@ -101,9 +142,222 @@ func (t *Toolkit) handleExplain(ctx context.Context, _ *mcp.CallToolRequest, inp
 		Type: string(result.Type),
 	}
 
+	// Only logical and distributed plans have a JSON form to analyze
+	if pe, ok := trinoClient.(planExplainer); ok &&
+		(explainType == client.ExplainLogical || explainType == client.ExplainDistributed) {
+		output += explainAnalysis(ctx, pe, sql, explainType, &explainOutput)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: output},
+		},
+	}, &explainOutput, nil
+}
+
+// explainAnalysis fetches the plan as JSON, adds its analysis to out, and
+// returns the analysis text. The analysis is best-effort: if the JSON plan
+// cannot be fetched, the text says so and out is left unchanged.
+func explainAnalysis(
+	ctx context.Context, pe planExplainer, sql string, explainType client.ExplainType, out *ExplainOutput,
+) string {
+	plan, err := pe.ExplainPlan(ctx, sql, explainType)
+	if err != nil {
+		return fmt.Sprintf("\n\n*Plan analysis unavailable: %v*", err)
+	}
+	analysis := buildExplainAnalysis(client.AnalyzePlan(plan))
+	out.Analysis = analysis
+	return formatExplainAnalysis(analysis)
+}
+
+func buildExplainAnalysis(a client.PlanAnalysis) *ExplainAnalysis {
+	out := &ExplainAnalysis{
+		Output:   buildPlanEstimate(a.Output),
+		Nodes:    make([]ExplainPlanNode, 0, len(a.Nodes)),
+		Findings: make([]ExplainFinding, 0, len(a.Findings)),
+	}
+	for _, n := range a.Nodes {
+		out.Nodes = append(out.Nodes, ExplainPlanNode{
+			Fragment: n.Fragment,
+			ID:       n.ID,
+			Name:     n.Name,
+			Table:    n.Table,
+			Estimate: buildPlanEstimate(n.Estimate),
+		})
+	}
+	for _, f := range a.Findings {
+		out.Findings = append(out.Findings, ExplainFinding(f))
+	}
+	return out
+}
+
+func buildPlanEstimate(e client.PlanEstimate) ExplainEstimate {
+	return ExplainEstimate{
+		Rows:        e.OutputRowCount,
+		Bytes:       e.OutputSizeInBytes,
+		CPUCost:     e.CPUCost,
+		MemoryCost:  e.MemoryCost,
+		NetworkCost: e.NetworkCost,
+	}
+}
+
+// formatExplainAnalysis renders the estimated output, the findings, and
+// the operators with the highest estimated CPU cost.
+func formatExplainAnalysis(a *ExplainAnalysis) string {
+	var sb strings.Builder
+	sb.WriteString("\n\n### Plan Analysis\n\n")
+	sb.WriteString("**Estimated output:** " + formatEstimate(a.Output) + "\n\n")
+
+	if len(a.Findings) == 0 {
+		sb.WriteString("No full table scans, cross joins, missing partition pruning, or large broadcast joins found.\n")
+	} else {
+		sb.WriteString("**Findings:**\n")
+		for _, f := range a.Findings {
+			fmt.Fprintf(&sb, "- **%s** (node %s): %s\n", f.Kind, f.NodeID, f.Message)
+		}
+	}
+
+	costly := make([]ExplainPlanNode, 0, len(a.Nodes))
+	for _, n := range a.Nodes {
+		if n.Estimate.CPUCost != nil {
+			costly = append(costly, n)
+		}
+	}
+	if len(costly) == 0 {
+		return strings.TrimSuffix(sb.String(), "\n")
+	}
+	slices.SortStableFunc(costly, func(a, b ExplainPlanNode) int {
+		return cmp.Compare(*b.Estimate.CPUCost, *a.Estimate.CPUCost)
+	})
+	costly = costly[:min(len(costly), maxCostlyOperators)]
+
+	sb.WriteString("\n**Most expensive operators (estimated):**\n\n")
+	sb.WriteString("| Node | Operator | Table | Rows | Size | CPU | Memory | Network |\n")
+	sb.WriteString("|------|----------|-------|------|------|-----|--------|---------|\n")
+	for _, n := range costly {
+		e := n.Estimate
+		fmt.Fprintf(&sb, "| %s | %s | %s | %s | %s | %s | %s | %s |\n",
+			n.ID, n.Name, n.Table, formatPlanNumber(e.Rows), formatPlanBytes(e.Bytes),
+			formatPlanNumber(e.CPUCost), formatPlanNumber(e.MemoryCost), formatPlanNumber(e.NetworkCost))
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// formatEstimate renders an output estimate, e.g. "1500 rows, 13.2 KiB".
+func formatEstimate(e ExplainEstimate) string {
+	if e.Rows == nil {
+		return "unknown (no table statistics)"
+	}
+	s := formatPlanNumber(e.Rows) + " rows"
+	if e.Bytes != nil {
+		s += ", " + formatPlanBytes(e.Bytes)
+	}
+	return s
+}
+
+// formatPlanNumber renders an estimate, or "?" if it is unknown.
+func formatPlanNumber(v *float64) string {
+	if v == nil {
+		return "?"
+	}
+	return fmt.Sprintf("%.0f", *v)
+}
+
+func formatPlanBytes(v *float64) string {
+	if v == nil {
+		return "?"
+	}
+	return formatBytes(int64(*v))
+}
+
+// explainAnalyze runs EXPLAIN ANALYZE and reports the plan with its stages
+// and hottest operators.
+func explainAnalyze(
+	ctx context.Context, trinoClient TrinoClient, sql string, verbose bool, opts client.QueryOptions,
+) (*mcp.CallToolResult, any, error) {
+	ea, ok := trinoClient.(explainAnalyzer)
+	if !ok {
+		return ErrorResult("EXPLAIN ANALYZE is not supported by this connection's client"), nil, nil
+	}
+	result, err := ea.ExplainAnalyze(ctx, sql, verbose, opts)
+	if err != nil {
+		return ErrorResult(fmt.Sprintf("Explain failed: %v", err)), nil, nil
+	}
+
+	explainOutput := ExplainOutput{
+		Plan:         result.Plan,
+		Type:         "ANALYZE",
+		Stages:       make([]ExplainStage, 0, len(result.Stages)),
+		HotOperators: make([]ExplainOperator, 0, maxHotOperators),
+	}
+	if verbose {
+		explainOutput.Type = "ANALYZE VERBOSE"
+	}
+	for _, s := range result.Stages {
+		explainOutput.Stages = append(explainOutput.Stages, ExplainStage(s))
+	}
+	for _, op := range result.HotOperators(maxHotOperators) {
+		explainOutput.HotOperators = append(explainOutput.HotOperators, ExplainOperator(op))
+	}
+
+	output := fmt.Sprintf("## Execution Plan (%s)\n\n```\n%s\n```", explainOutput.Type, result.Plan) +
+		formatHotOperators(explainOutput.HotOperators) + formatStages(explainOutput.Stages)
+
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: output},
 		},
 	}, &explainOutput, nil
 }
+
+// formatHotOperators renders the operators that used the most CPU time.
+func formatHotOperators(ops []ExplainOperator) string {
+	if len(ops) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("\n\n### Hot Operators\n\n")
+	sb.WriteString("| # | Operator | Fragment | CPU | CPU % | Wall | Input Rows | Output Rows | Spilled |\n")
+	sb.WriteString("|---|----------|----------|-----|-------|------|------------|-------------|---------|\n")
+	for i, op := range ops {
+		name := op.Name
+		if op.Table != "" {
+			name += " " + op.Table
+		}
+		input, spilled := "", ""
+		if op.InputRows > 0 {
+			input = fmt.Sprintf("%d", op.InputRows)
+		}
+		if op.SpilledBytes > 0 {
+			spilled = formatBytes(op.SpilledBytes)
+		}
+		fmt.Fprintf(&sb, "| %d | %s | %s | %s | %.2f%% | %s | %s | %d | %s |\n",
+			i+1, name, op.Fragment, formatMs(op.CPUMs), op.CPUPercent, formatMs(op.WallMs), input, op.OutputRows, spilled)
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// formatStages renders the runtime statistics of each stage.
+func formatStages(stages []ExplainStage) string {
+	if len(stages) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("\n\n### Stages\n\n")
+	sb.WriteString("| Fragment | Distribution | CPU | Wall | Input | Output | Peak Memory |\n")
+	sb.WriteString("|----------|--------------|-----|------|-------|--------|-------------|\n")
+	for _, s := range stages {
+		fmt.Fprintf(&sb, "| %s | %s | %s | %s | %d rows (%s) | %d rows (%s) | %s |\n",
+			s.Fragment, s.Distribution, formatMs(s.CPUMs), formatMs(s.WallMs),
+			s.InputRows, formatBytes(s.InputBytes), s.OutputRows, formatBytes(s.OutputBytes), formatBytes(s.PeakMemoryBytes))
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// formatMs renders a duration in milliseconds, e.g. "410.00ms" or "1.12s".
+func formatMs(ms float64) string {
+	if ms >= 1000 {
+		return fmt.Sprintf("%.2fs", ms/1000)
+	}
+	return fmt.Sprintf("%.2fms", ms)
+}
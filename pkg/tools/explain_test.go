@@ -1,7 +1,13 @@
 package tools
 
 import (
+	"context"
+	"errors"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
 
 	"github.com/txn2/mcp-trino/pkg/client"
 )
@@ -140,3 +146,216 @@ func TestExplainInput_AllTypes(t *testing.T) {
 		})
 	}
 }
+
+// planMockClient is a MockTrinoClient that also returns JSON plans.
+type planMockClient struct {
+	*MockTrinoClient
+	plan     *client.QueryPlan
+	err      error
+	planType client.ExplainType
+}
+
+func (m *planMockClient) ExplainPlan(_ context.Context, _ string, explainType client.ExplainType) (*client.QueryPlan, error) {
+	m.planType = explainType
+	return m.plan, m.err
+}
+
+func planFloat(f float64) *float64 { return &f }
+
+func TestHandleExplain_Analysis(t *testing.T) {
+	scan := &client.PlanNode{
+		ID: "0", Name: "TableScan", Descriptor: map[string]string{"table": "hive:sales:orders"},
+		Estimates: []client.PlanEstimate{{OutputRowCount: planFloat(1e6), OutputSizeInBytes: planFloat(2048), CPUCost: planFloat(9e6)}},
+	}
+	plan := &client.QueryPlan{Type: client.ExplainLogical, Fragments: []client.PlanFragment{{Root: &client.PlanNode{
+		ID: "1", Name: "Output", Children: []*client.PlanNode{scan},
+		Estimates: []client.PlanEstimate{{OutputRowCount: planFloat(1e6), OutputSizeInBytes: planFloat(2048)}},
+	}}}}
+
+	t.Run("logical", func(t *testing.T) {
+		mock := &planMockClient{MockTrinoClient: NewMockTrinoClient(), plan: plan}
+		toolkit := NewToolkit(mock, DefaultConfig())
+
+		result, out, err := toolkit.handleExplain(context.Background(), nil, ExplainInput{SQL: "SELECT * FROM orders"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if mock.planType != client.ExplainLogical {
+			t.Errorf("ExplainPlan type = %q, want LOGICAL", mock.planType)
+		}
+
+		analysis := out.(*ExplainOutput).Analysis
+		if analysis == nil || len(analysis.Nodes) != 2 || len(analysis.Findings) != 1 {
+			t.Fatalf("unexpected analysis: %+v", analysis)
+		}
+		if f := analysis.Findings[0]; f.Kind != client.FindingFullTableScan || f.Table != "hive:sales:orders" {
+			t.Errorf("unexpected finding: %+v", f)
+		}
+
+		text := result.Content[0].(*mcp.TextContent).Text
+		for _, want := range []string{
+			"### Plan Analysis",
+			"**Estimated output:** 1000000 rows, 2.0 KiB",
+			"- **full_table_scan** (node 0): TableScan reads all of hive:sales:orders (estimated 1000000 rows)",
+			"| 0 | TableScan | hive:sales:orders | 1000000 | 2.0 KiB | 9000000 | ? | ? |",
+		} {
+			if !strings.Contains(text, want) {
+				t.Errorf("expected %q in output:\n%s", want, text)
+			}
+		}
+	})
+
+	t.Run("io skips analysis", func(t *testing.T) {
+		mock := &planMockClient{MockTrinoClient: NewMockTrinoClient(), plan: plan}
+		toolkit := NewToolkit(mock, DefaultConfig())
+
+		_, out, err := toolkit.handleExplain(context.Background(), nil, ExplainInput{SQL: "SELECT 1", Type: "io"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if mock.planType != "" || out.(*ExplainOutput).Analysis != nil {
+			t.Error("expected no analysis for an IO plan")
+		}
+	})
+
+	t.Run("unavailable", func(t *testing.T) {
+		mock := &planMockClient{MockTrinoClient: NewMockTrinoClient(), err: errors.New("FORMAT JSON not supported")}
+		toolkit := NewToolkit(mock, DefaultConfig())
+
+		result, out, err := toolkit.handleExplain(context.Background(), nil, ExplainInput{SQL: "SELECT 1", Type: "distributed"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if out.(*ExplainOutput).Analysis != nil {
+			t.Error("expected no analysis")
+		}
+		text := result.Content[0].(*mcp.TextContent).Text
+		if !strings.Contains(text, "*Plan analysis unavailable: FORMAT JSON not supported*") ||
+			!strings.Contains(text, "Execution Plan") {
+			t.Errorf("expected the plan and an unavailable note, got:\n%s", text)
+		}
+	})
+}
+
+// analyzeMockClient is a MockTrinoClient that also runs EXPLAIN ANALYZE.
+type analyzeMockClient struct {
+	*MockTrinoClient
+	result  *client.ExplainAnalyzeResult
+	sql     string
+	verbose bool
+	opts    client.QueryOptions
+}
+
+func (m *analyzeMockClient) ExplainAnalyze(
+	_ context.Context, sql string, verbose bool, opts client.QueryOptions,
+) (*client.ExplainAnalyzeResult, error) {
+	m.sql, m.verbose, m.opts = sql, verbose, opts
+	return m.result, nil
+}
+
+func TestHandleExplain_Analyze(t *testing.T) {
+	result := &client.ExplainAnalyzeResult{
+		Plan:   "Fragment 1 [SOURCE]\n    TableScan[table = tpch:sf1:orders]",
+		Stages: []client.StageStats{{Fragment: "1", Distribution: "SOURCE", CPUMs: 1170, WallMs: 1720, OutputRows: 150000}},
+		Operators: []client.OperatorStats{
+			{Fragment: "1", Name: "Aggregate", CPUMs: 90, CPUPercent: 7.6, WallMs: 110, OutputRows: 150000},
+			{
+				Fragment: "1", Name: "TableScan", Table: "tpch:sf1:orders", CPUMs: 1080, CPUPercent: 92.4, WallMs: 1610,
+				InputRows: 1500000, OutputRows: 1500000, SpilledBytes: 2048,
+			},
+		},
+	}
+
+	t.Run("analyze_verbose", func(t *testing.T) {
+		mock := &analyzeMockClient{MockTrinoClient: NewMockTrinoClient(), result: result}
+		toolkit := NewToolkit(mock, DefaultConfig())
+
+		res, out, err := toolkit.handleExplain(context.Background(), nil, ExplainInput{
+			SQL: "SELECT orderstatus, count(*) FROM orders GROUP BY 1", Type: "analyze_verbose",
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if mock.ExplainCalled || !mock.verbose || mock.sql != "SELECT orderstatus, count(*) FROM orders GROUP BY 1" {
+			t.Errorf("expected ExplainAnalyze(verbose) only, got sql %q verbose %v", mock.sql, mock.verbose)
+		}
+		if mock.opts.Timeout != DefaultConfig().DefaultTimeout {
+			t.Errorf("expected the default timeout, got %v", mock.opts.Timeout)
+		}
+
+		explainOut := out.(*ExplainOutput)
+		if explainOut.Type != "ANALYZE VERBOSE" || len(explainOut.Stages) != 1 {
+			t.Errorf("unexpected output: %+v", explainOut)
+		}
+		if len(explainOut.HotOperators) != 2 || explainOut.HotOperators[0].Name != "TableScan" {
+			t.Errorf("expected TableScan as the hottest operator, got %+v", explainOut.HotOperators)
+		}
+
+		text := res.Content[0].(*mcp.TextContent).Text
+		for _, want := range []string{
+			"## Execution Plan (ANALYZE VERBOSE)",
+			"| 1 | TableScan tpch:sf1:orders | 1 | 1.08s | 92.40% | 1.61s | 1500000 | 1500000 | 2.0 KiB |",
+			"| 2 | Aggregate | 1 | 90.00ms | 7.60% | 110.00ms |  | 150000 |  |",
+			"| 1 | SOURCE | 1.17s | 1.72s | 0 rows (0 B) | 150000 rows (0 B) | 0 B |",
+		} {
+			if !strings.Contains(text, want) {
+				t.Errorf("expected %q in output:\n%s", want, text)
+			}
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		mock := &analyzeMockClient{MockTrinoClient: NewMockTrinoClient(), result: result}
+		toolkit := NewToolkit(mock, DefaultConfig())
+
+		for seconds, want := range map[int]time.Duration{30: 30 * time.Second, 3600: DefaultConfig().MaxTimeout} {
+			_, _, err := toolkit.handleExplain(context.Background(), nil, ExplainInput{SQL: "SELECT 1", Type: "analyze", TimeoutSeconds: seconds})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if mock.opts.Timeout != want {
+				t.Errorf("timeout_seconds %d: got timeout %v, want %v", seconds, mock.opts.Timeout, want)
+			}
+		}
+	})
+
+	t.Run("refuses writes", func(t *testing.T) {
+		mock := &analyzeMockClient{MockTrinoClient: NewMockTrinoClient(), result: result}
+		toolkit := NewToolkit(mock, DefaultConfig())
+
+		for _, sql := range []string{"INSERT INTO t SELECT * FROM orders", "EXPLAIN ANALYZE INSERT INTO t VALUES (1)"} {
+			res, _, err := toolkit.handleExplain(context.Background(), nil, ExplainInput{SQL: sql, Type: "analyze"})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !res.IsError || mock.sql != "" {
+				t.Errorf("expected %q to be refused without running", sql)
+			}
+		}
+	})
+
+	t.Run("plain explain of a write is allowed", func(t *testing.T) {
+		mock := NewMockTrinoClient()
+		toolkit := NewToolkit(mock, DefaultConfig())
+
+		res, _, err := toolkit.handleExplain(context.Background(), nil, ExplainInput{SQL: "INSERT INTO t VALUES (1)"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if res.IsError || !mock.ExplainCalled {
+			t.Error("expected EXPLAIN of a write to run, since it does not execute the statement")
+		}
+	})
+
+	t.Run("unsupported client", func(t *testing.T) {
+		toolkit := NewToolkit(NewMockTrinoClient(), DefaultConfig())
+
+		res, _, err := toolkit.handleExplain(context.Background(), nil, ExplainInput{SQL: "SELECT 1", Type: "analyze"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !res.IsError {
+			t.Error("expected an error result")
+		}
+	})
+}
@@ -0,0 +1,61 @@
+package tools
+
+import (
+	"context"
+)
+
+// ObjectFilter restricts which catalogs, schemas, and tables the schema
+// tools expose. trino_browse, resources, and argument completion leave out
+// objects the filter rejects, and trino_describe_table and browsing inside
+// a rejected catalog or schema fail with the filter's error. Use filters
+// for access policies; restrict SQL with a QueryInterceptor.
+type ObjectFilter interface {
+	// CheckObject returns an error, naming the object, if it must not be
+	// shown. Table is empty for a schema, and schema and table are empty
+	// for a catalog. The connection of the tool call is available through
+	// GetConnection.
+	CheckObject(ctx context.Context, catalog, schema, table string) error
+}
+
+// ObjectFilterFunc allows using a function as an object filter.
+type ObjectFilterFunc func(ctx context.Context, catalog, schema, table string) error
+
+// CheckObject implements ObjectFilter.
+func (f ObjectFilterFunc) CheckObject(ctx context.Context, catalog, schema, table string) error {
+	return f(ctx, catalog, schema, table)
+}
+
+// checkObject runs all object filters, stopping at the first rejection.
+func (t *Toolkit) checkObject(ctx context.Context, catalog, schema, table string) error {
+	for _, f := range t.objectFilters {
+		if err := f.CheckObject(ctx, catalog, schema, table); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// filterNames returns the names the object filters accept: catalog names
+// if catalog is empty, schema names in catalog if schema is empty, and
+// table names in catalog.schema otherwise.
+func (t *Toolkit) filterNames(ctx context.Context, catalog, schema string, names []string) []string {
+	if len(t.objectFilters) == 0 {
+		return names
+	}
+	kept := make([]string, 0, len(names))
+	for _, name := range names {
+		var err error
+		switch {
+		case catalog == "":
+			err = t.checkObject(ctx, name, "", "")
+		case schema == "":
+			err = t.checkObject(ctx, catalog, name, "")
+		default:
+			err = t.checkObject(ctx, catalog, schema, name)
+		}
+		if err == nil {
+			kept = append(kept, name)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,208 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// denyFilter rejects the hive catalog's pii schema and the system catalog,
+// recording the connection of each check.
+type denyFilter struct {
+	connections []string
+}
+
+func (f *denyFilter) CheckObject(ctx context.Context, catalog, schema, table string) error {
+	f.connections = append(f.connections, GetConnection(ctx))
+	switch {
+	case catalog == "system":
+		return errors.New(`catalog "system" is not allowed`)
+	case catalog == "hive" && schema == "pii":
+		return fmt.Errorf("schema %q is not allowed", catalog+"."+schema)
+	case table == "salaries":
+		return fmt.Errorf("table %q is not allowed", table)
+	}
+	return nil
+}
+
+func filterMock() *MockTrinoClient {
+	mock := NewMockTrinoClient()
+	mock.ListCatalogsFunc = func(_ context.Context) ([]string, error) {
+		return []string{"hive", "system", "iceberg"}, nil
+	}
+	mock.ListSchemasFunc = func(_ context.Context, _ string) ([]string, error) {
+		return []string{"sales", "pii"}, nil
+	}
+	return mock
+}
+
+func resultText(t *testing.T, result *mcp.CallToolResult) string {
+	t.Helper()
+	text, ok := result.Content[0].(*mcp.TextContent)
+	if !ok {
+		t.Fatal("expected TextContent")
+	}
+	return text.Text
+}
+
+func TestHandleBrowse_ObjectFilter(t *testing.T) {
+	filter := &denyFilter{}
+	toolkit := NewToolkit(filterMock(), DefaultConfig(), WithObjectFilter(filter))
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		input     BrowseInput
+		wantItems []string
+		wantErr   string
+	}{
+		{name: "catalogs", input: BrowseInput{Connection: "prod"}, wantItems: []string{"hive", "iceberg"}},
+		{name: "schemas", input: BrowseInput{Catalog: "hive"}, wantItems: []string{"sales"}},
+		{name: "tables", input: BrowseInput{Catalog: "hive", Schema: "sales"}, wantItems: []string{"users", "orders"}},
+		{name: "denied catalog", input: BrowseInput{Catalog: "system"}, wantErr: `catalog "system" is not allowed`},
+		{name: "denied schema", input: BrowseInput{Catalog: "hive", Schema: "pii"}, wantErr: `schema "hive.pii" is not allowed`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, out, err := toolkit.handleBrowse(ctx, nil, tt.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantErr != "" {
+				if !result.IsError || !strings.Contains(resultText(t, result), tt.wantErr) {
+					t.Errorf("expected error %q, got %q", tt.wantErr, resultText(t, result))
+				}
+				return
+			}
+			browse, ok := out.(*BrowseOutput)
+			if !ok {
+				t.Fatalf("expected *BrowseOutput, got %T", out)
+			}
+			if !reflect.DeepEqual(browse.Items, tt.wantItems) {
+				t.Errorf("items = %v, want %v", browse.Items, tt.wantItems)
+			}
+			if strings.Contains(resultText(t, result), "system") || strings.Contains(resultText(t, result), "pii") {
+				t.Errorf("filtered objects appear in text: %s", resultText(t, result))
+			}
+		})
+	}
+
+	if filter.connections[0] != "prod" {
+		t.Errorf("expected the filter to see connection prod, got %q", filter.connections[0])
+	}
+}
+
+func TestHandleBrowse_ObjectFilterTables(t *testing.T) {
+	mock := NewMockTrinoClient()
+	toolkit := NewToolkit(mock, DefaultConfig(), WithObjectFilter(ObjectFilterFunc(
+		func(_ context.Context, _, _, table string) error {
+			if table == "users" {
+				return errors.New("hidden")
+			}
+			return nil
+		})))
+
+	result, out, _ := toolkit.handleBrowse(context.Background(), nil, BrowseInput{Catalog: "hive", Schema: "sales"})
+	browse, ok := out.(*BrowseOutput)
+	if !ok {
+		t.Fatalf("expected *BrowseOutput, got %T", out)
+	}
+	if browse.Count != 1 || browse.Items[0] != "orders" {
+		t.Errorf("expected only orders, got %v", browse.Items)
+	}
+	if strings.Contains(resultText(t, result), "users") {
+		t.Error("hidden table appears in text")
+	}
+}
+
+func TestHandleDescribeTable_ObjectFilter(t *testing.T) {
+	mock := NewMockTrinoClient()
+	toolkit := NewToolkit(mock, DefaultConfig(), WithObjectFilter(&denyFilter{}))
+
+	result, _, err := toolkit.handleDescribeTable(context.Background(), nil, DescribeTableInput{
+		Catalog: "hive", Schema: "hr", Table: "salaries",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.IsError || !strings.Contains(resultText(t, result), `table "salaries" is not allowed`) {
+		t.Errorf("expected rejection naming the table, got %q", resultText(t, result))
+	}
+	if mock.DescribeTableCalled {
+		t.Error("DescribeTable should not be called for a rejected table")
+	}
+
+	result, _, _ = toolkit.handleDescribeTable(context.Background(), nil, DescribeTableInput{
+		Catalog: "hive", Schema: "hr", Table: "employees",
+	})
+	if result.IsError {
+		t.Errorf("unexpected error: %s", resultText(t, result))
+	}
+}
+
+func TestInterceptSQL_Connection(t *testing.T) {
+	var got []string
+	interceptor := QueryInterceptorFunc(func(ctx context.Context, sql string, _ ToolName) (string, error) {
+		catalog, schema := GetDefaultSchema(ctx)
+		got = append(got, GetConnection(ctx)+":"+catalog+"."+schema)
+		return sql, nil
+	})
+	toolkit := NewToolkit(NewMockTrinoClient(), DefaultConfig(), WithQueryInterceptor(interceptor))
+	ctx := context.Background()
+
+	_, _, _ = toolkit.handleQuery(ctx, nil, QueryInput{SQL: "SELECT 1", Connection: "staging", Catalog: "hive", Schema: "sales"})
+	_, _, _ = toolkit.handleExecute(ctx, nil, ExecuteInput{SQL: "SELECT 1", Connection: "prod"})
+	_, _, _ = toolkit.handleExplain(ctx, nil, ExplainInput{SQL: "SELECT 1"})
+
+	if want := []string{"staging:hive.sales", "prod:.", ":."}; !reflect.DeepEqual(got, want) {
+		t.Errorf("interceptor saw connections %q, want %q", got, want)
+	}
+}
+
+func TestComplete_ObjectFilter(t *testing.T) {
+	toolkit := NewToolkit(filterMock(), DefaultConfig(), WithObjectFilter(&denyFilter{}))
+	session := connectCompletion(t, toolkit)
+	ref := &mcp.CompleteReference{Type: "ref/resource", URI: ResourceTableTemplate}
+
+	complete := func(arg string, resolved map[string]string) []string {
+		t.Helper()
+		result, err := session.Complete(context.Background(), &mcp.CompleteParams{
+			Ref:      ref,
+			Argument: mcp.CompleteParamsArgument{Name: arg},
+			Context:  &mcp.CompleteContext{Arguments: resolved},
+		})
+		if err != nil {
+			t.Fatalf("Complete error: %v", err)
+		}
+		return result.Completion.Values
+	}
+
+	if got := complete("catalog", nil); !reflect.DeepEqual(got, []string{"hive", "iceberg"}) {
+		t.Errorf("catalogs = %v", got)
+	}
+	if got := complete("schema", map[string]string{"catalog": "hive"}); !reflect.DeepEqual(got, []string{"sales"}) {
+		t.Errorf("schemas = %v", got)
+	}
+}
+
+func TestReadResource_ObjectFilter(t *testing.T) {
+	session := connectResources(t, NewToolkit(filterMock(), DefaultConfig(), WithObjectFilter(&denyFilter{})))
+
+	var catalogs ResourceListing
+	readJSONResource(t, session, "trino://default", &catalogs)
+	if !reflect.DeepEqual(catalogs.Items, []string{"hive", "iceberg"}) {
+		t.Errorf("catalogs = %v", catalogs.Items)
+	}
+
+	for _, uri := range []string{"trino://default/system", "trino://default/hive/pii", "trino://default/hive/hr/salaries"} {
+		if _, err := session.ReadResource(context.Background(), &mcp.ReadResourceParams{URI: uri}); err == nil {
+			t.Errorf("expected error reading %s", uri)
+		}
+	}
+}
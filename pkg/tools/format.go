@@ -37,7 +37,7 @@ func validateFormat(format string) error {
 
 // validExplainTypes lists the accepted explain type values.
 // Must be kept in sync with the switch in handleExplain.
-var validExplainTypes = []string{"logical", "distributed", "io", "validate"}
+var validExplainTypes = []string{"logical", "distributed", "io", "validate", "analyze", "analyze_verbose"}
 
 // validateExplainType checks that the given explain type is valid.
 // An empty string is always valid (defaults to "logical").
@@ -108,11 +108,10 @@ func formatCSV(qo *QueryOutput) string {
 	}
 
 	// Stats footer
-	output += fmt.Sprintf("\n# %d rows returned", qo.Stats.RowCount)
-	if qo.Stats.Truncated {
-		output += fmt.Sprintf(" (truncated at limit %d)", qo.Stats.LimitApplied)
+	output += "\n# " + statsSummary(qo.Stats)
+	for _, w := range qo.Stats.Warnings {
+		output += "\n# Warning: " + formatWarning(w)
 	}
-	output += fmt.Sprintf(", executed in %dms", qo.Stats.DurationMs)
 
 	return output
 }
@@ -152,15 +151,71 @@ func formatMarkdown(qo *QueryOutput) string {
 	}
 
 	// Stats footer
-	output += fmt.Sprintf("\n*%d rows returned", qo.Stats.RowCount)
-	if qo.Stats.Truncated {
-		output += fmt.Sprintf(" (truncated at limit %d)", qo.Stats.LimitApplied)
+	output += "\n*" + statsSummary(qo.Stats) + "*"
+	for _, w := range qo.Stats.Warnings {
+		output += "\n\n> **Warning:** " + formatWarning(w)
 	}
-	output += fmt.Sprintf(", executed in %dms*", qo.Stats.DurationMs)
 
 	return output
 }
 
+// statsSummary renders the one-line stats footer shared by the CSV and
+// Markdown formats, e.g. "2 rows returned, executed in 120ms (cpu 340ms,
+// queued 7ms, 1500 rows / 2.0 KiB processed, peak memory 4.0 KiB, 12 splits)".
+func statsSummary(stats QueryStats) string {
+	summary := fmt.Sprintf("%d rows returned", stats.RowCount)
+	if stats.Truncated {
+		summary += fmt.Sprintf(" (truncated at limit %d)", stats.LimitApplied)
+	}
+	summary += fmt.Sprintf(", executed in %dms", stats.DurationMs)
+
+	var details []string
+	if stats.CPUTimeMs > 0 {
+		details = append(details, fmt.Sprintf("cpu %dms", stats.CPUTimeMs))
+	}
+	if stats.QueuedTimeMs > 0 {
+		details = append(details, fmt.Sprintf("queued %dms", stats.QueuedTimeMs))
+	}
+	if stats.ProcessedRows > 0 || stats.ProcessedBytes > 0 {
+		details = append(details, fmt.Sprintf("%d rows / %s processed", stats.ProcessedRows, formatBytes(stats.ProcessedBytes)))
+	}
+	if stats.PeakMemoryBytes > 0 {
+		details = append(details, "peak memory "+formatBytes(stats.PeakMemoryBytes))
+	}
+	if stats.Splits > 0 {
+		details = append(details, fmt.Sprintf("%d splits", stats.Splits))
+	}
+	if stats.Attempts > 1 {
+		details = append(details, fmt.Sprintf("%d attempts", stats.Attempts))
+	}
+	if len(details) > 0 {
+		summary += " (" + strings.Join(details, ", ") + ")"
+	}
+	return summary
+}
+
+// formatWarning renders a Trino warning as "NAME: message".
+func formatWarning(w QueryWarning) string {
+	if w.Name == "" {
+		return w.Message
+	}
+	return w.Name + ": " + w.Message
+}
+
+// formatBytes renders a byte count with a binary unit, e.g. "1.5 MiB".
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
 // stringifyValue converts a value to its string representation.
 // For maps and slices (e.g. unwrapped JSON), it produces compact JSON.
 // For all other types, it uses fmt.Sprintf.
@@ -55,9 +55,11 @@ func TestValidateExplainType(t *testing.T) {
 		{name: "distributed is valid", typ: "distributed", wantErr: false},
 		{name: "io is valid", typ: "io", wantErr: false},
 		{name: "validate is valid", typ: "validate", wantErr: false},
+		{name: "analyze is valid", typ: "analyze", wantErr: false},
+		{name: "analyze_verbose is valid", typ: "analyze_verbose", wantErr: false},
 		{name: "LOGICAL uppercase is invalid", typ: "LOGICAL", wantErr: true, errMsg: `invalid explain type "LOGICAL"`},
 		{name: "unknown is invalid", typ: "unknown", wantErr: true, errMsg: "must be one of"},
-		{name: "error names valid values", typ: "bad", wantErr: true, errMsg: "logical, distributed, io, validate"},
+		{name: "error names valid values", typ: "bad", wantErr: true, errMsg: "logical, distributed, io, validate, analyze, analyze_verbose"},
 	}
 
 	for _, tt := range tests {
@@ -423,3 +425,98 @@ func TestUnwrapJSONColumn_CSVFormatOutput(t *testing.T) {
 		t.Errorf("CSV output should contain JSON key, got:\n%s", output)
 	}
 }
+
+func TestStatsSummary(t *testing.T) {
+	tests := []struct {
+		name  string
+		stats QueryStats
+		want  string
+	}{
+		{
+			name:  "basic",
+			stats: QueryStats{RowCount: 2, DurationMs: 120},
+			want:  "2 rows returned, executed in 120ms",
+		},
+		{
+			name:  "truncated",
+			stats: QueryStats{RowCount: 1, Truncated: true, LimitApplied: 1, DurationMs: 5},
+			want:  "1 rows returned (truncated at limit 1), executed in 5ms",
+		},
+		{
+			name: "coordinator stats",
+			stats: QueryStats{
+				RowCount: 2, DurationMs: 120, CPUTimeMs: 340, QueuedTimeMs: 7,
+				ProcessedRows: 1500, ProcessedBytes: 2048, PeakMemoryBytes: 3 << 20, Splits: 12,
+			},
+			want: "2 rows returned, executed in 120ms (cpu 340ms, queued 7ms, 1500 rows / 2.0 KiB processed, " +
+				"peak memory 3.0 MiB, 12 splits)",
+		},
+		{
+			name:  "retried",
+			stats: QueryStats{RowCount: 2, DurationMs: 900, Attempts: 3},
+			want:  "2 rows returned, executed in 900ms (3 attempts)",
+		},
+		{
+			name:  "single attempt",
+			stats: QueryStats{RowCount: 2, DurationMs: 120, Attempts: 1},
+			want:  "2 rows returned, executed in 120ms",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := statsSummary(tt.stats); got != tt.want {
+				t.Errorf("statsSummary() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		n    int64
+		want string
+	}{
+		{0, "0 B"},
+		{1023, "1023 B"},
+		{1536, "1.5 KiB"},
+		{5 << 30, "5.0 GiB"},
+	}
+	for _, tt := range tests {
+		if got := formatBytes(tt.n); got != tt.want {
+			t.Errorf("formatBytes(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestFormatOutput_Warnings(t *testing.T) {
+	qo := &QueryOutput{
+		Columns:  []QueryColumn{{Name: "id", Type: "INTEGER"}},
+		Rows:     []map[string]any{{"id": 1}},
+		RowCount: 1,
+		Stats: QueryStats{
+			RowCount: 1,
+			Warnings: []QueryWarning{{Code: 1, Name: "DEPRECATED_FUNCTION", Message: "function foo is deprecated"}},
+		},
+	}
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{format: "csv", want: "\n# Warning: DEPRECATED_FUNCTION: function foo is deprecated"},
+		{format: "markdown", want: "\n\n> **Warning:** DEPRECATED_FUNCTION: function foo is deprecated"},
+		{format: "json", want: `"name": "DEPRECATED_FUNCTION"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			output, err := formatOutput(qo, tt.format)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !strings.Contains(output, tt.want) {
+				t.Errorf("expected output to contain %q, got:\n%s", tt.want, output)
+			}
+		})
+	}
+}
@@ -3,11 +3,13 @@ package tools
 import (
 	"context"
 	"errors"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/trinodb/trino-go-client/trino"
 
 	"github.com/txn2/mcp-trino/pkg/client"
 )
@@ -437,6 +439,70 @@ func TestHandleBrowse_Tables_WithPattern(t *testing.T) {
 	}
 }
 
+// TestHandleBrowse_Tables_ByType tests the object type filter and how views
+// and comments are listed.
+func TestHandleBrowse_Tables_ByType(t *testing.T) {
+	mock := NewMockTrinoClient()
+	mock.ListTablesFunc = func(_ context.Context, catalog, schema string) ([]client.TableInfo, error) {
+		return []client.TableInfo{
+			{Catalog: catalog, Schema: schema, Name: "orders", Type: client.TableTypeTable, Comment: "All orders"},
+			{Catalog: catalog, Schema: schema, Name: "order_summary", Type: client.TableTypeView},
+			{Catalog: catalog, Schema: schema, Name: "daily_orders", Type: client.TableTypeMaterializedView},
+		}, nil
+	}
+	toolkit := NewToolkit(mock, DefaultConfig())
+
+	tests := []struct {
+		name      string
+		filter    string
+		wantItems []string
+		wantText  []string
+	}{
+		{
+			name:      "all types",
+			wantItems: []string{"orders", "order_summary", "daily_orders"},
+			wantText: []string{
+				"- `orders` — All orders", "- `order_summary` (view)", "- `daily_orders` (materialized view)",
+				"*3 tables found*", "Views run their defining query",
+			},
+		},
+		{name: "views", filter: "view", wantItems: []string{"order_summary"}, wantText: []string{"## Views in", "*1 views found*"}},
+		{
+			name: "materialized views", filter: "materialized_view", wantItems: []string{"daily_orders"},
+			wantText: []string{"## Materialized views in"},
+		},
+		{name: "tables", filter: "table", wantItems: []string{"orders"}, wantText: []string{"## Tables in"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, out, err := toolkit.handleBrowse(context.Background(), nil, BrowseInput{
+				Catalog: "hive",
+				Schema:  "sales",
+				Type:    tt.filter,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			browseOut, ok := out.(*BrowseOutput)
+			if !ok {
+				t.Fatalf("expected *BrowseOutput, got %T", out)
+			}
+			if !reflect.DeepEqual(browseOut.Items, tt.wantItems) {
+				t.Errorf("Items = %v, want %v", browseOut.Items, tt.wantItems)
+			}
+			if len(browseOut.Tables) != len(tt.wantItems) {
+				t.Fatalf("expected %d table details, got %d", len(tt.wantItems), len(browseOut.Tables))
+			}
+			text := result.Content[0].(*mcp.TextContent).Text
+			for _, want := range tt.wantText {
+				if !strings.Contains(text, want) {
+					t.Errorf("expected %q in output:\n%s", want, text)
+				}
+			}
+		})
+	}
+}
+
 // TestHandleBrowse_Tables_Error tests table listing error.
 func TestHandleBrowse_Tables_Error(t *testing.T) {
 	mock := NewMockTrinoClient()
@@ -529,6 +595,179 @@ func TestHandleDescribeTable_Success(t *testing.T) {
 	}
 }
 
+// TestHandleDescribeTable_View tests that views are labeled as such, with
+// their comment and a note on their cost.
+func TestHandleDescribeTable_View(t *testing.T) {
+	mock := NewMockTrinoClient()
+	mock.DescribeTableFunc = func(_ context.Context, catalog, schema, table string) (*client.TableInfo, error) {
+		return &client.TableInfo{
+			Catalog: catalog, Schema: schema, Name: table, Type: client.TableTypeView, Comment: "Orders joined with customers",
+			Columns: []client.ColumnDef{{Name: "id", Type: "bigint"}},
+		}, nil
+	}
+	toolkit := NewToolkit(mock, DefaultConfig())
+
+	result, out, err := toolkit.handleDescribeTable(context.Background(), nil, DescribeTableInput{
+		Catalog: "hive",
+		Schema:  "sales",
+		Table:   "order_details",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	text := result.Content[0].(*mcp.TextContent).Text
+	for _, want := range []string{"## View: `hive.sales.order_details`", "**Comment:** Orders joined with customers", "This is a view"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected %q in output:\n%s", want, text)
+		}
+	}
+	describeOut, ok := out.(*DescribeTableOutput)
+	if !ok {
+		t.Fatalf("expected *DescribeTableOutput, got %T", out)
+	}
+	if describeOut.Type != client.TableTypeView || describeOut.Comment != "Orders joined with customers" {
+		t.Errorf("unexpected type/comment: %q / %q", describeOut.Type, describeOut.Comment)
+	}
+}
+
+// TestHandleDescribeTable_Definition tests the DDL and table property
+// sections.
+func TestHandleDescribeTable_Definition(t *testing.T) {
+	const ddl = "CREATE TABLE iceberg.events.clicks (\n   id bigint,\n   ts timestamp(6)\n)\n" +
+		"WITH (\n   format = 'PARQUET',\n   partitioning = ARRAY['day(ts)','bucket(id, 16)']\n)"
+
+	tests := []struct {
+		name        string
+		input       DescribeTableInput
+		tableType   string
+		wantSQL     string
+		wantText    []string
+		rejectText  []string
+		wantDDL     bool
+		wantColumns []string
+	}{
+		{
+			name:    "properties only",
+			input:   DescribeTableInput{IncludeProperties: true},
+			wantSQL: `SHOW CREATE TABLE "iceberg"."events"."clicks"`,
+			wantText: []string{
+				"**Partition columns:** `ts`, `id`", "**Partitioning:** `day(ts)`, `bucket(id, 16)`", "| `format` | `'PARQUET'` |",
+			},
+			rejectText:  []string{"### DDL"},
+			wantColumns: []string{"ts", "id"},
+		},
+		{
+			name:       "DDL only",
+			input:      DescribeTableInput{IncludeDDL: true},
+			wantSQL:    `SHOW CREATE TABLE "iceberg"."events"."clicks"`,
+			wantText:   []string{"### DDL", "```sql\nCREATE TABLE iceberg.events.clicks"},
+			rejectText: []string{"### Partitioning"},
+			wantDDL:    true,
+		},
+		{
+			name:      "view",
+			input:     DescribeTableInput{IncludeDDL: true},
+			tableType: client.TableTypeView,
+			wantSQL:   `SHOW CREATE VIEW "iceberg"."events"."clicks"`,
+			wantText:  []string{"### DDL"},
+			wantDDL:   true,
+		},
+		{
+			name:        "materialized view",
+			input:       DescribeTableInput{IncludeProperties: true},
+			tableType:   client.TableTypeMaterializedView,
+			wantSQL:     `SHOW CREATE MATERIALIZED VIEW "iceberg"."events"."clicks"`,
+			wantText:    []string{"### Partitioning"},
+			wantColumns: []string{"ts", "id"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := NewMockTrinoClient()
+			mock.DescribeTableFunc = func(_ context.Context, catalog, schema, table string) (*client.TableInfo, error) {
+				return &client.TableInfo{Catalog: catalog, Schema: schema, Name: table, Type: tt.tableType}, nil
+			}
+			mock.QueryFunc = func(_ context.Context, _ string, _ client.QueryOptions) (*client.QueryResult, error) {
+				return &client.QueryResult{
+					Columns: []client.ColumnInfo{{Name: "Create Table", Type: "varchar"}},
+					Rows:    []map[string]any{{"Create Table": ddl}},
+				}, nil
+			}
+			toolkit := NewToolkit(mock, DefaultConfig())
+
+			input := tt.input
+			input.Catalog, input.Schema, input.Table = "iceberg", "events", "clicks"
+			result, out, err := toolkit.handleDescribeTable(context.Background(), nil, input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if mock.QuerySQL != tt.wantSQL {
+				t.Errorf("query = %q, want %q", mock.QuerySQL, tt.wantSQL)
+			}
+
+			text := result.Content[0].(*mcp.TextContent).Text
+			for _, want := range tt.wantText {
+				if !strings.Contains(text, want) {
+					t.Errorf("expected %q in output:\n%s", want, text)
+				}
+			}
+			for _, reject := range tt.rejectText {
+				if strings.Contains(text, reject) {
+					t.Errorf("unexpected %q in output:\n%s", reject, text)
+				}
+			}
+
+			describeOut, ok := out.(*DescribeTableOutput)
+			if !ok {
+				t.Fatalf("expected *DescribeTableOutput, got %T", out)
+			}
+			if (describeOut.DDL != "") != tt.wantDDL {
+				t.Errorf("DDL = %q, want present: %v", describeOut.DDL, tt.wantDDL)
+			}
+			if tt.wantColumns == nil {
+				if describeOut.Properties != nil {
+					t.Errorf("unexpected properties: %+v", describeOut.Properties)
+				}
+				return
+			}
+			if describeOut.Properties == nil {
+				t.Fatal("expected properties")
+			}
+			if !reflect.DeepEqual(describeOut.Properties.PartitionColumns, tt.wantColumns) {
+				t.Errorf("PartitionColumns = %v, want %v", describeOut.Properties.PartitionColumns, tt.wantColumns)
+			}
+		})
+	}
+}
+
+// TestHandleDescribeTable_DefinitionUnavailable tests that a failed SHOW
+// CREATE does not fail the description.
+func TestHandleDescribeTable_DefinitionUnavailable(t *testing.T) {
+	mock := NewMockTrinoClient()
+	mock.QueryFunc = func(_ context.Context, _ string, _ client.QueryOptions) (*client.QueryResult, error) {
+		return nil, errors.New("access denied")
+	}
+	toolkit := NewToolkit(mock, DefaultConfig())
+
+	result, out, err := toolkit.handleDescribeTable(context.Background(), nil, DescribeTableInput{
+		Catalog: "hive", Schema: "sales", Table: "orders", IncludeDDL: true, IncludeProperties: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatal("expected the description to succeed")
+	}
+	text := result.Content[0].(*mcp.TextContent).Text
+	if !strings.Contains(text, "Table definition unavailable: access denied") {
+		t.Errorf("expected unavailable note, got:\n%s", text)
+	}
+	if describeOut := out.(*DescribeTableOutput); describeOut.DDL != "" || describeOut.Properties != nil {
+		t.Errorf("expected no definition, got %+v", describeOut)
+	}
+}
+
 // TestHandleDescribeTable_WithSample tests table description with sample data.
 func TestHandleDescribeTable_WithSample(t *testing.T) {
 	mock := NewMockTrinoClient()
@@ -1523,3 +1762,133 @@ func TestRegisteredToolInvocation(t *testing.T) {
 		})
 	}
 }
+
+// TestHandleQuery_SessionInput tests that catalog, schema, and allowed
+// session properties reach the client.
+func TestHandleQuery_SessionInput(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.AllowedSessionProperties = []string{"query_max_execution_time", "hive.parquet_use_column_names"}
+
+	tests := []struct {
+		name      string
+		config    Config
+		props     map[string]string
+		wantError string
+	}{
+		{name: "no properties", config: DefaultConfig()},
+		{name: "allowed properties", config: cfg, props: map[string]string{
+			"query_max_execution_time": "5m", "HIVE.parquet_use_column_names": "true",
+		}},
+		{name: "property not allowed", config: cfg, props: map[string]string{"query_max_memory": "1TB"},
+			wantError: `session property "query_max_memory" is not allowed`},
+		{name: "properties not enabled", config: DefaultConfig(), props: map[string]string{"query_max_execution_time": "5m"},
+			wantError: "session_properties is not enabled"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got client.QueryOptions
+			mock := NewMockTrinoClient()
+			mock.QueryFunc = func(_ context.Context, _ string, opts client.QueryOptions) (*client.QueryResult, error) {
+				got = opts
+				return &client.QueryResult{}, nil
+			}
+			toolkit := NewToolkit(mock, tt.config)
+
+			inputs := map[ToolName]func() (*mcp.CallToolResult, any, error){
+				ToolQuery: func() (*mcp.CallToolResult, any, error) {
+					return toolkit.handleQuery(context.Background(), nil, QueryInput{
+						SQL: "SELECT * FROM orders", Catalog: "hive", Schema: "sales", SessionProperties: tt.props,
+					})
+				},
+				ToolExecute: func() (*mcp.CallToolResult, any, error) {
+					return toolkit.handleExecute(context.Background(), nil, ExecuteInput{
+						SQL: "DELETE FROM orders", Catalog: "hive", Schema: "sales", SessionProperties: tt.props,
+					})
+				},
+			}
+			for tool, call := range inputs {
+				got = client.QueryOptions{}
+				result, _, err := call()
+				if err != nil {
+					t.Fatalf("%s: unexpected error: %v", tool, err)
+				}
+				if tt.wantError != "" {
+					if !result.IsError || !strings.Contains(result.Content[0].(*mcp.TextContent).Text, tt.wantError) {
+						t.Errorf("%s: expected error containing %q, got %+v", tool, tt.wantError, result.Content)
+					}
+					continue
+				}
+				if result.IsError {
+					t.Fatalf("%s: unexpected error result: %+v", tool, result.Content)
+				}
+				if got.Catalog != "hive" || got.Schema != "sales" || !reflect.DeepEqual(got.SessionProperties, tt.props) {
+					t.Errorf("%s: unexpected options: %+v", tool, got)
+				}
+			}
+		})
+	}
+}
+
+func TestHandleQuery_Params(t *testing.T) {
+	params := []QueryParam{
+		{Value: float64(42)},
+		{Value: "O'Brien"},
+		{Value: "2024-03-01", Type: "date"},
+	}
+	want := []any{int64(42), "O'Brien", trino.Date(2024, 3, 1)}
+
+	var got client.QueryOptions
+	mock := NewMockTrinoClient()
+	mock.QueryFunc = func(_ context.Context, _ string, opts client.QueryOptions) (*client.QueryResult, error) {
+		got = opts
+		return &client.QueryResult{}, nil
+	}
+	toolkit := NewToolkit(mock, DefaultConfig())
+
+	result, _, err := toolkit.handleQuery(context.Background(), nil, QueryInput{
+		SQL: "SELECT * FROM customers WHERE id = ? AND name = ? AND since >= ?", Params: params,
+	})
+	if err != nil || result.IsError {
+		t.Fatalf("handleQuery() = %+v, %v", result, err)
+	}
+	if !reflect.DeepEqual(got.Args, want) {
+		t.Errorf("query args = %#v, want %#v", got.Args, want)
+	}
+
+	got = client.QueryOptions{}
+	result, _, err = toolkit.handleExecute(context.Background(), nil, ExecuteInput{
+		SQL: "DELETE FROM customers WHERE id = ? AND name = ? AND since < ?", Params: params,
+	})
+	if err != nil || result.IsError {
+		t.Fatalf("handleExecute() = %+v, %v", result, err)
+	}
+	if !reflect.DeepEqual(got.Args, want) {
+		t.Errorf("execute args = %#v, want %#v", got.Args, want)
+	}
+
+	result, _, _ = toolkit.handleQuery(context.Background(), nil, QueryInput{
+		SQL: "SELECT * FROM customers WHERE since >= ?", Params: []QueryParam{{Value: "yesterday", Type: "date"}},
+	})
+	if !result.IsError || !strings.Contains(result.Content[0].(*mcp.TextContent).Text, "Invalid params: params[0]: invalid date") {
+		t.Errorf("expected invalid date error, got %+v", result.Content)
+	}
+}
+
+func TestHandleQuery_DisableLimitPushdown(t *testing.T) {
+	var got []bool
+	mock := NewMockTrinoClient()
+	mock.QueryFunc = func(_ context.Context, _ string, opts client.QueryOptions) (*client.QueryResult, error) {
+		got = append(got, opts.DisableLimitPushdown)
+		return &client.QueryResult{}, nil
+	}
+	toolkit := NewToolkit(mock, DefaultConfig())
+
+	_, _, _ = toolkit.handleQuery(context.Background(), nil, QueryInput{SQL: "SELECT 1"})
+	_, _, _ = toolkit.handleQuery(context.Background(), nil, QueryInput{SQL: "SELECT 1", DisableLimitPushdown: true})
+	_, _, _ = toolkit.handleExecute(context.Background(), nil, ExecuteInput{SQL: "SELECT 1", DisableLimitPushdown: true})
+
+	if want := []bool{false, true, true}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DisableLimitPushdown passed = %v, want %v", got, want)
+	}
+}
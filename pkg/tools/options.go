@@ -1,6 +1,8 @@
 package tools
 
 import (
+	"time"
+
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 
 	"github.com/txn2/mcp-trino/pkg/semantic"
@@ -35,6 +37,24 @@ func WithResultTransformer(tr ResultTransformer) ToolkitOption {
 	}
 }
 
+// WithQueryOutputTransformer adds a transformer for the structured result
+// of trino_query and trino_execute. Transformers are executed in the order
+// added, before the result is formatted.
+func WithQueryOutputTransformer(tr QueryOutputTransformer) ToolkitOption {
+	return func(t *Toolkit) {
+		t.outputTransformers = append(t.outputTransformers, tr)
+	}
+}
+
+// WithObjectFilter adds a filter for the catalogs, schemas, and tables
+// that trino_browse, trino_describe_table, resources, and argument
+// completion expose. An object must pass every filter.
+func WithObjectFilter(f ObjectFilter) ToolkitOption {
+	return func(t *Toolkit) {
+		t.objectFilters = append(t.objectFilters, f)
+	}
+}
+
 // WithToolMiddleware adds middleware for a specific tool.
 // This middleware only runs when the named tool is executed.
 func WithToolMiddleware(name ToolName, m ToolMiddleware) ToolkitOption {
@@ -99,6 +119,34 @@ func WithIcons(icons map[ToolName][]mcp.Icon) ToolkitOption {
 	}
 }
 
+// WithPrompts adds custom prompts registered by RegisterPrompts alongside the
+// built-in prompts. A prompt named like a built-in prompt replaces it.
+//
+// Example:
+//
+//	toolkit := tools.NewToolkit(client, cfg,
+//	    tools.WithPrompts(tools.Prompt{
+//	        Name:        "weekly_sales",
+//	        Description: "Summarize last week's sales for a region",
+//	        Arguments:   []*mcp.PromptArgument{{Name: "region", Required: true}},
+//	        Template:    "Summarize last week's sales in {{.region}} from hive.sales.orders.",
+//	    }),
+//	)
+func WithPrompts(prompts ...Prompt) ToolkitOption {
+	return func(t *Toolkit) {
+		t.prompts = append(t.prompts, prompts...)
+	}
+}
+
+// WithCompletionCacheTTL sets how long catalog, schema, and table names are
+// cached for argument completion (see Toolkit.Complete). Zero disables caching.
+// Default: DefaultCompletionCacheTTL.
+func WithCompletionCacheTTL(ttl time.Duration) ToolkitOption {
+	return func(t *Toolkit) {
+		t.completions = newCompletionIndex(ttl)
+	}
+}
+
 // toolConfig holds per-registration configuration for a tool.
 type toolConfig struct {
 	middlewares []ToolMiddleware
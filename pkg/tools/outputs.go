@@ -15,17 +15,111 @@ type QueryColumn struct {
 }
 
 // QueryStats provides execution statistics for a query.
+// The coordinator-reported fields are omitted when Trino did not provide them.
 type QueryStats struct {
 	RowCount     int   `json:"row_count"`
 	Truncated    bool  `json:"truncated"`
 	LimitApplied int   `json:"limit_applied,omitempty"`
 	DurationMs   int64 `json:"duration_ms"`
+
+	QueryID         string         `json:"query_id,omitempty"`
+	Attempts        int            `json:"attempts,omitempty"`
+	CPUTimeMs       int64          `json:"cpu_time_ms,omitempty"`
+	QueuedTimeMs    int64          `json:"queued_time_ms,omitempty"`
+	ProcessedRows   int64          `json:"processed_rows,omitempty"`
+	ProcessedBytes  int64          `json:"processed_bytes,omitempty"`
+	PeakMemoryBytes int64          `json:"peak_memory_bytes,omitempty"`
+	Splits          int            `json:"splits,omitempty"`
+	Warnings        []QueryWarning `json:"warnings,omitempty"`
+}
+
+// QueryWarning is a warning Trino reported for a query.
+type QueryWarning struct {
+	Code    int    `json:"code"`
+	Name    string `json:"name"`
+	Message string `json:"message"`
 }
 
 // ExplainOutput defines the structured output of the trino_explain tool.
 type ExplainOutput struct {
 	Plan string `json:"plan"`
 	Type string `json:"type"`
+
+	// Analysis summarizes a logical or distributed plan. It is omitted for
+	// other explain types and when the JSON plan could not be fetched.
+	Analysis *ExplainAnalysis `json:"analysis,omitempty"`
+
+	// Stages and HotOperators hold the runtime statistics of the analyze
+	// and analyze_verbose types, which run the query. HotOperators lists
+	// the operators that used the most CPU time, highest first.
+	Stages       []ExplainStage    `json:"stages,omitempty"`
+	HotOperators []ExplainOperator `json:"hot_operators,omitempty"`
+}
+
+// ExplainStage holds the runtime statistics of a plan fragment.
+type ExplainStage struct {
+	Fragment        string  `json:"fragment"`
+	Distribution    string  `json:"distribution"`
+	CPUMs           float64 `json:"cpu_ms"`
+	WallMs          float64 `json:"wall_ms"`
+	InputRows       int64   `json:"input_rows"`
+	InputBytes      int64   `json:"input_bytes"`
+	OutputRows      int64   `json:"output_rows"`
+	OutputBytes     int64   `json:"output_bytes"`
+	PeakMemoryBytes int64   `json:"peak_memory_bytes,omitempty"`
+}
+
+// ExplainOperator holds the runtime statistics of a plan node. Input rows
+// and bytes are only reported for table scans.
+type ExplainOperator struct {
+	Fragment     string  `json:"fragment"`
+	Name         string  `json:"name"`
+	Table        string  `json:"table,omitempty"`
+	CPUMs        float64 `json:"cpu_ms"`
+	CPUPercent   float64 `json:"cpu_percent"`
+	WallMs       float64 `json:"wall_ms"`
+	InputRows    int64   `json:"input_rows,omitempty"`
+	InputBytes   int64   `json:"input_bytes,omitempty"`
+	OutputRows   int64   `json:"output_rows"`
+	OutputBytes  int64   `json:"output_bytes"`
+	SpilledBytes int64   `json:"spilled_bytes,omitempty"`
+}
+
+// ExplainAnalysis summarizes a query plan: the planner's estimates for each
+// operator and the patterns that commonly make a query slow or expensive.
+type ExplainAnalysis struct {
+	Output   ExplainEstimate   `json:"output"`
+	Nodes    []ExplainPlanNode `json:"nodes"`
+	Findings []ExplainFinding  `json:"findings"`
+}
+
+// ExplainPlanNode is an operator of the plan with its output estimate.
+type ExplainPlanNode struct {
+	Fragment string          `json:"fragment,omitempty"`
+	ID       string          `json:"id"`
+	Name     string          `json:"name"`
+	Table    string          `json:"table,omitempty"`
+	Estimate ExplainEstimate `json:"estimate"`
+}
+
+// ExplainEstimate holds the planner's estimates for an operator's output.
+// Unknown estimates, typically for tables without statistics, are omitted.
+type ExplainEstimate struct {
+	Rows        *float64 `json:"rows,omitempty"`
+	Bytes       *float64 `json:"bytes,omitempty"`
+	CPUCost     *float64 `json:"cpu_cost,omitempty"`
+	MemoryCost  *float64 `json:"memory_cost,omitempty"`
+	NetworkCost *float64 `json:"network_cost,omitempty"`
+}
+
+// ExplainFinding is a pattern in the plan worth a second look. Kind is
+// full_table_scan, cross_join, missing_partition_pruning, or
+// large_broadcast_join.
+type ExplainFinding struct {
+	Kind    string `json:"kind"`
+	NodeID  string `json:"node_id"`
+	Table   string `json:"table,omitempty"`
+	Message string `json:"message"`
 }
 
 // BrowseOutput defines the structured output of the trino_browse tool.
@@ -36,6 +130,20 @@ type BrowseOutput struct {
 	Items   []string `json:"items"`
 	Count   int      `json:"count"`
 	Pattern string   `json:"pattern,omitempty"`
+
+	// Type is the object type filter applied when listing tables.
+	Type string `json:"type,omitempty"`
+
+	// Tables details the listed tables, in the same order as Items.
+	Tables []BrowseTable `json:"tables,omitempty"`
+}
+
+// BrowseTable describes a table, view, or materialized view listed by the
+// trino_browse tool.
+type BrowseTable struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Comment string `json:"comment,omitempty"`
 }
 
 // DescribeTableOutput defines the structured output of the trino_describe_table tool.
@@ -43,9 +151,31 @@ type DescribeTableOutput struct {
 	Catalog string           `json:"catalog"`
 	Schema  string           `json:"schema"`
 	Table   string           `json:"table"`
+	Type    string           `json:"type,omitempty"` // TABLE, VIEW, or MATERIALIZED VIEW
+	Comment string           `json:"comment,omitempty"`
 	Columns []DescribeColumn `json:"columns"`
 	Count   int              `json:"column_count"`
 	Sample  []map[string]any `json:"sample,omitempty"`
+
+	// DDL is the SHOW CREATE statement, when requested with include_ddl.
+	DDL string `json:"ddl,omitempty"`
+
+	// Properties are the table properties, when requested with
+	// include_properties.
+	Properties *DescribeTableProperties `json:"properties,omitempty"`
+}
+
+// DescribeTableProperties describes a table's storage layout, taken from
+// the WITH clause of its SHOW CREATE statement.
+type DescribeTableProperties struct {
+	Format           string            `json:"format,omitempty"`
+	Location         string            `json:"location,omitempty"`
+	Partitioning     []string          `json:"partitioning,omitempty"`
+	PartitionColumns []string          `json:"partition_columns,omitempty"`
+	BucketedBy       []string          `json:"bucketed_by,omitempty"`
+	BucketCount      int               `json:"bucket_count,omitempty"`
+	SortedBy         []string          `json:"sorted_by,omitempty"`
+	All              map[string]string `json:"all,omitempty"` // every property, with values as written in the DDL
 }
 
 // DescribeColumn describes a column in the table.
@@ -1,6 +1,7 @@
 package tools
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/txn2/mcp-trino/pkg/client"
@@ -52,6 +53,39 @@ func TestBuildQueryOutput(t *testing.T) {
 	}
 }
 
+func TestBuildQueryOutput_CoordinatorStats(t *testing.T) {
+	result := &client.QueryResult{
+		Stats: client.QueryStats{
+			RowCount:        1,
+			QueryID:         "20250101_000000_00001_abcde",
+			CPUTimeMs:       340,
+			QueuedTimeMs:    7,
+			ProcessedRows:   1500,
+			ProcessedBytes:  2048,
+			PeakMemoryBytes: 4096,
+			Splits:          12,
+		},
+		Warnings: []client.QueryWarning{{Code: 1, Name: "DEPRECATED_FUNCTION", Message: "function foo is deprecated"}},
+	}
+
+	out := buildQueryOutput(result)
+
+	want := QueryStats{
+		RowCount:        1,
+		QueryID:         "20250101_000000_00001_abcde",
+		CPUTimeMs:       340,
+		QueuedTimeMs:    7,
+		ProcessedRows:   1500,
+		ProcessedBytes:  2048,
+		PeakMemoryBytes: 4096,
+		Splits:          12,
+		Warnings:        []QueryWarning{{Code: 1, Name: "DEPRECATED_FUNCTION", Message: "function foo is deprecated"}},
+	}
+	if !reflect.DeepEqual(out.Stats, want) {
+		t.Errorf("unexpected stats:\n got %+v\nwant %+v", out.Stats, want)
+	}
+}
+
 func TestBuildQueryOutput_Empty(t *testing.T) {
 	result := &client.QueryResult{
 		Stats: client.QueryStats{},
@@ -0,0 +1,183 @@
+package tools
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/trinodb/trino-go-client/trino"
+)
+
+// QueryParam is a value bound to a ? placeholder in trino_query or
+// trino_execute. Values are sent as typed literals, never spliced into the
+// SQL text, so quoting is handled by the server.
+type QueryParam struct {
+	// Value is the parameter value: a string, number, boolean, or null.
+	Value any `json:"value" jsonschema_description:"Parameter value: string, number, boolean, or null"`
+
+	// Type optionally sets the Trino type of Value. When empty, strings are
+	// varchar, whole numbers bigint, other numbers decimal, and booleans boolean.
+	Type string `json:"type,omitempty" jsonschema_description:"Optional Trino type: varchar, bigint, decimal, double, boolean, date, timestamp, or timestamp with time zone (dates and timestamps as ISO 8601 strings)"` //nolint:lll // jsonschema_description must be a single tag value
+}
+
+// paramTimestampLayouts are the accepted formats for timestamp parameters.
+var paramTimestampLayouts = []string{
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02",
+}
+
+// paramArgs converts tool parameters to driver arguments for
+// client.QueryOptions.Args.
+func paramArgs(params []QueryParam) ([]any, error) {
+	if len(params) == 0 {
+		return nil, nil
+	}
+	args := make([]any, len(params))
+	for i, p := range params {
+		arg, err := paramArg(p)
+		if err != nil {
+			return nil, fmt.Errorf("params[%d]: %w", i, err)
+		}
+		args[i] = arg
+	}
+	return args, nil
+}
+
+// paramArg converts one parameter according to its type hint.
+func paramArg(p QueryParam) (any, error) {
+	if p.Value == nil {
+		return nil, nil
+	}
+
+	switch typ := strings.ToLower(strings.TrimSpace(p.Type)); typ {
+	case "":
+		return inferParam(p.Value)
+	case "varchar":
+		if s, ok := p.Value.(string); ok {
+			return s, nil
+		}
+		return fmt.Sprint(p.Value), nil
+	case "bigint", "integer":
+		return integerParam(p.Value)
+	case "decimal":
+		return numericParam(p.Value, 'f')
+	case "double":
+		// An exponent makes Trino read the literal as DOUBLE
+		return numericParam(p.Value, 'E')
+	case "boolean":
+		return booleanParam(p.Value)
+	case "date", "timestamp", "timestamp with time zone":
+		return temporalParam(typ, p.Value)
+	default:
+		return nil, fmt.Errorf("unsupported type %q", p.Type)
+	}
+}
+
+// booleanParam converts a JSON boolean or boolean string.
+func booleanParam(v any) (any, error) {
+	switch x := v.(type) {
+	case bool:
+		return x, nil
+	case string:
+		b, err := strconv.ParseBool(x)
+		if err != nil {
+			return nil, fmt.Errorf("invalid boolean %q", x)
+		}
+		return b, nil
+	}
+	return nil, fmt.Errorf("value %v is not a boolean", v)
+}
+
+// temporalParam converts an ISO 8601 string to a date, timestamp, or
+// timestamp with time zone.
+func temporalParam(typ string, v any) (any, error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, fmt.Errorf("%s value must be a string, got %v", typ, v)
+	}
+
+	switch typ {
+	case "date":
+		d, err := time.Parse("2006-01-02", s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date %q (use YYYY-MM-DD)", s)
+		}
+		return trino.Date(d.Year(), d.Month(), d.Day()), nil
+	case "timestamp":
+		for _, layout := range paramTimestampLayouts {
+			if ts, err := time.Parse(layout, s); err == nil {
+				return trino.Timestamp(ts.Year(), ts.Month(), ts.Day(),
+					ts.Hour(), ts.Minute(), ts.Second(), ts.Nanosecond()), nil
+			}
+		}
+		return nil, fmt.Errorf("invalid timestamp %q (use YYYY-MM-DD HH:MM:SS)", s)
+	default:
+		ts, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timestamp with time zone %q (use RFC 3339)", s)
+		}
+		return ts, nil
+	}
+}
+
+// inferParam converts a JSON value without a type hint.
+func inferParam(v any) (any, error) {
+	switch x := v.(type) {
+	case string, bool:
+		return x, nil
+	case float64:
+		if x == math.Trunc(x) && math.Abs(x) < 1<<53 {
+			return int64(x), nil
+		}
+		return numericParam(x, 'f')
+	default:
+		return nil, fmt.Errorf("unsupported value %v (use a string, number, boolean, or null)", v)
+	}
+}
+
+// integerParam converts a JSON number or numeric string to an integer.
+// JSON numbers are only exact up to 2^53, so larger integers must be
+// strings.
+func integerParam(v any) (any, error) {
+	switch x := v.(type) {
+	case float64:
+		if x != math.Trunc(x) {
+			return nil, fmt.Errorf("value %v is not an integer", x)
+		}
+		if math.Abs(x) >= 1<<53 {
+			return nil, fmt.Errorf("integer %v is too large for a JSON number; pass it as a string", x)
+		}
+		return int64(x), nil
+	case string:
+		n, err := strconv.ParseInt(x, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer %q", x)
+		}
+		return n, nil
+	}
+	return nil, fmt.Errorf("value %v is not an integer", v)
+}
+
+// numericParam converts a JSON number or numeric string to a numeric
+// literal. Numeric strings are kept as given, so decimals keep their
+// precision; numbers are formatted with the given strconv format.
+func numericParam(v any, format byte) (any, error) {
+	switch x := v.(type) {
+	case float64:
+		return trino.Numeric(strconv.FormatFloat(x, format, -1, 64)), nil
+	case string:
+		// ParseFloat also accepts forms Trino does not, like hex and NaN
+		f, err := strconv.ParseFloat(x, 64)
+		if err != nil || math.IsNaN(f) || math.IsInf(f, 0) || strings.ContainsAny(x, "xX_") {
+			return nil, fmt.Errorf("invalid number %q", x)
+		}
+		if format == 'E' && !strings.ContainsAny(x, "eE") {
+			return trino.Numeric(strconv.FormatFloat(f, 'E', -1, 64)), nil
+		}
+		return trino.Numeric(x), nil
+	}
+	return nil, fmt.Errorf("value %v is not a number", v)
+}
@@ -0,0 +1,87 @@
+package tools
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/trinodb/trino-go-client/trino"
+)
+
+func TestParamArg(t *testing.T) {
+	tests := []struct {
+		name    string
+		param   QueryParam
+		want    any
+		wantErr string
+	}{
+		{name: "null", param: QueryParam{Value: nil, Type: "date"}, want: nil},
+		{name: "string", param: QueryParam{Value: "abc"}, want: "abc"},
+		{name: "bool", param: QueryParam{Value: true}, want: true},
+		{name: "whole number", param: QueryParam{Value: float64(7)}, want: int64(7)},
+		{name: "fraction", param: QueryParam{Value: 1.25}, want: trino.Numeric("1.25")},
+		{name: "unsupported value", param: QueryParam{Value: []any{1}}, wantErr: "unsupported value"},
+		{name: "varchar from number", param: QueryParam{Value: float64(12), Type: "varchar"}, want: "12"},
+		{name: "bigint from string", param: QueryParam{Value: "9007199254740993", Type: "bigint"}, want: int64(9007199254740993)},
+		{name: "bigint fraction", param: QueryParam{Value: 1.5, Type: "BIGINT"}, wantErr: "not an integer"},
+		{name: "bigint largest exact number", param: QueryParam{Value: float64(1<<53 - 1), Type: "bigint"}, want: int64(1<<53 - 1)},
+		{name: "bigint inexact number", param: QueryParam{Value: float64(1 << 53), Type: "bigint"}, wantErr: "pass it as a string"},
+		{name: "bigint out of range", param: QueryParam{Value: -1e19, Type: "bigint"}, wantErr: "too large"},
+		{name: "bigint infinity", param: QueryParam{Value: math.Inf(1), Type: "bigint"}, wantErr: "too large"},
+		{name: "bigint NaN", param: QueryParam{Value: math.NaN(), Type: "bigint"}, wantErr: "not an integer"},
+		{name: "decimal keeps precision", param: QueryParam{Value: "12345678901234567890.12", Type: "decimal"},
+			want: trino.Numeric("12345678901234567890.12")},
+		{name: "decimal rejects hex", param: QueryParam{Value: "0x1p-2", Type: "decimal"}, wantErr: "invalid number"},
+		{name: "double", param: QueryParam{Value: 2.5, Type: "double"}, want: trino.Numeric("2.5E+00")},
+		{name: "double from string", param: QueryParam{Value: "2.5", Type: "double"}, want: trino.Numeric("2.5E+00")},
+		{name: "boolean from string", param: QueryParam{Value: "false", Type: "boolean"}, want: false},
+		{name: "boolean invalid", param: QueryParam{Value: float64(1), Type: "boolean"}, wantErr: "not a boolean"},
+		{name: "date", param: QueryParam{Value: "2024-02-29", Type: "date"}, want: trino.Date(2024, 2, 29)},
+		{name: "date invalid", param: QueryParam{Value: "02/29/2024", Type: "date"}, wantErr: "invalid date"},
+		{name: "date not string", param: QueryParam{Value: float64(20240229), Type: "date"}, wantErr: "must be a string"},
+		{name: "timestamp", param: QueryParam{Value: "2024-02-29 13:45:00.5", Type: "timestamp"},
+			want: trino.Timestamp(2024, 2, 29, 13, 45, 0, 500000000)},
+		{name: "timestamp ISO", param: QueryParam{Value: "2024-02-29T13:45:00", Type: "timestamp"},
+			want: trino.Timestamp(2024, 2, 29, 13, 45, 0, 0)},
+		{name: "timestamp with time zone", param: QueryParam{Value: "2024-02-29T13:45:00Z", Type: "timestamp with time zone"},
+			want: time.Date(2024, 2, 29, 13, 45, 0, 0, time.UTC)},
+		{name: "unsupported type", param: QueryParam{Value: "x", Type: "uuid"}, wantErr: `unsupported type "uuid"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := paramArg(tt.param)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("paramArg() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("paramArg() error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("paramArg() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParamArgs_Serializable(t *testing.T) {
+	// Every converted value must be accepted by the driver's serializer
+	args, err := paramArgs([]QueryParam{
+		{Value: "it's"}, {Value: float64(1)}, {Value: 0.1}, {Value: 3.0, Type: "double"},
+		{Value: "2024-01-01", Type: "date"}, {Value: "2024-01-01 00:00:00", Type: "timestamp"},
+		{Value: "2024-01-01T00:00:00+02:00", Type: "timestamp with time zone"}, {Value: nil},
+	})
+	if err != nil {
+		t.Fatalf("paramArgs() error: %v", err)
+	}
+	for i, arg := range args {
+		if _, err := trino.Serial(arg); err != nil {
+			t.Errorf("args[%d] (%#v) not serializable: %v", i, arg, err)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/txn2/mcp-trino/pkg/client"
+)
+
+// principalKey is the context key for the calling principal.
+type principalKey struct{}
+
+// WithPrincipal returns a new context carrying the authenticated principal.
+// Embedders that authenticate callers themselves can use this to supply the
+// principal; otherwise the toolkit takes it from the request's bearer token.
+func WithPrincipal(ctx context.Context, principal string) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// GetPrincipal retrieves the authenticated principal from the context.
+// Returns "" if the caller is not authenticated.
+func GetPrincipal(ctx context.Context) string {
+	p, _ := ctx.Value(principalKey{}).(string) //nolint:errcheck // type assertion ok is unused by design
+	return p
+}
+
+// callerContext resolves the calling principal and, when impersonation is
+// enabled, arranges for Trino queries to run as that principal.
+// The extra argument carries the request's token info and may be nil.
+func (t *Toolkit) callerContext(ctx context.Context, extra *mcp.RequestExtra) context.Context {
+	principal := GetPrincipal(ctx)
+	if principal == "" && extra != nil && extra.TokenInfo != nil {
+		principal = extra.TokenInfo.UserID
+	}
+	if principal == "" {
+		return ctx
+	}
+
+	ctx = WithPrincipal(ctx, principal)
+	if t.config.Impersonate {
+		ctx = client.WithUser(ctx, principal)
+	}
+	return ctx
+}
+
+// toolRequestExtra returns the extra data of a tool request, tolerating a nil request.
+func toolRequestExtra(req *mcp.CallToolRequest) *mcp.RequestExtra {
+	if req == nil {
+		return nil
+	}
+	return req.Extra
+}
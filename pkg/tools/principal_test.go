@@ -0,0 +1,123 @@
+package tools
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/auth"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/txn2/mcp-trino/pkg/client"
+)
+
+func TestPrincipalContext(t *testing.T) {
+	if got := GetPrincipal(context.Background()); got != "" {
+		t.Errorf("expected empty principal, got %q", got)
+	}
+	if got := GetPrincipal(WithPrincipal(context.Background(), "alice")); got != "alice" {
+		t.Errorf("expected alice, got %q", got)
+	}
+}
+
+func authenticatedRequest(user string) *mcp.CallToolRequest {
+	return &mcp.CallToolRequest{
+		Extra: &mcp.RequestExtra{
+			TokenInfo: &auth.TokenInfo{UserID: user, Expiration: time.Now().Add(time.Hour)},
+		},
+	}
+}
+
+func TestWrapHandler_Principal(t *testing.T) {
+	tests := []struct {
+		name          string
+		impersonate   bool
+		opts          []ToolkitOption
+		ctx           context.Context
+		req           *mcp.CallToolRequest
+		wantPrincipal string
+		wantUser      string
+	}{
+		{
+			name: "unauthenticated",
+			ctx:  context.Background(),
+		},
+		{
+			name:          "token without impersonation",
+			ctx:           context.Background(),
+			req:           authenticatedRequest("alice"),
+			wantPrincipal: "alice",
+		},
+		{
+			name:          "token with impersonation",
+			impersonate:   true,
+			ctx:           context.Background(),
+			req:           authenticatedRequest("alice"),
+			wantPrincipal: "alice",
+			wantUser:      "alice",
+		},
+		{
+			name:          "context principal takes precedence",
+			impersonate:   true,
+			ctx:           WithPrincipal(context.Background(), "bob"),
+			req:           authenticatedRequest("alice"),
+			wantPrincipal: "bob",
+			wantUser:      "bob",
+		},
+		{
+			name:          "with middleware",
+			impersonate:   true,
+			opts:          []ToolkitOption{WithMiddleware(MiddlewareFunc{})},
+			ctx:           context.Background(),
+			req:           authenticatedRequest("alice"),
+			wantPrincipal: "alice",
+			wantUser:      "alice",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Impersonate = tt.impersonate
+			toolkit := NewToolkit(NewMockTrinoClient(), cfg, tt.opts...)
+
+			var gotPrincipal, gotUser string
+			handler := toolkit.wrapHandler(ToolQuery, func(ctx context.Context, _ *mcp.CallToolRequest, _ any) (*mcp.CallToolResult, any, error) {
+				gotPrincipal = GetPrincipal(ctx)
+				gotUser = client.UserFromContext(ctx)
+				return &mcp.CallToolResult{}, nil, nil
+			}, nil)
+
+			if _, _, err := handler(tt.ctx, tt.req, QueryInput{SQL: "SELECT 1"}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if gotPrincipal != tt.wantPrincipal {
+				t.Errorf("principal = %q, want %q", gotPrincipal, tt.wantPrincipal)
+			}
+			if gotUser != tt.wantUser {
+				t.Errorf("trino user = %q, want %q", gotUser, tt.wantUser)
+			}
+		})
+	}
+}
+
+func TestWrapHandler_ToolContextPrincipal(t *testing.T) {
+	var got string
+	mw := MiddlewareFunc{
+		BeforeFn: func(ctx context.Context, tc *ToolContext) (context.Context, error) {
+			got = tc.Principal
+			return ctx, nil
+		},
+	}
+	toolkit := NewToolkit(NewMockTrinoClient(), DefaultConfig(), WithMiddleware(mw))
+
+	handler := toolkit.wrapHandler(ToolQuery, func(_ context.Context, _ *mcp.CallToolRequest, _ any) (*mcp.CallToolResult, any, error) {
+		return &mcp.CallToolResult{}, nil, nil
+	}, nil)
+	if _, _, err := handler(context.Background(), authenticatedRequest("carol"), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "carol" {
+		t.Errorf("expected ToolContext.Principal carol, got %q", got)
+	}
+}
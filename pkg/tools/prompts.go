@@ -0,0 +1,188 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Built-in prompt names.
+const (
+	PromptExploreDataset       = "trino_explore_dataset"
+	PromptInvestigateSlowQuery = "trino_investigate_slow_query"
+	PromptReportQuery          = "trino_report_query"
+)
+
+// Prompt is an MCP prompt offered by the toolkit. Built-in prompts and those
+// added with WithPrompts are registered by RegisterPrompts.
+type Prompt struct {
+	// Name uniquely identifies the prompt. A custom prompt with the same name
+	// as a built-in prompt replaces it.
+	Name string
+
+	// Title is the human-readable name shown by clients.
+	Title string
+
+	// Description explains what the prompt does.
+	Description string
+
+	// Arguments lists the prompt arguments. Required arguments are checked
+	// before Template is rendered.
+	Arguments []*mcp.PromptArgument
+
+	// Template is a text/template rendered into a single user message. The
+	// template data is the map of argument values; missing arguments are "".
+	// Ignored when Handler is set.
+	Template string
+
+	// Handler builds the prompt messages directly, for prompts that need
+	// more than one templated message.
+	Handler mcp.PromptHandler
+}
+
+// RegisterPrompts adds the built-in analyst prompts and any prompts supplied
+// with WithPrompts to the given MCP server.
+func (t *Toolkit) RegisterPrompts(server *mcp.Server) {
+	for _, p := range append(builtinPrompts(), t.prompts...) {
+		server.AddPrompt(&mcp.Prompt{
+			Name:        p.Name,
+			Title:       p.Title,
+			Description: p.Description,
+			Arguments:   p.Arguments,
+		}, p.handler())
+	}
+}
+
+// handler returns the prompt's Handler, or one rendering its Template.
+func (p Prompt) handler() mcp.PromptHandler {
+	if p.Handler != nil {
+		return p.Handler
+	}
+
+	tmpl, parseErr := template.New(p.Name).Option("missingkey=zero").Parse(p.Template)
+	return func(_ context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		if parseErr != nil {
+			return nil, fmt.Errorf("prompt %s: invalid template: %w", p.Name, parseErr)
+		}
+
+		args := req.Params.Arguments
+		if args == nil {
+			args = map[string]string{}
+		}
+		for _, arg := range p.Arguments {
+			if arg.Required && strings.TrimSpace(args[arg.Name]) == "" {
+				return nil, fmt.Errorf("prompt %s: missing required argument %q", p.Name, arg.Name)
+			}
+		}
+
+		var sb strings.Builder
+		if err := tmpl.Execute(&sb, args); err != nil {
+			return nil, fmt.Errorf("prompt %s: %w", p.Name, err)
+		}
+
+		return &mcp.GetPromptResult{
+			Description: p.Description,
+			Messages: []*mcp.PromptMessage{
+				{Role: "user", Content: &mcp.TextContent{Text: strings.TrimSpace(sb.String())}},
+			},
+		}, nil
+	}
+}
+
+// connectionArgument is the optional connection argument shared by the built-in prompts.
+var connectionArgument = &mcp.PromptArgument{
+	Name:        "connection",
+	Description: "Named connection to use (see trino_list_connections). Empty uses the default.",
+}
+
+// builtinPrompts returns the prompts registered for every toolkit.
+func builtinPrompts() []Prompt {
+	return []Prompt{
+		{
+			Name:        PromptExploreDataset,
+			Title:       "Explore a Dataset",
+			Description: "Survey the catalogs, schemas, and tables available in Trino and summarize what the data covers.",
+			Arguments: []*mcp.PromptArgument{
+				connectionArgument,
+				{Name: "catalog", Description: "Catalog to focus on. Empty starts from the list of catalogs."},
+				{Name: "schema", Description: "Schema to focus on within the catalog."},
+			},
+			Template: exploreDatasetTemplate,
+		},
+		{
+			Name:        PromptInvestigateSlowQuery,
+			Title:       "Investigate a Slow Query",
+			Description: "Analyze the execution plan of a slow query and suggest how to make it faster.",
+			Arguments: []*mcp.PromptArgument{
+				{Name: "sql", Description: "The slow SQL query.", Required: true},
+				connectionArgument,
+			},
+			Template: investigateSlowQueryTemplate,
+		},
+		{
+			Name:        PromptReportQuery,
+			Title:       "Write a Report Query",
+			Description: "Write, validate, and run a reporting query against a table.",
+			Arguments: []*mcp.PromptArgument{
+				{Name: "catalog", Description: "Catalog containing the table.", Required: true},
+				{Name: "schema", Description: "Schema containing the table.", Required: true},
+				{Name: "table", Description: "Table to report on.", Required: true},
+				{Name: "goal", Description: "What the report should show, e.g. \"monthly revenue by region\"."},
+				connectionArgument,
+			},
+			Template: reportQueryTemplate,
+		},
+	}
+}
+
+const exploreDatasetTemplate = `
+Help me explore the data available in Trino
+{{- if .connection}} on the "{{.connection}}" connection{{end}}
+{{- if and .catalog .schema}}, focusing on the schema {{.catalog}}.{{.schema}}
+{{- else if .catalog}}, focusing on the catalog {{.catalog}}{{end}}.
+{{if .connection}}
+Pass connection "{{.connection}}" to every tool call.
+{{end}}
+1. Use trino_browse to list
+{{- if and .catalog .schema}} the tables in {{.catalog}}.{{.schema}}
+{{- else if .catalog}} the schemas in {{.catalog}}, then the tables in the most relevant schemas
+{{- else}} the catalogs, then the schemas and tables in the most relevant ones{{end}}.
+2. Use trino_describe_table with include_sample for the most important tables.
+3. Summarize the dataset: what each table contains, its grain, the keys that join tables together, and any data quality caveats you notice.
+4. Suggest three questions this data could answer, each with the SQL that would answer it.
+
+Do not run queries that scan whole large tables; use LIMIT and filters while exploring.
+`
+
+const investigateSlowQueryTemplate = `
+This Trino query is slow. Find out why and suggest how to make it faster.
+{{if .connection}}
+Pass connection "{{.connection}}" to every tool call.
+{{end}}
+` + "```sql\n{{.sql}}\n```" + `
+
+1. Run trino_explain with type "distributed" to see the stages, exchanges, and join distribution.
+2. Run trino_explain with type "io" to see which tables and partitions are read.
+3. Use trino_describe_table on the tables involved to check column types and partition keys.
+4. Identify the likely bottlenecks, such as full table scans, missing partition filters, large broadcast or cross joins, skewed exchanges, or expensive functions applied to filter columns.
+5. Propose a rewritten query, explain each change, and confirm it with trino_explain.
+
+Do not execute the original query.
+`
+
+const reportQueryTemplate = `
+Write a reporting query for the table {{.catalog}}.{{.schema}}.{{.table}}
+{{- if .connection}} on the "{{.connection}}" connection{{end}}.
+{{if .goal}}
+The report should show: {{.goal}}
+{{end}}{{if .connection}}
+Pass connection "{{.connection}}" to every tool call.
+{{end}}
+1. Use trino_describe_table with include_sample to learn the columns, their meaning, and typical values. Respect any columns marked sensitive.
+2. Write a SELECT with explicit columns, clear aliases, the needed filters and aggregations, an ORDER BY, and a LIMIT.
+3. Validate it with trino_explain type "validate", then check the cost with type "io".
+4. Run it with trino_query and present the SQL followed by a short summary of the results.
+`
@@ -0,0 +1,143 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func getPromptText(t *testing.T, session *mcp.ClientSession, name string, args map[string]string) string {
+	t.Helper()
+	result, err := session.GetPrompt(context.Background(), &mcp.GetPromptParams{Name: name, Arguments: args})
+	if err != nil {
+		t.Fatalf("GetPrompt(%s) error: %v", name, err)
+	}
+	if len(result.Messages) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(result.Messages))
+	}
+	text, ok := result.Messages[0].Content.(*mcp.TextContent)
+	if !ok {
+		t.Fatalf("expected text content, got %T", result.Messages[0].Content)
+	}
+	return text.Text
+}
+
+func TestRegisterPrompts_Builtin(t *testing.T) {
+	toolkit := NewToolkit(NewMockTrinoClient(), DefaultConfig())
+	session := connectTestSession(t, nil, toolkit.RegisterPrompts)
+
+	list, err := session.ListPrompts(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("ListPrompts error: %v", err)
+	}
+	names := make(map[string]bool)
+	for _, p := range list.Prompts {
+		names[p.Name] = true
+	}
+	for _, want := range []string{PromptExploreDataset, PromptInvestigateSlowQuery, PromptReportQuery} {
+		if !names[want] {
+			t.Errorf("expected prompt %s to be registered", want)
+		}
+	}
+
+	tests := []struct {
+		name        string
+		prompt      string
+		args        map[string]string
+		contains    []string
+		notContains []string
+	}{
+		{
+			name:        "explore without arguments",
+			prompt:      PromptExploreDataset,
+			contains:    []string{"list the catalogs", "trino_describe_table"},
+			notContains: []string{"connection \"", "<no value>"},
+		},
+		{
+			name:     "explore schema on connection",
+			prompt:   PromptExploreDataset,
+			args:     map[string]string{"connection": "prod", "catalog": "hive", "schema": "sales"},
+			contains: []string{"on the \"prod\" connection", "schema hive.sales", "tables in hive.sales", "Pass connection \"prod\""},
+		},
+		{
+			name:     "slow query",
+			prompt:   PromptInvestigateSlowQuery,
+			args:     map[string]string{"sql": "SELECT * FROM orders"},
+			contains: []string{"```sql\nSELECT * FROM orders\n```", "type \"distributed\"", "type \"io\""},
+		},
+		{
+			name:     "report query with goal",
+			prompt:   PromptReportQuery,
+			args:     map[string]string{"catalog": "hive", "schema": "sales", "table": "orders", "goal": "revenue by region"},
+			contains: []string{"hive.sales.orders", "The report should show: revenue by region", "trino_query"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text := getPromptText(t, session, tt.prompt, tt.args)
+			for _, s := range tt.contains {
+				if !strings.Contains(text, s) {
+					t.Errorf("expected prompt to contain %q, got:\n%s", s, text)
+				}
+			}
+			for _, s := range tt.notContains {
+				if strings.Contains(text, s) {
+					t.Errorf("expected prompt not to contain %q, got:\n%s", s, text)
+				}
+			}
+		})
+	}
+}
+
+func TestRegisterPrompts_MissingRequiredArgument(t *testing.T) {
+	toolkit := NewToolkit(NewMockTrinoClient(), DefaultConfig())
+	session := connectTestSession(t, nil, toolkit.RegisterPrompts)
+
+	_, err := session.GetPrompt(context.Background(), &mcp.GetPromptParams{
+		Name:      PromptReportQuery,
+		Arguments: map[string]string{"catalog": "hive", "schema": "sales"},
+	})
+	if err == nil || !strings.Contains(err.Error(), `"table"`) {
+		t.Errorf("expected missing table argument error, got %v", err)
+	}
+}
+
+func TestWithPrompts(t *testing.T) {
+	toolkit := NewToolkit(NewMockTrinoClient(), DefaultConfig(),
+		WithPrompts(
+			Prompt{
+				Name:      "weekly_sales",
+				Arguments: []*mcp.PromptArgument{{Name: "region", Required: true}},
+				Template:  "Summarize sales in {{.region}}.",
+			},
+			Prompt{
+				Name: PromptExploreDataset,
+				Handler: func(_ context.Context, _ *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+					return &mcp.GetPromptResult{
+						Messages: []*mcp.PromptMessage{{Role: "user", Content: &mcp.TextContent{Text: "custom explore"}}},
+					}, nil
+				},
+			},
+			Prompt{
+				Name:     "broken",
+				Template: "{{.unclosed",
+			},
+		),
+	)
+	session := connectTestSession(t, nil, toolkit.RegisterPrompts)
+
+	if got := getPromptText(t, session, "weekly_sales", map[string]string{"region": "EMEA"}); got != "Summarize sales in EMEA." {
+		t.Errorf("unexpected custom prompt text: %q", got)
+	}
+	if got := getPromptText(t, session, PromptExploreDataset, nil); got != "custom explore" {
+		t.Errorf("expected custom prompt to replace built-in, got %q", got)
+	}
+
+	_, err := session.GetPrompt(context.Background(), &mcp.GetPromptParams{Name: "broken"})
+	if err == nil || !strings.Contains(err.Error(), "invalid template") {
+		t.Errorf("expected invalid template error, got %v", err)
+	}
+}
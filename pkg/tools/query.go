@@ -3,32 +3,21 @@ package tools
 import (
 	"context"
 	"fmt"
-	"regexp"
 	"strings"
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 
 	"github.com/txn2/mcp-trino/pkg/client"
+	"github.com/txn2/mcp-trino/pkg/sqlparse"
 )
 
-// writeKeywords are SQL statement prefixes that modify data or schema.
-var writeKeywords = []string{
-	"INSERT", "UPDATE", "DELETE", "DROP", "CREATE", "ALTER",
-	"TRUNCATE", "GRANT", "REVOKE", "MERGE", "CALL", "EXECUTE",
-}
-
-// writePattern matches SQL statements that begin with a write keyword,
-// allowing for leading whitespace and SQL comments.
-var writePattern = regexp.MustCompile(
-	`(?i)^\s*(?:--[^\n]*\n\s*|/\*[\s\S]*?\*/\s*)*\s*(` +
-		strings.Join(writeKeywords, "|") +
-		`)(?:\s|$|;|\()`,
-)
-
-// IsWriteSQL returns true if the SQL statement is a write operation.
+// IsWriteSQL returns true if the SQL is not read-only: if any of its
+// statements can change data, schema, or privileges, or changes session
+// state, as SET SESSION and USE do. EXPLAIN ANALYZE runs the statement it
+// explains, so a write under it is a write too.
 func IsWriteSQL(sql string) bool {
-	return writePattern.MatchString(sql)
+	return !sqlparse.IsReadOnly(sql)
 }
 
 // QueryInput defines the input for the trino_query tool.
@@ -39,6 +28,10 @@ type QueryInput struct {
 	// Limit is the maximum number of rows to return. Default: 1000, Max: 10000.
 	Limit int `json:"limit,omitempty" jsonschema_description:"Maximum rows to return (default: 1000, max: 10000)"`
 
+	// DisableLimitPushdown runs the SQL unchanged instead of adding the limit
+	// to it; the limit is then only applied while reading rows.
+	DisableLimitPushdown bool `json:"disable_limit_pushdown,omitempty" jsonschema_description:"When true, do not add the row limit to the SQL; Trino runs the full query and rows beyond the limit are discarded"` //nolint:lll // jsonschema_description must be a single tag value
+
 	// TimeoutSeconds is the query timeout in seconds. Default: 120, Max: 300.
 	TimeoutSeconds int `json:"timeout_seconds,omitempty" jsonschema_description:"Query timeout in seconds (default: 120, max: 300)"`
 
@@ -53,6 +46,21 @@ type QueryInput struct {
 	// Connection is the named connection to use. Empty uses the default connection.
 	// Use trino_list_connections to see available connections.
 	Connection string `json:"connection,omitempty" jsonschema_description:"Named connection to use (see trino_list_connections)"`
+
+	// Catalog and Schema set the default catalog and schema for unqualified table names.
+	Catalog string `json:"catalog,omitempty" jsonschema_description:"Default catalog for unqualified table names"`
+	Schema  string `json:"schema,omitempty" jsonschema_description:"Default schema for unqualified table names"`
+
+	// SessionProperties sets Trino session properties for this query.
+	// Only properties allowed by Config.AllowedSessionProperties are accepted.
+	SessionProperties map[string]string `json:"session_properties,omitempty" jsonschema_description:"Trino session properties for this query (only server-allowed properties are accepted)"` //nolint:lll // jsonschema_description must be a single tag value
+
+	// Params are bound, in order, to ? placeholders in SQL.
+	Params []QueryParam `json:"params,omitempty" jsonschema_description:"Values for ? placeholders in sql, in order. Use instead of inlining literals"`
+
+	// AcknowledgeSensitive confirms the caller means to read columns marked
+	// sensitive, when the server requires acknowledgement for them.
+	AcknowledgeSensitive bool `json:"acknowledge_sensitive,omitempty" jsonschema_description:"Set to true to confirm reading columns marked sensitive, when the server asks for acknowledgement"` //nolint:lll // jsonschema_description must be a single tag value
 }
 
 // registerQueryTool adds the trino_query tool to the server.
@@ -102,10 +110,13 @@ func (t *Toolkit) handleQuery(ctx context.Context, _ *mcp.CallToolRequest, input
 	// For write operations, use trino_execute.
 	if IsWriteSQL(input.SQL) {
 		return ErrorResult("trino_query is read-only — write operations (INSERT, UPDATE, DELETE, " +
-			"CREATE, DROP, etc.) are not allowed. Use trino_execute for write operations."), nil, nil
+			"CREATE, DROP, etc.) and session changes (SET SESSION, USE) are not allowed. " +
+			"Use trino_execute for write operations."), nil, nil
 	}
 
 	// Apply query interceptors
+	ctx = WithDefaultSchema(WithConnection(ctx, input.Connection), input.Catalog, input.Schema)
+	ctx = WithSensitiveAcknowledged(ctx, input.AcknowledgeSensitive)
 	sql, err := t.InterceptSQL(ctx, input.SQL, ToolQuery)
 	if err != nil {
 		return ErrorResult(fmt.Sprintf("Query rejected: %v", err)), nil, nil
@@ -121,13 +132,7 @@ func (t *Toolkit) handleQuery(ctx context.Context, _ *mcp.CallToolRequest, input
 	}
 
 	// Apply timeout
-	timeout := time.Duration(input.TimeoutSeconds) * time.Second
-	if timeout <= 0 {
-		timeout = t.config.DefaultTimeout
-	}
-	if timeout > t.config.MaxTimeout {
-		timeout = t.config.MaxTimeout
-	}
+	timeout := t.queryTimeout(input.TimeoutSeconds)
 
 	// Get client for the specified connection
 	trinoClient, err := t.getClient(input.Connection)
@@ -141,8 +146,15 @@ func (t *Toolkit) handleQuery(ctx context.Context, _ *mcp.CallToolRequest, input
 
 	// Execute query
 	opts := client.QueryOptions{
-		Limit:   limit,
-		Timeout: timeout,
+		Limit:                limit,
+		Timeout:              timeout,
+		DisableLimitPushdown: input.DisableLimitPushdown,
+	}
+	if err := t.applySessionInput(&opts, input.Catalog, input.Schema, input.SessionProperties); err != nil {
+		return ErrorResult(err.Error()), nil, nil
+	}
+	if opts.Args, err = paramArgs(input.Params); err != nil {
+		return ErrorResult(fmt.Sprintf("Invalid params: %v", err)), nil, nil
 	}
 
 	result, err := trinoClient.Query(ctx, sql, opts)
@@ -157,6 +169,11 @@ func (t *Toolkit) handleQuery(ctx context.Context, _ *mcp.CallToolRequest, input
 	// Build structured output
 	queryOutput := buildQueryOutput(result)
 
+	// Transform it, e.g. redact values, before any formatting
+	if err := t.transformQueryOutput(ctx, ToolQuery, sql, &queryOutput); err != nil {
+		return ErrorResult(fmt.Sprintf("Result transform failed: %v", err)), nil, nil
+	}
+
 	// Attempt JSON unwrap if requested — mutates queryOutput in place,
 	// changing columns[0].type to "JSON" and replacing the row value.
 	if input.UnwrapJSON {
@@ -179,6 +196,46 @@ func (t *Toolkit) handleQuery(ctx context.Context, _ *mcp.CallToolRequest, input
 	}, &queryOutput, nil
 }
 
+// queryTimeout returns the timeout for a tool call's timeout_seconds:
+// the configured default if unset, capped at the configured maximum.
+func (t *Toolkit) queryTimeout(seconds int) time.Duration {
+	timeout := time.Duration(seconds) * time.Second
+	if timeout <= 0 {
+		timeout = t.config.DefaultTimeout
+	}
+	return min(timeout, t.config.MaxTimeout)
+}
+
+// applySessionInput sets the catalog, schema, and session properties from a
+// tool call on opts. Session properties must be listed in
+// Config.AllowedSessionProperties; names are matched case-insensitively.
+func (t *Toolkit) applySessionInput(opts *client.QueryOptions, catalog, schema string, props map[string]string) error {
+	opts.Catalog = catalog
+	opts.Schema = schema
+	if len(props) == 0 {
+		return nil
+	}
+
+	if len(t.config.AllowedSessionProperties) == 0 {
+		return fmt.Errorf("session_properties is not enabled on this server")
+	}
+	for name := range props {
+		allowed := false
+		for _, a := range t.config.AllowedSessionProperties {
+			if strings.EqualFold(name, a) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return fmt.Errorf("session property %q is not allowed (allowed: %s)",
+				name, strings.Join(t.config.AllowedSessionProperties, ", "))
+		}
+	}
+	opts.SessionProperties = props
+	return nil
+}
+
 // notifyProgress sends a progress notification if a notifier is available.
 // Errors are intentionally ignored — progress is best-effort.
 //
@@ -195,15 +252,28 @@ func buildQueryOutput(r *client.QueryResult) QueryOutput {
 	for i, c := range r.Columns {
 		cols[i] = QueryColumn{Name: c.Name, Type: c.Type}
 	}
+	var warnings []QueryWarning
+	for _, w := range r.Warnings {
+		warnings = append(warnings, QueryWarning{Code: w.Code, Name: w.Name, Message: w.Message})
+	}
 	return QueryOutput{
 		Columns:  cols,
 		Rows:     r.Rows,
 		RowCount: r.Stats.RowCount,
 		Stats: QueryStats{
-			RowCount:     r.Stats.RowCount,
-			Truncated:    r.Stats.Truncated,
-			LimitApplied: r.Stats.LimitApplied,
-			DurationMs:   r.Stats.DurationMs,
+			RowCount:        r.Stats.RowCount,
+			Truncated:       r.Stats.Truncated,
+			LimitApplied:    r.Stats.LimitApplied,
+			DurationMs:      r.Stats.DurationMs,
+			QueryID:         r.Stats.QueryID,
+			Attempts:        r.Stats.Attempts,
+			CPUTimeMs:       r.Stats.CPUTimeMs,
+			QueuedTimeMs:    r.Stats.QueuedTimeMs,
+			ProcessedRows:   r.Stats.ProcessedRows,
+			ProcessedBytes:  r.Stats.ProcessedBytes,
+			PeakMemoryBytes: r.Stats.PeakMemoryBytes,
+			Splits:          r.Stats.Splits,
+			Warnings:        warnings,
 		},
 	}
 }
@@ -0,0 +1,309 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/txn2/mcp-trino/pkg/semantic"
+)
+
+// Resource URIs. Catalogs, schemas, and tables are addressed by path under
+// their connection, e.g. trino://warehouse/hive/sales/orders.
+const (
+	// ResourceConnectionsURI lists the configured connections.
+	ResourceConnectionsURI = "trino://connections"
+
+	// ResourceCatalogsTemplate lists the catalogs of a connection.
+	ResourceCatalogsTemplate = "trino://{connection}"
+
+	// ResourceSchemasTemplate lists the schemas of a catalog.
+	ResourceSchemasTemplate = "trino://{connection}/{catalog}"
+
+	// ResourceTablesTemplate lists the tables of a schema.
+	ResourceTablesTemplate = "trino://{connection}/{catalog}/{schema}"
+
+	// ResourceTableTemplate describes a table together with its semantic context.
+	ResourceTableTemplate = "trino://{connection}/{catalog}/{schema}/{table}"
+)
+
+const (
+	resourceURIPrefix = "trino://"
+	resourceMIMEType  = "application/json"
+)
+
+// ResourceListing is the content of a catalog, schema, or table list resource.
+type ResourceListing struct {
+	Connection string `json:"connection"`
+	BrowseOutput
+
+	// URIs holds the resource URI of each item, in the same order as Items.
+	URIs []string `json:"uris"`
+}
+
+// TableResource is the content of a table resource: the trino_describe_table
+// output plus any semantic metadata known for the table and its columns.
+type TableResource struct {
+	Connection string `json:"connection"`
+	DescribeTableOutput
+
+	Context        *semantic.TableContext             `json:"context,omitempty"`
+	ColumnContexts map[string]*semantic.ColumnContext `json:"column_contexts,omitempty"`
+}
+
+// RegisterResources adds the Trino catalog resources and resource templates
+// to the server, letting MCP clients attach schema information as context
+// without calling tools. Resources share the toolkit's connections, semantic
+// provider, and caller impersonation; tool middleware is not applied.
+func (t *Toolkit) RegisterResources(server *mcp.Server) {
+	server.AddResource(&mcp.Resource{
+		URI:         ResourceConnectionsURI,
+		Name:        "connections",
+		Title:       "Trino Connections",
+		Description: "Configured Trino connections. Browse each one at trino://{connection}.",
+		MIMEType:    resourceMIMEType,
+	}, t.readConnectionsResource)
+
+	templates := []*mcp.ResourceTemplate{
+		{
+			URITemplate: ResourceCatalogsTemplate,
+			Name:        "catalogs",
+			Title:       "Trino Catalogs",
+			Description: "Catalogs available on a Trino connection.",
+		},
+		{
+			URITemplate: ResourceSchemasTemplate,
+			Name:        "schemas",
+			Title:       "Trino Schemas",
+			Description: "Schemas in a Trino catalog.",
+		},
+		{
+			URITemplate: ResourceTablesTemplate,
+			Name:        "tables",
+			Title:       "Trino Tables",
+			Description: "Tables in a Trino schema.",
+		},
+		{
+			URITemplate: ResourceTableTemplate,
+			Name:        "table",
+			Title:       "Trino Table",
+			Description: "Columns of a Trino table with semantic metadata (descriptions, owners, tags, sensitivity).",
+		},
+	}
+	for _, tmpl := range templates {
+		tmpl.MIMEType = resourceMIMEType
+		server.AddResourceTemplate(tmpl, t.readCatalogResource)
+	}
+}
+
+func (t *Toolkit) readConnectionsResource(_ context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	return jsonResource(req.Params.URI, t.listConnections())
+}
+
+// readCatalogResource serves every trino://{connection}/... template,
+// dispatching on the number of path segments in the URI.
+func (t *Toolkit) readCatalogResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	uri := req.Params.URI
+	parts, err := parseResourceURI(uri)
+	if err != nil {
+		return nil, mcp.ResourceNotFoundError(uri)
+	}
+
+	connection := parts[0]
+	ctx = WithConnection(t.callerContext(ctx, req.Extra), connection)
+	trinoClient, err := t.getClient(connection)
+	if err != nil {
+		return nil, mcp.ResourceNotFoundError(uri)
+	}
+
+	var out any
+	switch len(parts) {
+	case 1:
+		out, err = t.catalogListing(ctx, trinoClient, connection)
+	case 2:
+		out, err = t.schemaListing(ctx, trinoClient, connection, parts[1])
+	case 3:
+		out, err = t.tableListing(ctx, trinoClient, connection, parts[1], parts[2])
+	default:
+		out, err = t.tableResource(ctx, trinoClient, connection, parts[1], parts[2], parts[3])
+	}
+	if err != nil {
+		return nil, err
+	}
+	return jsonResource(uri, out)
+}
+
+func (t *Toolkit) catalogListing(ctx context.Context, trinoClient TrinoClient, connection string) (*ResourceListing, error) {
+	catalogs, err := trinoClient.ListCatalogs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list catalogs: %w", err)
+	}
+	catalogs = t.filterNames(ctx, "", "", catalogs)
+	return newResourceListing(connection, BrowseOutput{
+		Level: "catalogs",
+		Items: catalogs,
+		Count: len(catalogs),
+	}), nil
+}
+
+func (t *Toolkit) schemaListing(
+	ctx context.Context, trinoClient TrinoClient, connection, catalog string,
+) (*ResourceListing, error) {
+	if err := t.checkObject(ctx, catalog, "", ""); err != nil {
+		return nil, err
+	}
+	schemas, err := trinoClient.ListSchemas(ctx, catalog)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list schemas: %w", err)
+	}
+	schemas = t.filterNames(ctx, catalog, "", schemas)
+	return newResourceListing(connection, BrowseOutput{
+		Level:   "schemas",
+		Catalog: catalog,
+		Items:   schemas,
+		Count:   len(schemas),
+	}), nil
+}
+
+func (t *Toolkit) tableListing(
+	ctx context.Context, trinoClient TrinoClient, connection, catalog, schema string,
+) (*ResourceListing, error) {
+	if err := t.checkObject(ctx, catalog, schema, ""); err != nil {
+		return nil, err
+	}
+	tables, err := trinoClient.ListTables(ctx, catalog, schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tables: %w", err)
+	}
+	names := make([]string, len(tables))
+	for i, tbl := range tables {
+		names[i] = tbl.Name
+	}
+	names = t.filterNames(ctx, catalog, schema, names)
+	return newResourceListing(connection, BrowseOutput{
+		Level:   "tables",
+		Catalog: catalog,
+		Schema:  schema,
+		Items:   names,
+		Count:   len(names),
+	}), nil
+}
+
+func (t *Toolkit) tableResource(
+	ctx context.Context, trinoClient TrinoClient, connection, catalog, schema, table string,
+) (*TableResource, error) {
+	if err := t.checkObject(ctx, catalog, schema, table); err != nil {
+		return nil, err
+	}
+	info, err := trinoClient.DescribeTable(ctx, catalog, schema, table)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe table: %w", err)
+	}
+
+	input := DescribeTableInput{Catalog: catalog, Schema: schema, Table: table}
+	out := &TableResource{
+		Connection:          connection,
+		DescribeTableOutput: buildDescribeOutput(input, info),
+	}
+
+	if t.semanticProvider != nil {
+		tableID := semantic.TableIdentifier{
+			Connection: t.semanticConnection(connection),
+			Catalog:    catalog,
+			Schema:     schema,
+			Table:      table,
+		}
+		//nolint:errcheck // semantic enrichment is optional
+		out.Context, _ = t.semanticProvider.GetTableContext(ctx, tableID)
+		//nolint:errcheck // semantic enrichment is optional
+		out.ColumnContexts, _ = t.semanticProvider.GetColumnsContext(ctx, tableID)
+	}
+
+	return out, nil
+}
+
+// semanticConnection returns the connection name used for semantic lookups.
+// The default connection maps to "", matching trino_describe_table calls that
+// omit the connection.
+func (t *Toolkit) semanticConnection(connection string) string {
+	for _, info := range t.ConnectionInfos() {
+		if info.IsDefault && info.Name == connection {
+			return ""
+		}
+	}
+	return connection
+}
+
+// newResourceListing wraps a browse result with the resource URI of each item.
+func newResourceListing(connection string, browse BrowseOutput) *ResourceListing {
+	prefix := []string{connection}
+	if browse.Catalog != "" {
+		prefix = append(prefix, browse.Catalog)
+	}
+	if browse.Schema != "" {
+		prefix = append(prefix, browse.Schema)
+	}
+
+	uris := make([]string, len(browse.Items))
+	for i, item := range browse.Items {
+		uris[i] = ResourceURI(append(prefix, item)...)
+	}
+
+	return &ResourceListing{
+		Connection:   connection,
+		BrowseOutput: browse,
+		URIs:         uris,
+	}
+}
+
+// ResourceURI builds a resource URI from a connection followed by an optional
+// catalog, schema, and table. Each segment is path-escaped.
+func ResourceURI(segments ...string) string {
+	escaped := make([]string, len(segments))
+	for i, s := range segments {
+		escaped[i] = url.PathEscape(s)
+	}
+	return resourceURIPrefix + strings.Join(escaped, "/")
+}
+
+// parseResourceURI splits a trino:// URI into its unescaped segments:
+// connection, then optionally catalog, schema, and table.
+func parseResourceURI(uri string) ([]string, error) {
+	rest, ok := strings.CutPrefix(uri, resourceURIPrefix)
+	if !ok {
+		return nil, fmt.Errorf("not a trino resource URI: %s", uri)
+	}
+
+	parts := strings.Split(rest, "/")
+	if len(parts) > 4 {
+		return nil, fmt.Errorf("too many path segments in %s", uri)
+	}
+	for i, p := range parts {
+		seg, err := url.PathUnescape(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid segment %q in %s: %w", p, uri, err)
+		}
+		if seg == "" {
+			return nil, fmt.Errorf("empty segment in %s", uri)
+		}
+		parts[i] = seg
+	}
+	return parts, nil
+}
+
+// jsonResource returns v as the JSON content of the resource at uri.
+func jsonResource(uri string, v any) (*mcp.ReadResourceResult, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal resource: %w", err)
+	}
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{
+			{URI: uri, MIMEType: resourceMIMEType, Text: string(data)},
+		},
+	}, nil
+}
@@ -0,0 +1,299 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/txn2/mcp-trino/pkg/client"
+	"github.com/txn2/mcp-trino/pkg/semantic"
+)
+
+// connectTestSession sets up a server with opts and register and returns a
+// connected client session.
+func connectTestSession(t *testing.T, opts *mcp.ServerOptions, register func(server *mcp.Server)) *mcp.ClientSession {
+	t.Helper()
+	ctx := context.Background()
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0"}, opts)
+	register(server)
+
+	t1, t2 := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, t1, nil)
+	if err != nil {
+		t.Fatalf("server connect: %v", err)
+	}
+	t.Cleanup(func() { _ = serverSession.Close() })
+
+	mcpClient := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0"}, nil)
+	clientSession, err := mcpClient.Connect(ctx, t2, nil)
+	if err != nil {
+		t.Fatalf("client connect: %v", err)
+	}
+	t.Cleanup(func() { _ = clientSession.Close() })
+	return clientSession
+}
+
+// connectResources registers toolkit resources and returns a connected client session.
+func connectResources(t *testing.T, toolkit *Toolkit) *mcp.ClientSession {
+	t.Helper()
+	return connectTestSession(t, nil, toolkit.RegisterResources)
+}
+
+func readJSONResource(t *testing.T, session *mcp.ClientSession, uri string, v any) {
+	t.Helper()
+	result, err := session.ReadResource(context.Background(), &mcp.ReadResourceParams{URI: uri})
+	if err != nil {
+		t.Fatalf("ReadResource(%s) error: %v", uri, err)
+	}
+	if len(result.Contents) != 1 {
+		t.Fatalf("expected 1 content, got %d", len(result.Contents))
+	}
+	content := result.Contents[0]
+	if content.URI != uri {
+		t.Errorf("expected URI %s, got %s", uri, content.URI)
+	}
+	if content.MIMEType != "application/json" {
+		t.Errorf("expected application/json, got %s", content.MIMEType)
+	}
+	if err := json.Unmarshal([]byte(content.Text), v); err != nil {
+		t.Fatalf("unmarshal %s: %v", uri, err)
+	}
+}
+
+func TestRegisterResources_List(t *testing.T) {
+	session := connectResources(t, NewToolkit(NewMockTrinoClient(), DefaultConfig()))
+	ctx := context.Background()
+
+	resources, err := session.ListResources(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListResources error: %v", err)
+	}
+	if len(resources.Resources) != 1 || resources.Resources[0].URI != ResourceConnectionsURI {
+		t.Errorf("expected only %s, got %+v", ResourceConnectionsURI, resources.Resources)
+	}
+
+	templates, err := session.ListResourceTemplates(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListResourceTemplates error: %v", err)
+	}
+	want := map[string]bool{
+		ResourceCatalogsTemplate: true,
+		ResourceSchemasTemplate:  true,
+		ResourceTablesTemplate:   true,
+		ResourceTableTemplate:    true,
+	}
+	if len(templates.ResourceTemplates) != len(want) {
+		t.Fatalf("expected %d templates, got %d", len(want), len(templates.ResourceTemplates))
+	}
+	for _, tmpl := range templates.ResourceTemplates {
+		if !want[tmpl.URITemplate] {
+			t.Errorf("unexpected template %s", tmpl.URITemplate)
+		}
+	}
+}
+
+func TestReadResource_Connections(t *testing.T) {
+	session := connectResources(t, NewToolkit(NewMockTrinoClient(), DefaultConfig()))
+
+	var out ListConnectionsOutput
+	readJSONResource(t, session, ResourceConnectionsURI, &out)
+	if out.Count != 1 || out.Connections[0].Name != "default" {
+		t.Errorf("unexpected connections: %+v", out)
+	}
+}
+
+func TestReadResource_Listings(t *testing.T) {
+	mock := NewMockTrinoClient()
+	mock.ListCatalogsFunc = func(_ context.Context) ([]string, error) {
+		return []string{"hive", "my catalog"}, nil
+	}
+	mock.ListSchemasFunc = func(_ context.Context, _ string) ([]string, error) {
+		return []string{"sales"}, nil
+	}
+	mock.ListTablesFunc = func(_ context.Context, catalog, schema string) ([]client.TableInfo, error) {
+		return []client.TableInfo{{Catalog: catalog, Schema: schema, Name: "orders"}}, nil
+	}
+	session := connectResources(t, NewToolkit(mock, DefaultConfig()))
+
+	tests := []struct {
+		name      string
+		uri       string
+		wantLevel string
+		wantItems []string
+		wantURIs  []string
+	}{
+		{
+			name:      "catalogs",
+			uri:       "trino://default",
+			wantLevel: "catalogs",
+			wantItems: []string{"hive", "my catalog"},
+			wantURIs:  []string{"trino://default/hive", "trino://default/my%20catalog"},
+		},
+		{
+			name:      "schemas",
+			uri:       "trino://default/hive",
+			wantLevel: "schemas",
+			wantItems: []string{"sales"},
+			wantURIs:  []string{"trino://default/hive/sales"},
+		},
+		{
+			name:      "tables",
+			uri:       "trino://default/hive/sales",
+			wantLevel: "tables",
+			wantItems: []string{"orders"},
+			wantURIs:  []string{"trino://default/hive/sales/orders"},
+		},
+		{
+			name:      "escaped segment",
+			uri:       "trino://default/my%20catalog",
+			wantLevel: "schemas",
+			wantItems: []string{"sales"},
+			wantURIs:  []string{"trino://default/my%20catalog/sales"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out ResourceListing
+			readJSONResource(t, session, tt.uri, &out)
+
+			if out.Connection != "default" {
+				t.Errorf("expected connection default, got %q", out.Connection)
+			}
+			if out.Level != tt.wantLevel {
+				t.Errorf("expected level %s, got %s", tt.wantLevel, out.Level)
+			}
+			if out.Count != len(tt.wantItems) {
+				t.Errorf("expected count %d, got %d", len(tt.wantItems), out.Count)
+			}
+			for i := range tt.wantItems {
+				if out.Items[i] != tt.wantItems[i] || out.URIs[i] != tt.wantURIs[i] {
+					t.Errorf("item %d: got %s (%s), want %s (%s)", i, out.Items[i], out.URIs[i], tt.wantItems[i], tt.wantURIs[i])
+				}
+			}
+		})
+	}
+
+	if mock.ListSchemasCatalog != "my catalog" {
+		t.Errorf("expected unescaped catalog, got %q", mock.ListSchemasCatalog)
+	}
+}
+
+func TestReadResource_Table(t *testing.T) {
+	mock := NewMockTrinoClient()
+	mock.DescribeTableFunc = func(_ context.Context, catalog, schema, table string) (*client.TableInfo, error) {
+		return &client.TableInfo{
+			Catalog: catalog,
+			Schema:  schema,
+			Name:    table,
+			Columns: []client.ColumnDef{
+				{Name: "id", Type: "bigint"},
+				{Name: "email", Type: "varchar"},
+			},
+		}, nil
+	}
+
+	var gotID semantic.TableIdentifier
+	provider := &semantic.ProviderFunc{
+		NameFn: func() string { return "test" },
+		GetTableContextFn: func(_ context.Context, id semantic.TableIdentifier) (*semantic.TableContext, error) {
+			gotID = id
+			return &semantic.TableContext{Description: "Customer orders"}, nil
+		},
+		GetColumnsContextFn: func(_ context.Context, _ semantic.TableIdentifier) (map[string]*semantic.ColumnContext, error) {
+			return map[string]*semantic.ColumnContext{
+				"email": {Description: "Contact email", IsSensitive: true},
+			}, nil
+		},
+	}
+	session := connectResources(t, NewToolkit(mock, DefaultConfig(), WithSemanticProvider(provider)))
+
+	var out TableResource
+	readJSONResource(t, session, "trino://default/hive/sales/orders", &out)
+
+	if out.Catalog != "hive" || out.Schema != "sales" || out.Table != "orders" {
+		t.Errorf("unexpected table: %s.%s.%s", out.Catalog, out.Schema, out.Table)
+	}
+	if out.Count != 2 || out.Columns[1].Name != "email" {
+		t.Errorf("unexpected columns: %+v", out.Columns)
+	}
+	if out.Context == nil || out.Context.Description != "Customer orders" {
+		t.Errorf("expected table context, got %+v", out.Context)
+	}
+	if col := out.ColumnContexts["email"]; col == nil || !col.IsSensitive {
+		t.Errorf("expected sensitive email column context, got %+v", col)
+	}
+	if gotID.Connection != "" {
+		t.Errorf("expected default connection to map to empty semantic connection, got %q", gotID.Connection)
+	}
+}
+
+func TestReadResource_Errors(t *testing.T) {
+	mock := NewMockTrinoClient()
+	mock.DescribeTableFunc = func(_ context.Context, _, _, _ string) (*client.TableInfo, error) {
+		return nil, errors.New("table not found")
+	}
+	session := connectResources(t, NewToolkit(mock, DefaultConfig()))
+
+	uris := []string{
+		"trino://default/hive/sales/missing",
+		"trino://default/hive/sales/orders/extra",
+		"trino://default//sales",
+	}
+	for _, uri := range uris {
+		t.Run(uri, func(t *testing.T) {
+			_, err := session.ReadResource(context.Background(), &mcp.ReadResourceParams{URI: uri})
+			if err == nil {
+				t.Errorf("expected error reading %s", uri)
+			}
+		})
+	}
+}
+
+func TestParseResourceURI(t *testing.T) {
+	tests := []struct {
+		uri     string
+		want    []string
+		wantErr bool
+	}{
+		{uri: "trino://prod", want: []string{"prod"}},
+		{uri: "trino://prod/hive/sales/orders", want: []string{"prod", "hive", "sales", "orders"}},
+		{uri: "trino://prod/my%2Fcatalog", want: []string{"prod", "my/catalog"}},
+		{uri: "trino://", wantErr: true},
+		{uri: "trino://prod/", wantErr: true},
+		{uri: "trino://prod/a/b/c/d", wantErr: true},
+		{uri: "trino://prod/%zz", wantErr: true},
+		{uri: "file:///etc/passwd", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.uri, func(t *testing.T) {
+			got, err := parseResourceURI(tt.uri)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseResourceURI(%q) error = %v, wantErr %v", tt.uri, err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("parseResourceURI(%q) = %v, want %v", tt.uri, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("segment %d = %q, want %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestResourceURI(t *testing.T) {
+	if got := ResourceURI("prod", "hive", "my/schema"); got != "trino://prod/hive/my%2Fschema" {
+		t.Errorf("unexpected URI %s", got)
+	}
+	parts, err := parseResourceURI(ResourceURI("prod", "a b", "c/d"))
+	if err != nil || parts[1] != "a b" || parts[2] != "c/d" {
+		t.Errorf("round trip failed: %v %v", parts, err)
+	}
+}
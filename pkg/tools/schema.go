@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -26,6 +27,12 @@ type DescribeTableInput struct {
 	// IncludeSample includes a sample of data rows.
 	IncludeSample bool `json:"include_sample,omitempty" jsonschema_description:"Include a 5-row sample of data"`
 
+	// IncludeDDL includes the SHOW CREATE statement of the table or view.
+	IncludeDDL bool `json:"include_ddl,omitempty" jsonschema_description:"Include the SHOW CREATE TABLE or SHOW CREATE VIEW statement"`
+
+	// IncludeProperties includes the table properties and partitioning.
+	IncludeProperties bool `json:"include_properties,omitempty" jsonschema_description:"Include table properties: format, location, partition columns, bucketing, and sort order"` //nolint:lll // struct tag
+
 	// Connection is the named connection to use. Empty uses the default connection.
 	// Use trino_list_connections to see available connections.
 	Connection string `json:"connection,omitempty" jsonschema_description:"Named connection to use (see trino_list_connections)"`
@@ -75,17 +82,27 @@ func (t *Toolkit) handleDescribeTable(
 		return ErrorResult(fmt.Sprintf("Connection error: %v", err)), nil, nil
 	}
 
+	ctx = WithConnection(ctx, input.Connection)
+	if err := t.checkObject(ctx, input.Catalog, input.Schema, input.Table); err != nil {
+		return ErrorResult(err.Error()), nil, nil
+	}
+
 	info, err := trinoClient.DescribeTable(ctx, input.Catalog, input.Schema, input.Table)
 	if err != nil {
 		return ErrorResult(fmt.Sprintf("Failed to describe table: %v", err)), nil, nil
 	}
 
-	output := fmt.Sprintf("## Table: `%s.%s.%s`\n\n", info.Catalog, info.Schema, info.Name)
+	output := fmt.Sprintf("## %s: `%s.%s.%s`\n\n", tableTypeTitle(info.Type), info.Catalog, info.Schema, info.Name)
+	output += formatTableHeader(info)
 	output += t.formatTableWithSemantics(ctx, input, info)
 
 	// Build structured output
 	describeOutput := buildDescribeOutput(input, info)
 
+	if input.IncludeDDL || input.IncludeProperties {
+		output += t.describeDefinition(ctx, trinoClient, input, info, &describeOutput)
+	}
+
 	if input.IncludeSample {
 		sampleRows, sampleOutput, err := t.formatSampleData(ctx, trinoClient, input)
 		if err != nil {
@@ -117,6 +134,33 @@ func validateDescribeTableInput(input DescribeTableInput) error {
 	return nil
 }
 
+// tableTypeTitle returns the heading for a client.TableInfo type, e.g.
+// "Materialized View".
+func tableTypeTitle(tableType string) string {
+	switch tableType {
+	case client.TableTypeView:
+		return "View"
+	case client.TableTypeMaterializedView:
+		return "Materialized View"
+	default:
+		return "Table"
+	}
+}
+
+// formatTableHeader renders the table comment and, for views, a reminder
+// that reading one runs its defining query.
+func formatTableHeader(info *client.TableInfo) string {
+	var sb strings.Builder
+	if info.Comment != "" {
+		fmt.Fprintf(&sb, "**Comment:** %s\n\n", info.Comment)
+	}
+	if info.Type == client.TableTypeView {
+		sb.WriteString("*This is a view: every query against it runs its defining query, " +
+			"including any joins, so it can be far more expensive than a table of the same size.*\n\n")
+	}
+	return sb.String()
+}
+
 func buildDescribeOutput(input DescribeTableInput, info *client.TableInfo) DescribeTableOutput {
 	cols := make([]DescribeColumn, len(info.Columns))
 	for i, c := range info.Columns {
@@ -131,6 +175,8 @@ func buildDescribeOutput(input DescribeTableInput, info *client.TableInfo) Descr
 		Catalog: input.Catalog,
 		Schema:  input.Schema,
 		Table:   input.Table,
+		Type:    info.Type,
+		Comment: info.Comment,
 		Columns: cols,
 		Count:   len(cols),
 	}
@@ -194,6 +240,113 @@ func formatBasicColumns(columns []client.ColumnDef) string {
 	return sb.String()
 }
 
+// showCreateStatement returns the SHOW CREATE statement for the object
+// described by info.
+func showCreateStatement(info *client.TableInfo) string {
+	kind := "TABLE"
+	switch info.Type {
+	case client.TableTypeView:
+		kind = "VIEW"
+	case client.TableTypeMaterializedView:
+		kind = "MATERIALIZED VIEW"
+	}
+	return fmt.Sprintf("SHOW CREATE %s %s.%s.%s", kind,
+		client.QuoteIdentifier(info.Catalog),
+		client.QuoteIdentifier(info.Schema),
+		client.QuoteIdentifier(info.Name))
+}
+
+// describeDefinition fetches the object's SHOW CREATE statement and adds
+// the sections input asks for, DDL and table properties, to out. It
+// returns their text. Like the sample, the definition is best-effort: if
+// it cannot be fetched, the text says so and out is left unchanged.
+func (t *Toolkit) describeDefinition(
+	ctx context.Context, trinoClient TrinoClient, input DescribeTableInput, info *client.TableInfo,
+	out *DescribeTableOutput,
+) string {
+	result, err := trinoClient.Query(ctx, showCreateStatement(info), client.DefaultQueryOptions())
+	if err != nil {
+		return fmt.Sprintf("\n\n*Table definition unavailable: %v*", err)
+	}
+	if len(result.Rows) == 0 || len(result.Columns) == 0 {
+		return "\n\n*Table definition unavailable: SHOW CREATE returned no rows*"
+	}
+	ddl, _ := result.Rows[0][result.Columns[0].Name].(string)
+
+	var sb strings.Builder
+	if input.IncludeProperties {
+		props := buildTableProperties(client.ParseTableDDL(ddl))
+		out.Properties = props
+		sb.WriteString(formatTableProperties(props))
+	}
+	if input.IncludeDDL {
+		out.DDL = ddl
+		sb.WriteString("\n\n### DDL\n\n```sql\n" + ddl + "\n```")
+	}
+	return sb.String()
+}
+
+func buildTableProperties(ddl client.TableDDL) *DescribeTableProperties {
+	return &DescribeTableProperties{
+		Format:           ddl.Format,
+		Location:         ddl.Location,
+		Partitioning:     ddl.Partitioning,
+		PartitionColumns: ddl.PartitionColumns,
+		BucketedBy:       ddl.BucketedBy,
+		BucketCount:      ddl.BucketCount,
+		SortedBy:         ddl.SortedBy,
+		All:              ddl.Properties,
+	}
+}
+
+// formatTableProperties renders the partitioning and the table properties.
+// Partition columns come first: filtering on them is what keeps a query
+// on a large table from reading all of it.
+func formatTableProperties(p *DescribeTableProperties) string {
+	var sb strings.Builder
+	sb.WriteString("\n\n### Partitioning\n\n")
+	if len(p.PartitionColumns) == 0 {
+		sb.WriteString("*Not partitioned.*")
+	} else {
+		fmt.Fprintf(&sb, "**Partition columns:** %s — filter on these so Trino can skip partitions.",
+			backtickList(p.PartitionColumns))
+		if !slices.Equal(p.Partitioning, p.PartitionColumns) {
+			fmt.Fprintf(&sb, "\n\n**Partitioning:** %s", backtickList(p.Partitioning))
+		}
+	}
+	if len(p.BucketedBy) > 0 {
+		fmt.Fprintf(&sb, "\n\n**Bucketed by:** %s into %d buckets", backtickList(p.BucketedBy), p.BucketCount)
+	}
+	if len(p.SortedBy) > 0 {
+		fmt.Fprintf(&sb, "\n\n**Sorted by:** %s", backtickList(p.SortedBy))
+	}
+
+	if len(p.All) == 0 {
+		return sb.String()
+	}
+	sb.WriteString("\n\n### Table Properties\n\n")
+	sb.WriteString("| Property | Value |\n")
+	sb.WriteString("|----------|-------|\n")
+	names := make([]string, 0, len(p.All))
+	for name := range p.All {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		fmt.Fprintf(&sb, "| `%s` | `%s` |\n", name, p.All[name])
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// backtickList renders values as a comma-separated list of code spans.
+func backtickList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = "`" + v + "`"
+	}
+	return strings.Join(quoted, ", ")
+}
+
 // formatSampleData fetches up to 5 sample rows and returns them both as raw rows
 // (for the structured output's Sample field, #574) and as a formatted text block.
 // A failed or empty sample is best-effort: it returns nil rows and an empty
@@ -0,0 +1,24 @@
+package tools
+
+import (
+	"context"
+)
+
+// sensitiveAcknowledgedKey is the context key for a tool caller's
+// acknowledgement that a query may return sensitive columns.
+type sensitiveAcknowledgedKey struct{}
+
+// WithSensitiveAcknowledged returns a new context recording whether the
+// caller set acknowledge_sensitive. trino_query and trino_execute set it
+// before running query interceptors, so a guard on sensitive columns can
+// let acknowledged queries through.
+func WithSensitiveAcknowledged(ctx context.Context, acknowledged bool) context.Context {
+	return context.WithValue(ctx, sensitiveAcknowledgedKey{}, acknowledged)
+}
+
+// GetSensitiveAcknowledged reports whether the caller acknowledged reading
+// sensitive columns.
+func GetSensitiveAcknowledged(ctx context.Context) bool {
+	ack, _ := ctx.Value(sensitiveAcknowledgedKey{}).(bool) //nolint:errcheck // type assertion ok is unused by design
+	return ack
+}
@@ -0,0 +1,34 @@
+package tools
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestSensitiveAcknowledged(t *testing.T) {
+	if GetSensitiveAcknowledged(context.Background()) {
+		t.Error("expected no acknowledgement in an empty context")
+	}
+	if !GetSensitiveAcknowledged(WithSensitiveAcknowledged(context.Background(), true)) {
+		t.Error("expected acknowledgement")
+	}
+}
+
+func TestInterceptSQL_SensitiveAcknowledged(t *testing.T) {
+	var got []bool
+	interceptor := QueryInterceptorFunc(func(ctx context.Context, sql string, _ ToolName) (string, error) {
+		got = append(got, GetSensitiveAcknowledged(ctx))
+		return sql, nil
+	})
+	toolkit := NewToolkit(NewMockTrinoClient(), DefaultConfig(), WithQueryInterceptor(interceptor))
+	ctx := context.Background()
+
+	_, _, _ = toolkit.handleQuery(ctx, nil, QueryInput{SQL: "SELECT 1", AcknowledgeSensitive: true})
+	_, _, _ = toolkit.handleQuery(ctx, nil, QueryInput{SQL: "SELECT 1"})
+	_, _, _ = toolkit.handleExecute(ctx, nil, ExecuteInput{SQL: "SELECT 1", AcknowledgeSensitive: true})
+
+	if want := []bool{true, false, true}; !reflect.DeepEqual(got, want) {
+		t.Errorf("interceptor saw acknowledgements %v, want %v", got, want)
+	}
+}
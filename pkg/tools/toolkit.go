@@ -25,6 +25,17 @@ type Config struct {
 
 	// MaxTimeout is the maximum allowed query timeout. Default: 300s.
 	MaxTimeout time.Duration
+
+	// Impersonate runs Trino queries as the authenticated principal
+	// (sent as X-Trino-User) instead of the configured connection user.
+	// Has no effect for unauthenticated callers. Default: false.
+	Impersonate bool
+
+	// AllowedSessionProperties lists the Trino session properties callers
+	// may set through the session_properties argument of trino_query and
+	// trino_execute, e.g. "query_max_execution_time" or
+	// "hive.parquet_use_column_names". Default: none.
+	AllowedSessionProperties []string
 }
 
 // DefaultConfig returns a Config with sensible defaults.
@@ -45,10 +56,12 @@ type Toolkit struct {
 	config  Config
 
 	// Extensibility hooks (all optional, zero-value = no overhead)
-	middlewares     []ToolMiddleware              // Global middleware
-	interceptors    []QueryInterceptor            // SQL interceptors
-	transformers    []ResultTransformer           // Result transformers
-	toolMiddlewares map[ToolName][]ToolMiddleware // Per-tool middleware
+	middlewares        []ToolMiddleware              // Global middleware
+	interceptors       []QueryInterceptor            // SQL interceptors
+	transformers       []ResultTransformer           // Result transformers
+	outputTransformers []QueryOutputTransformer      // Structured query output transformers
+	objectFilters      []ObjectFilter                // Schema object filters
+	toolMiddlewares    map[ToolName][]ToolMiddleware // Per-tool middleware
 
 	// Semantic layer (optional, zero-overhead if nil)
 	semanticProvider    semantic.Provider
@@ -66,6 +79,12 @@ type Toolkit struct {
 	// Icon overrides (toolkit-level)
 	icons map[ToolName][]mcp.Icon
 
+	// Custom prompts registered alongside the built-in prompts
+	prompts []Prompt
+
+	// Cached names for argument completion
+	completions *completionIndex
+
 	// Internal tracking
 	registeredTools map[ToolName]bool
 }
@@ -107,6 +126,7 @@ func newBaseToolkit(cfg Config) *Toolkit {
 		annotations:     make(map[ToolName]*mcp.ToolAnnotations),
 		icons:           make(map[ToolName][]mcp.Icon),
 		registeredTools: make(map[ToolName]bool),
+		completions:     newCompletionIndex(DefaultCompletionCacheTTL),
 	}
 }
 
@@ -208,7 +228,8 @@ func (t *Toolkit) InterceptSQL(ctx context.Context, sql string, toolName ToolNam
 }
 
 // wrapHandler wraps a handler with middleware and transformer support.
-// Returns the original handler if no middleware is configured (zero overhead).
+// The caller's principal is always resolved into the context; beyond that,
+// no work is added if no middleware or transformers are configured.
 func (t *Toolkit) wrapHandler(
 	name ToolName,
 	handler func(ctx context.Context, req *mcp.CallToolRequest, input any) (*mcp.CallToolResult, any, error),
@@ -222,13 +243,17 @@ func (t *Toolkit) wrapHandler(
 		allMiddlewares = append(allMiddlewares, cfg.middlewares...) // Per-registration
 	}
 
-	// If no middleware or transformers configured, return handler unchanged
+	// If no middleware or transformers configured, only resolve the caller
 	if len(allMiddlewares) == 0 && len(t.transformers) == 0 {
-		return handler
+		return func(ctx context.Context, req *mcp.CallToolRequest, input any) (*mcp.CallToolResult, any, error) {
+			return handler(t.callerContext(ctx, toolRequestExtra(req)), req, input)
+		}
 	}
 
 	return func(ctx context.Context, req *mcp.CallToolRequest, input any) (*mcp.CallToolResult, any, error) {
+		ctx = t.callerContext(ctx, toolRequestExtra(req))
 		tc := NewToolContext(name, input)
+		tc.Principal = GetPrincipal(ctx)
 
 		// Run Before hooks
 		var err error
@@ -295,6 +320,16 @@ func (t *Toolkit) HasTransformers() bool {
 	return len(t.transformers) > 0
 }
 
+// HasQueryOutputTransformers returns true if any query output transformers are configured.
+func (t *Toolkit) HasQueryOutputTransformers() bool {
+	return len(t.outputTransformers) > 0
+}
+
+// HasObjectFilters returns true if any object filters are configured.
+func (t *Toolkit) HasObjectFilters() bool {
+	return len(t.objectFilters) > 0
+}
+
 // NewToolkitWithManager creates a Toolkit with multi-server support.
 // Use this when you need to connect to multiple Trino servers.
 func NewToolkitWithManager(mgr *multiserver.Manager, cfg Config, opts ...ToolkitOption) *Toolkit {
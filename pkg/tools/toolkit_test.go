@@ -196,6 +196,39 @@ func TestNewToolkitWithManager(t *testing.T) {
 	}
 }
 
+func TestListConnections_PoolStats(t *testing.T) {
+	mgr := multiserver.NewManager(multiserver.Config{
+		Default: "prod",
+		Primary: client.Config{
+			Host: "localhost",
+			Port: 8080,
+			User: "admin",
+			Pool: client.PoolConfig{MaxOpenConns: 25},
+		},
+		Connections: map[string]multiserver.ConnectionConfig{
+			"staging": {Host: "staging.example.com"},
+		},
+	})
+	defer func() { _ = mgr.Close() }()
+	if _, err := mgr.Client("prod"); err != nil {
+		t.Fatalf("Client(prod) error: %v", err)
+	}
+
+	output := NewToolkitWithManager(mgr, DefaultConfig()).listConnections()
+	for _, conn := range output.Connections {
+		switch conn.Name {
+		case "prod":
+			if conn.Pool == nil || conn.Pool.MaxOpen != 25 || conn.Pool.InUse != 0 {
+				t.Errorf("unexpected prod pool: %+v", conn.Pool)
+			}
+		case "staging":
+			if conn.Pool != nil {
+				t.Errorf("expected no pool stats for unused connection, got %+v", conn.Pool)
+			}
+		}
+	}
+}
+
 func TestNewToolkitWithManager_DefaultsZeroValues(t *testing.T) {
 	msCfg := multiserver.Config{
 		Default: "default",
@@ -57,3 +57,34 @@ func (tc *TransformerChain) Append(transformers ...ResultTransformer) {
 func (tc *TransformerChain) Len() int {
 	return len(tc.transformers)
 }
+
+// QueryOutputTransformer modifies the structured result of trino_query and
+// trino_execute before it is formatted, so changes such as redaction reach
+// the JSON, CSV, and markdown text and the structured output alike.
+// ResultTransformers, by contrast, only see the formatted text.
+type QueryOutputTransformer interface {
+	// TransformQueryOutput modifies output, the result of sql, in place.
+	// Return an error to replace the result with an error result.
+	TransformQueryOutput(ctx context.Context, toolName ToolName, sql string, output *QueryOutput) error
+}
+
+// QueryOutputTransformerFunc allows using a function as a query output transformer.
+type QueryOutputTransformerFunc func(ctx context.Context, toolName ToolName, sql string, output *QueryOutput) error
+
+// TransformQueryOutput implements QueryOutputTransformer.
+func (f QueryOutputTransformerFunc) TransformQueryOutput(
+	ctx context.Context, toolName ToolName, sql string, output *QueryOutput,
+) error {
+	return f(ctx, toolName, sql, output)
+}
+
+// transformQueryOutput runs all query output transformers in order,
+// stopping at the first error.
+func (t *Toolkit) transformQueryOutput(ctx context.Context, toolName ToolName, sql string, output *QueryOutput) error {
+	for _, tr := range t.outputTransformers {
+		if err := tr.TransformQueryOutput(ctx, toolName, sql, output); err != nil {
+			return err
+		}
+	}
+	return nil
+}
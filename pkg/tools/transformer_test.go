@@ -3,6 +3,7 @@ package tools
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -180,3 +181,59 @@ func TestTransformerChain_ToolNamePassed(t *testing.T) {
 		t.Errorf("expected ToolDescribeTable, got %v", receivedToolName)
 	}
 }
+
+func TestQueryOutputTransformer_Handlers(t *testing.T) {
+	var seen []string
+	mask := QueryOutputTransformerFunc(func(_ context.Context, toolName ToolName, sql string, output *QueryOutput) error {
+		seen = append(seen, string(toolName)+": "+sql)
+		for _, row := range output.Rows {
+			row["name"] = "***"
+		}
+		return nil
+	})
+	toolkit := NewToolkit(NewMockTrinoClient(), DefaultConfig(), WithQueryOutputTransformer(mask))
+	if !toolkit.HasQueryOutputTransformers() {
+		t.Fatal("expected query output transformers")
+	}
+	ctx := context.Background()
+
+	for _, format := range []string{"json", "csv", "markdown"} {
+		result, out, err := toolkit.handleQuery(ctx, nil, QueryInput{SQL: "SELECT id, name FROM users", Format: format})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		text := resultText(t, result)
+		if strings.Contains(text, "Alice") || !strings.Contains(text, "***") {
+			t.Errorf("%s output not masked: %s", format, text)
+		}
+		if qo, ok := out.(*QueryOutput); !ok || qo.Rows[0]["name"] != "***" {
+			t.Errorf("structured output not masked: %+v", out)
+		}
+	}
+
+	result, _, _ := toolkit.handleExecute(ctx, nil, ExecuteInput{SQL: "SELECT name FROM users"})
+	if strings.Contains(resultText(t, result), "Alice") {
+		t.Errorf("execute output not masked: %s", resultText(t, result))
+	}
+	if want := "trino_execute: SELECT name FROM users"; seen[len(seen)-1] != want {
+		t.Errorf("transformer saw %q, want %q", seen[len(seen)-1], want)
+	}
+}
+
+func TestQueryOutputTransformer_Error(t *testing.T) {
+	fail := QueryOutputTransformerFunc(func(_ context.Context, _ ToolName, _ string, _ *QueryOutput) error {
+		return errors.New("lookup failed")
+	})
+	toolkit := NewToolkit(NewMockTrinoClient(), DefaultConfig(), WithQueryOutputTransformer(fail))
+
+	result, out, err := toolkit.handleQuery(context.Background(), nil, QueryInput{SQL: "SELECT 1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.IsError || !strings.Contains(resultText(t, result), "lookup failed") {
+		t.Errorf("expected error result, got %q", resultText(t, result))
+	}
+	if out != nil {
+		t.Errorf("expected no structured output, got %+v", out)
+	}
+}